@@ -9,12 +9,15 @@ import (
 	"os"
 
 	"github.com/caarlos0/env/v10"
+	"github.com/samber/lo"
 
 	"github.com/grafviktor/goto/internal/config"
+	"github.com/grafviktor/goto/internal/i18n"
 	"github.com/grafviktor/goto/internal/logger"
 	"github.com/grafviktor/goto/internal/state"
 	"github.com/grafviktor/goto/internal/storage"
 	"github.com/grafviktor/goto/internal/ui"
+	"github.com/grafviktor/goto/internal/ui/theme"
 	"github.com/grafviktor/goto/internal/utils"
 	"github.com/grafviktor/goto/internal/version"
 )
@@ -45,10 +48,31 @@ func main() {
 
 	commandLineParams := config.User{}
 	displayApplicationDetailsAndExit := false
+	exportStatsPath := ""
+	importHostsDir := ""
+	importConflictPolicy := ""
+	exportHostsDir := ""
+	exportPasswords := false
 	// Command line parameters have the highest precedence
 	flag.BoolVar(&displayApplicationDetailsAndExit, "v", false, "Display application details")
 	flag.StringVar(&commandLineParams.AppHome, "f", environmentParams.AppHome, "Application home folder")
 	flag.StringVar(&commandLineParams.LogLevel, "l", environmentParams.LogLevel, "Log verbosity level: debug, info")
+	flag.StringVar(
+		(*string)(&commandLineParams.TitleDerivation),
+		"t",
+		string(environmentParams.TitleDerivation),
+		"How to generate title of a new host from its address: full, short",
+	)
+	flag.StringVar(&exportStatsPath, "s", "", "Export connection stats of hosts to a CSV file and exit, '-' is stdout")
+	flag.StringVar(&importHostsDir, "i", "", "Import hosts from a folder with one YAML file per host and exit")
+	flag.StringVar(
+		&importConflictPolicy,
+		"c",
+		string(storage.ConflictSkip),
+		"What to do with imported hosts which titles are taken: skip, overwrite, rename",
+	)
+	flag.StringVar(&exportHostsDir, "e", "", "Export hosts to a folder with one YAML file per host and exit")
+	flag.BoolVar(&exportPasswords, "p", false, "Keep passwords in the files which are exported using '-e'")
 	flag.Parse()
 
 	var err error
@@ -71,6 +95,32 @@ func main() {
 
 	// Create application configuration and set application home folder
 	appConfig := config.Merge(environmentParams, commandLineParams, &lg)
+	appConfig.Placeholders, err = config.LoadPlaceholders(appConfig.AppHome)
+	if err != nil {
+		lg.Error("[MAIN] Can't load placeholders, default values are used: %v", err)
+	}
+	appConfig.Resolver, err = config.LoadResolver(appConfig.AppHome)
+	if err != nil {
+		lg.Error("[MAIN] Can't load resolver, addresses are not resolved: %v", err)
+	}
+
+	// Select language of the user interface. Locale variables are checked in the same order as gettext does.
+	if utils.StringEmpty(appConfig.Language) {
+		appConfig.Language = i18n.LanguageFromLocale(lo.CoalesceOrEmpty(
+			os.Getenv("LC_ALL"),
+			os.Getenv("LC_MESSAGES"),
+			os.Getenv("LANG"),
+		))
+	}
+	if !i18n.SetLanguage(appConfig.Language) {
+		lg.Info("[MAIN] Translation for language '%s' is not available, using '%s'", appConfig.Language, i18n.DefaultLanguage)
+	}
+	lg.Debug("[MAIN] Set user interface language to %s", i18n.Language())
+
+	if !theme.Set(appConfig.Theme) {
+		lg.Info("[MAIN] Theme '%s' is not available, using '%s'", appConfig.Theme, theme.DefaultTheme)
+	}
+	lg.Debug("[MAIN] Set user interface theme to %s", theme.Name())
 
 	// If "-v" parameter provided, display application version configuration and exit
 	if displayApplicationDetailsAndExit {
@@ -94,12 +144,52 @@ func main() {
 	ctx := context.Background()
 	application := config.NewApplication(ctx, appConfig, &lg)
 	appState := state.Get(application.Config.AppHome, &lg)
+	appState.ApplicationConfig = application.Config
 	storage, err := storage.Get(ctx, application)
 	if err != nil {
 		lg.Error("[MAIN] Error running application: %v", err)
 		os.Exit(1)
 	}
 
+	// If "-s" parameter provided, export connection stats and exit
+	if exportStatsPath != "" {
+		lg.Info("[MAIN] Export connection stats to '%s'", exportStatsPath)
+		if err = exportStats(storage, exportStatsPath); err != nil {
+			lg.Error("[MAIN] Can't export connection stats: %v", err)
+			log.Fatalf("[MAIN] Can't export connection stats: %v", err)
+		}
+
+		os.Exit(0)
+	}
+
+	// If "-i" parameter provided, import hosts and exit
+	if importHostsDir != "" {
+		lg.Info("[MAIN] Import hosts from '%s'", importHostsDir)
+		result, err := importHosts(storage, importHostsDir, importConflictPolicy)
+		if err != nil {
+			lg.Error("[MAIN] Can't import hosts: %v", err)
+			log.Fatalf("[MAIN] Can't import hosts: %v", err)
+		}
+
+		summary := fmt.Sprintf("Hosts created: %d, overwritten: %d, renamed: %d, skipped: %d",
+			result.Created, result.Overwritten, result.Renamed, result.Skipped)
+		lg.Info("[MAIN] %s", summary)
+		fmt.Println(summary)
+
+		os.Exit(0)
+	}
+
+	// If "-e" parameter provided, export hosts and exit
+	if exportHostsDir != "" {
+		lg.Info("[MAIN] Export hosts to '%s'", exportHostsDir)
+		if err = exportHosts(storage, exportHostsDir, exportPasswords); err != nil {
+			lg.Error("[MAIN] Can't export hosts: %v", err)
+			log.Fatalf("[MAIN] Can't export hosts: %v", err)
+		}
+
+		os.Exit(0)
+	}
+
 	// Run user interface
 	ui.Start(ctx, storage, appState, &lg)
 
@@ -114,3 +204,52 @@ func main() {
 
 	lg.Info("[MAIN] Close application")
 }
+
+// importHosts - imports hosts from a folder with one YAML file per host. policyName decides what happens with
+// hosts which titles are taken, see storage.ConflictPolicy. Malformed files are printed, but they don't stop
+// the import unless there is nothing else to import.
+func importHosts(hostStorage storage.HostStorage, dirPath, policyName string) (storage.ImportResult, error) {
+	policy, err := storage.ParseConflictPolicy(policyName)
+	if err != nil {
+		return storage.ImportResult{}, err
+	}
+
+	hosts, err := storage.ReadHostsDir(dirPath)
+	if err != nil && len(hosts) == 0 {
+		return storage.ImportResult{}, err
+	} else if err != nil {
+		fmt.Printf("Some files are not imported:\n%v\n", err)
+	}
+
+	return storage.Import(hostStorage, hosts, storage.ApplyToAll(policy))
+}
+
+// exportHosts - writes every host into its own YAML file and prints the number of exported hosts.
+func exportHosts(hostStorage storage.HostStorage, dirPath string, withPasswords bool) error {
+	fileNames, err := storage.ExportHostsDir(hostStorage, dirPath, withPasswords)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Hosts exported: %d\n", len(fileNames))
+	return nil
+}
+
+// exportStats - writes connection stats of all hosts as CSV into a file, "-" means standard output.
+func exportStats(hostStorage storage.HostStorage, filePath string) error {
+	if filePath == "-" {
+		return storage.ExportStatsCSV(hostStorage, os.Stdout)
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err = storage.ExportStatsCSV(hostStorage, file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -5,10 +5,13 @@ go 1.22.0
 toolchain go1.23.2
 
 require (
+	github.com/atotto/clipboard v0.1.4
 	github.com/caarlos0/env/v10 v10.0.0
 	github.com/charmbracelet/bubbles v0.20.0
 	github.com/charmbracelet/bubbletea v1.1.1
 	github.com/charmbracelet/lipgloss v0.13.0
+	github.com/charmbracelet/x/ansi v0.3.2
+	github.com/fsnotify/fsnotify v1.7.0
 	github.com/samber/lo v1.47.0
 	github.com/stretchr/testify v1.8.4
 	golang.org/x/exp v0.0.0-20241004190924-225e2abe05e6
@@ -17,9 +20,7 @@ require (
 )
 
 require (
-	github.com/atotto/clipboard v0.1.4 // indirect
 	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
-	github.com/charmbracelet/x/ansi v0.3.2 // indirect
 	github.com/charmbracelet/x/term v0.2.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/erikgeiser/coninput v0.0.0-20211004153227-1c3628e74d0f // indirect
@@ -3,7 +3,21 @@ package config
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/grafviktor/goto/internal/constant"
+)
+
+const (
+	// placeholdersFile contains user-defined templates of input placeholders.
+	placeholdersFile = "placeholders.yaml"
+	// resolverFile contains user-defined addresses of short host names.
+	resolverFile = "resolver.yaml"
 )
 
 type iLogger interface {
@@ -15,14 +29,45 @@ type iLogger interface {
 
 // User structs contains user-definable parameters.
 type User struct {
-	AppHome  string `env:"GG_HOME"`
-	LogLevel string `env:"GG_LOG_LEVEL" envDefault:"info"`
+	AppHome         string                   `env:"GG_HOME"`
+	LogLevel        string                   `env:"GG_LOG_LEVEL" envDefault:"info"`
+	TitleDerivation constant.TitleDerivation `env:"GG_TITLE_DERIVATION" envDefault:"full"`
+	// Language of the user interface. When not set, it is derived from the system locale.
+	Language string `env:"GG_LANG"`
+	// Theme is a name of the color palette of the user interface, see theme.Names.
+	Theme string `env:"GG_THEME"`
+	// WarnLoopback is set when user should confirm connection to the local machine, it helps to catch
+	// misconfigured hosts, for instance a host which points to a locally forwarded port.
+	WarnLoopback bool `env:"GG_WARN_LOOPBACK"`
+	// ProbeRemoteOS is set when operating system of a remote host should be detected after connection.
+	ProbeRemoteOS bool `env:"GG_PROBE_REMOTE_OS"`
+	// EchoCommand is set when connect command, with password redacted, should be displayed for a moment before
+	// ssh takes over the screen.
+	EchoCommand bool `env:"GG_ECHO_COMMAND"`
+	// EditDraft is set when in-progress changes of the host edit form should be written to a draft file, which is
+	// restored when the form of the same host is opened next time.
+	EditDraft bool `env:"GG_EDIT_DRAFT"`
+	// PruneThreshold is a number of consecutive failed reachability checks after which a host is offered for pruning.
+	PruneThreshold int `env:"GG_PRUNE_THRESHOLD" envDefault:"3"`
+	// Placeholders contains templates of input placeholders, key is an input name. See LoadPlaceholders.
+	Placeholders map[string]string
+	// Resolver maps short host names to addresses, it's used in networks where names are not resolved by DNS.
+	// See LoadResolver.
+	Resolver map[string]string
 }
 
 // Print outputs user-definable parameters in the console.
 func (userConfig User) Print() {
-	fmt.Printf("App home:  %s\n", userConfig.AppHome)
-	fmt.Printf("Log level: %s\n", userConfig.LogLevel)
+	fmt.Printf("App home:         %s\n", userConfig.AppHome)
+	fmt.Printf("Log level:        %s\n", userConfig.LogLevel)
+	fmt.Printf("Title derivation: %s\n", userConfig.TitleDerivation)
+	fmt.Printf("Language:         %s\n", userConfig.Language)
+	fmt.Printf("Theme:            %s\n", userConfig.Theme)
+	fmt.Printf("Warn loopback:    %v\n", userConfig.WarnLoopback)
+	fmt.Printf("Probe remote OS:  %v\n", userConfig.ProbeRemoteOS)
+	fmt.Printf("Echo command:     %v\n", userConfig.EchoCommand)
+	fmt.Printf("Edit draft:       %v\n", userConfig.EditDraft)
+	fmt.Printf("Prune threshold:  %d\n", userConfig.PruneThreshold)
 }
 
 // Merge builds application configuration from user parameters and common objects. For instance - logger.
@@ -39,9 +84,43 @@ func Merge(envParams, cmdParams User, logger iLogger) User {
 	}
 	logger.Debug("[CONFIG] Set application log level to %s\n", envParams.LogLevel)
 
+	if len(cmdParams.TitleDerivation) > 0 {
+		envParams.TitleDerivation = cmdParams.TitleDerivation
+	}
+	logger.Debug("[CONFIG] Set title derivation mode to %s\n", envParams.TitleDerivation)
+
 	return envParams
 }
 
+// LoadPlaceholders - reads templates of input placeholders from "placeholders.yaml" file located
+// in the application home folder. Missing file is not an error, default placeholders are used then.
+func LoadPlaceholders(appHome string) (map[string]string, error) {
+	return loadMap(appHome, placeholdersFile)
+}
+
+// LoadResolver - reads addresses of short host names from "resolver.yaml" file located in the application
+// home folder, for instance "db1: 10.0.0.5". Missing file is not an error, addresses are not resolved then.
+func LoadResolver(appHome string) (map[string]string, error) {
+	return loadMap(appHome, resolverFile)
+}
+
+// loadMap - reads key-value pairs from a yaml file located in the application home folder.
+func loadMap(appHome, fileName string) (map[string]string, error) {
+	fileData, err := os.ReadFile(path.Join(appHome, fileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	if err = yaml.Unmarshal(fileData, &values); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", fileName, err)
+	}
+
+	return values, nil
+}
+
 // Application is a struct which contains logger, application context and user parameters.
 type Application struct {
 	Context context.Context
@@ -6,8 +6,15 @@ import "errors"
 // ErrNotFound is used by data layer.
 var ErrNotFound = errors.New("not found")
 
-// ProtocolSSH - is only supported protocol.
-const ProtocolSSH = "ssh"
+// Protocols which are used to connect to a remote host.
+const (
+	// ProtocolSSH - is a default protocol, host is connected using ssh client.
+	ProtocolSSH = "ssh"
+	// ProtocolTeleport - host is connected through Teleport proxy using tsh client.
+	ProtocolTeleport = "teleport"
+	// ProtocolKubectl - host is a Kubernetes pod, a shell is started in the pod using kubectl exec.
+	ProtocolKubectl = "kubectl"
+)
 
 // ScreenLayout is used to determine how the hostlist should be displayed.
 type ScreenLayout string
@@ -17,6 +24,20 @@ const (
 	ScreenLayoutTight ScreenLayout = "tight"
 	// ScreenLayoutNormal is set when all hosts are shown with description field and a margin.
 	ScreenLayoutNormal ScreenLayout = "normal"
+	// ScreenLayoutCompact is set when every host is shown in a single line which contains title and address.
+	ScreenLayoutCompact ScreenLayout = "compact"
+)
+
+// HostSortOrder is used to determine how hosts are sorted in the hostlist. Favorite hosts always go first.
+type HostSortOrder string
+
+const (
+	// HostSortGroup is set when hosts are displayed under group headers and sorted by title within a group.
+	HostSortGroup HostSortOrder = "group"
+	// HostSortTitle is set when hosts are sorted by title.
+	HostSortTitle HostSortOrder = "title"
+	// HostSortLastConnected is set when the most recently connected hosts go first.
+	HostSortLastConnected HostSortOrder = "lastConnected"
 )
 
 // ProcessType is used to determine what kind of external process is running.
@@ -29,4 +50,30 @@ const (
 	ProcessTypeSSHCopyID ProcessType = "ssh-copy-id"
 	// ProcessTypeSSHConnect is used when we want to connect to a remote host.
 	ProcessTypeSSHConnect ProcessType = "ssh-connect"
+	// ProcessTypeEditStorage is used when user edits hosts file in a text editor.
+	ProcessTypeEditStorage ProcessType = "edit-storage"
+	// ProcessTypeOpenURL is used when we open web url of a host in the default browser.
+	ProcessTypeOpenURL ProcessType = "open-url"
+	// ProcessTypeSSHFS is used when we need to run sshfs to mount a remote file system.
+	ProcessTypeSSHFS ProcessType = "sshfs"
+	// ProcessTypeSFTP is used when we open an interactive sftp session with a remote host.
+	ProcessTypeSFTP ProcessType = "sftp"
+	// ProcessTypeListAgentKeys is used when we need to run ssh-add -l to list keys loaded into ssh-agent.
+	ProcessTypeListAgentKeys ProcessType = "list-agent-keys"
+	// ProcessTypeProbeRemoteOS is used when we run uname on a remote host to detect its operating system.
+	ProcessTypeProbeRemoteOS ProcessType = "probe-remote-os"
+	// ProcessTypeSSHCheckAuth is used when we log in to a remote host without opening a shell to check authentication.
+	ProcessTypeSSHCheckAuth ProcessType = "ssh-check-auth"
+	// ProcessTypeDesktopNotify is used when we display a desktop notification, for instance when a tunnel is established.
+	ProcessTypeDesktopNotify ProcessType = "desktop-notify"
+)
+
+// TitleDerivation is used to determine how a title of a new host is generated from its address.
+type TitleDerivation string
+
+const (
+	// TitleDerivationFull is set when the title is equal to the full host address. Ex: web-01.example.com.
+	TitleDerivationFull TitleDerivation = "full"
+	// TitleDerivationShort is set when the domain part is stripped from the host address. Ex: web-01.
+	TitleDerivationShort TitleDerivation = "short"
 )
@@ -0,0 +1,227 @@
+package i18n
+
+// catalogDE - german translation of user interface strings.
+var catalogDE = map[string]string{
+	// Edit form labels
+	"Title":            "Titel",
+	"Host":             "Host",
+	"Command":          "Befehl",
+	"Description":      "Beschreibung",
+	"Group":            "Gruppe",
+	"Tags":             "Tags",
+	"Web URL":          "Web-URL",
+	"Banner":           "Banner",
+	"Login":            "Benutzer",
+	"Network Port":     "Netzwerkport",
+	"Identity File":    "Schlüsseldatei",
+	"Password":         "Passwort",
+	"Password Command": "Passwortbefehl",
+	"Gateway Ports":    "Gateway-Ports",
+	"Escape Character": "Escape-Zeichen",
+	"Bind Address":     "Bind-Adresse",
+	"Connect Template": "Verbindungsvorlage",
+	"Identities Only":  "Nur Schlüsseldatei",
+	"Compression":      "Komprimierung",
+	"Terminal Type":    "Terminaltyp",
+	"terminal type must be a single word, ex: xterm-256color": "Terminaltyp muss ein einzelnes Wort sein, z.B.: xterm-256color",
+	"Remote Forwards": "Entfernte Weiterleitungen",
+	"remote forward '%s' must be [bind_address:]port:host:port": "entfernte Weiterleitung '%s' muss [bind_address:]port:host:port sein",
+	"Dynamic Forward":                        "Dynamische Weiterleitung",
+	"Background Tunnel":                      "Tunnel im Hintergrund",
+	"Auto Connect":                           "Automatisch verbinden",
+	"Host Key Alias":                         "Host-Schlüssel-Alias",
+	"host key alias must not contain spaces": "Host-Schlüssel-Alias darf keine Leerzeichen enthalten",
+	"Extra Options":                          "Zusätzliche Optionen",
+	"extra option '%s' must be Key=Value":    "Zusätzliche Option '%s' muss Key=Value sein",
+	"Strict Host Key Checking":               "Strenge Host-Schlüssel-Prüfung",
+	"strict host key checking must be one of: yes, no, accept-new": "Strenge Host-Schlüssel-Prüfung muss einer der Werte sein: yes, no, accept-new",
+	"recent host":                 "letzter Host",
+	"no recent host to switch to": "kein letzter Host zum Wechseln",
+	"Connect Timeout":             "Verbindungs-Timeout",
+	"connect timeout must be a positive number of seconds": "Verbindungs-Timeout muss eine positive Anzahl von Sekunden sein",
+	"Keepalive Interval":                             "Keepalive-Intervall",
+	"Keepalive Count Max":                            "Keepalive-Maximalanzahl",
+	"value must be a non-negative number":            "Wert muss eine nicht negative Zahl sein",
+	"Local Forwards":                                 "Lokale Weiterleitungen",
+	"local forward '%s' must be port:host:port":      "lokale Weiterleitung '%s' muss port:host:port sein",
+	"Compression Level":                              "Komprimierungsstufe",
+	"compression level must be a number from 1 to 9": "Komprimierungsstufe muss eine Zahl von 1 bis 9 sein",
+	"ignored, compression is off":                    "wird ignoriert, Komprimierung ist aus",
+	"Forward Agent":                                  "Agent weiterleiten",
+	"Protocol":                                       "Protokoll",
+	"Teleport Cluster":                               "Teleport-Cluster",
+	"Namespace":                                      "Namespace",
+	"Pod":                                            "Pod",
+	"Container":                                      "Container",
+	"Alias":                                          "Kürzel",
+	"Notes":                                          "Notizen",
+
+	// Validation errors
+	"value is required": "Wert ist erforderlich",
+	"network port must be a number which is less than 65,535": "Netzwerkport muss eine Zahl kleiner als 65.535 sein",
+	"web url must be a valid http or https url":               "Web-URL muss eine gültige http- oder https-URL sein",
+	"gateway ports must be one of: yes, no, clientspecified":  "Gateway-Ports muss einer der Werte sein: yes, no, clientspecified",
+	"forward preset '%s' binds local port %s more than once":  "Weiterleitungsvorlage '%s' bindet den lokalen Port %s mehrfach",
+	"preset '%s' and local forwards bind port %s":             "Weiterleitungsvorlage '%s' und lokale Weiterleitungen binden Port %s",
+	"local port %s is forwarded more than once":               "lokaler Port %s wird mehrfach weitergeleitet",
+	"bind address must be an IP address":                      "Bind-Adresse muss eine IP-Adresse sein",
+	"address must be a hostname or an IP address":             "Adresse muss ein Hostname oder eine IP-Adresse sein",
+	"escape character must be a single character or none":     "Escape-Zeichen muss ein einzelnes Zeichen oder none sein",
+	"connect template is not valid, %v":                       "Verbindungsvorlage ist ungültig, %v",
+	"cannot read private keys from %s":                        "Private Schlüssel aus %s können nicht gelesen werden",
+	"no private keys found in %s":                             "Keine privaten Schlüssel in %s gefunden",
+	"title is required":                                       "Titel ist erforderlich",
+	"yaml is not valid: %v":                                   "YAML ist ungültig: %v",
+	"cannot convert host to yaml":                             "Host kann nicht in YAML umgewandelt werden",
+	"permissions %#o are too open, ssh will ignore the key":   "Berechtigungen %#o sind zu offen, ssh wird den Schlüssel ignorieren",
+	"host address is required to build connect command":       "Hostadresse wird für den Verbindungsbefehl benötigt",
+	"protocol must be one of: %s, %s, %s":                     "Protokoll muss einer der Werte sein: %s, %s, %s",
+	"alias must not contain spaces":                           "Kürzel darf keine Leerzeichen enthalten",
+	"alias is used by another host":                           "Kürzel wird von einem anderen Host verwendet",
+
+	// Edit form titles
+	"host details":    "Hostdetails",
+	"%s is not valid": "%s ist ungültig",
+	"cannot save host, connect command is empty": "Host kann nicht gespeichert werden, Verbindungsbefehl ist leer",
+	"cannot copy command to clipboard":           "Befehl kann nicht in die Zwischenablage kopiert werden",
+	"notes panel":                                "Notizenbereich",
+	"show password":                              "Passwort anzeigen",
+	"copy value":                                 "Wert kopieren",
+	"value is empty":                             "Wert ist leer",
+	"cannot copy value to clipboard":             "Wert kann nicht in die Zwischenablage kopiert werden",
+	"value copied to clipboard":                  "Wert in die Zwischenablage kopiert",
+	"command copied to clipboard":                "Befehl in die Zwischenablage kopiert",
+	"\"%s\" has the same address and login, press %s again to save": "\"%s\" hat dieselbe Adresse und denselben Benutzer, zum Speichern erneut %s drücken",
+
+	// Host list titles
+	"you must select an item":     "Sie müssen einen Eintrag auswählen",
+	"press 'n' to add a new host": "drücken Sie 'n', um einen neuen Host hinzuzufügen",
+	"delete \"%s\" ? (y/N)":       "\"%s\" löschen? (y/N)",
+	"permission denied (publickey). copy ssh key to the remote host? (y/N)": "Zugriff verweigert (publickey). SSH-Schlüssel auf den entfernten Host kopieren? (y/N)",
+	"copy ssh key to the remote host? (y/N)":                                "SSH-Schlüssel auf den entfernten Host kopieren? (y/N)",
+	"cannot read public key '%s'":                                           "Öffentlicher Schlüssel '%s' kann nicht gelesen werden",
+	"cannot copy public key to clipboard":                                   "Öffentlicher Schlüssel kann nicht in die Zwischenablage kopiert werden",
+	"public key copied to clipboard":                                        "Öffentlicher Schlüssel in die Zwischenablage kopiert",
+	"identity file is not set":                                              "Identitätsdatei ist nicht gesetzt",
+	"cannot copy identity file path to clipboard":                           "Pfad der Identitätsdatei kann nicht in die Zwischenablage kopiert werden",
+	"identity file path copied to clipboard: %s":                            "Pfad der Identitätsdatei in die Zwischenablage kopiert: %s",
+	"mount point is required":                                               "Einhängepunkt ist erforderlich",
+	"unsaved changes are restored from draft":                               "Ungespeicherte Änderungen wurden aus dem Entwurf wiederhergestellt",
+	"scratch":                           "Notizzettel",
+	"temporary host, it's lost on exit": "temporärer Host, geht beim Beenden verloren",
+	"never":                             "nie",
+	"just now":                          "gerade eben",
+	"%dm ago":                           "vor %d Min.",
+	"%dh ago":                           "vor %d Std.",
+	"%dd ago":                           "vor %d T.",
+	"%dmo ago":                          "vor %d Mon.",
+	"%dy ago":                           "vor %d J.",
+	"scratch host is not stored, clone it to keep it":           "Notizzettel-Host wird nicht gespeichert, klonen Sie ihn, um ihn zu behalten",
+	"host address is not set, press 'e' to edit the host":       "Hostadresse ist nicht gesetzt, drücken Sie 'e', um den Host zu bearbeiten",
+	"log path is not set":                                       "Log-Pfad ist nicht gesetzt",
+	"web url is not set":                                        "Web-URL ist nicht gesetzt",
+	"host connects to the local machine, connect anyway? (y/N)": "Host verbindet sich mit dem lokalen Rechner, trotzdem verbinden? (y/N)",
+	"local ports are in use: %s, connect anyway? (y/N)":         "Lokale Ports sind belegt: %s, trotzdem verbinden? (y/N)",
+	"tunnel to %s is not established, %s":                       "Tunnel zu %s wird nicht aufgebaut, %s",
+	"host connects to the local machine":                        "Host verbindet sich mit dem lokalen Rechner",
+	"local ports are in use: %s":                                "Lokale Ports sind belegt: %s",
+	"%s acknowledge and connect? (y/N)":                         "%s bestätigen und verbinden? (y/N)",
+	"clone to group: ":                                          "in Gruppe klonen: ",
+	"mount point: ":                                             "Einhängepunkt: ",
+	"%d hosts selected":                                         "%d Hosts ausgewählt",
+	"identity file of %d hosts: ":                               "Schlüsseldatei für %d Hosts: ",
+	"identity file is set for %d hosts":                         "Schlüsseldatei für %d Hosts gesetzt",
+	"identity file does not exist":                              "Schlüsseldatei existiert nicht",
+	"profiles: 0) default":                                      "Profile: 0) Standard",
+	"forwards: 0) none":                                         "Weiterleitungen: 0) keine",
+	"agent keys: 0) none":                                       "Agent-Schlüssel: 0) keiner",
+	"the agent has no keys":                                     "Der Agent hat keine Schlüssel",
+	"note: %s (any key to connect, esc to cancel)":              "Notiz: %s (beliebige Taste zum Verbinden, Esc zum Abbrechen)",
+	"identity files:":                                           "Identitätsdateien:",
+	"type \"%s\" to connect: ":                                  "Geben Sie \"%s\" ein, um zu verbinden: ",
+	"title does not match, connection is cancelled":             "Titel stimmt nicht überein, Verbindung wird abgebrochen",
+	"connect to alias: ":                                        "Verbinden mit Kürzel: ",
+	"filter by tag (empty to clear): ":                          "Nach Tag filtern (leer zum Zurücksetzen): ",
+	"tag '%s' must not contain spaces":                          "Tag '%s' darf keine Leerzeichen enthalten",
+	"Ungrouped":                                                 "Ohne Gruppe",
+	"%d hosts":                                                  "%d Hosts",
+	"hosts are not grouped":                                     "Hosts sind nicht gruppiert",
+	"checking authentication...":                                "Anmeldung wird geprüft...",
+	"authentication succeeded":                                  "Anmeldung erfolgreich",
+	"authentication failed: %s":                                 "Anmeldung fehlgeschlagen: %s",
+	"cannot check authentication: %s":                           "Anmeldung kann nicht geprüft werden: %s",
+	"hosts are sorted by title":                                 "Hosts sind nach Titel sortiert",
+	"hosts are sorted by last connection":                       "Hosts sind nach letzter Verbindung sortiert",
+	"hosts are sorted by group":                                 "Hosts sind nach Gruppe sortiert",
+	"connections jump through %s":                               "Verbindungen laufen über %s",
+	"connections don't jump through a host anymore":             "Verbindungen laufen nicht mehr über einen Jump-Host",
+	"host cannot be used as a jump host":                        "Host kann nicht als Jump-Host verwendet werden",
+	"press enter to collapse or expand the group":               "Enter drücken, um die Gruppe ein- oder auszuklappen",
+	"alias \"%s\" not found":                                    "Kürzel \"%s\" nicht gefunden",
+	"cannot pin the key, its comment is not a file path":        "Schlüssel kann nicht festgelegt werden, sein Kommentar ist kein Dateipfad",
+	"tunnel to %s is established":                               "Tunnel zu %s ist aufgebaut",
+	"remote file system mounted":                                "Entferntes Dateisystem eingehängt",
+	"keyboard shortcuts":                                        "Tastenkürzel",
+	"press any key to close":                                    "beliebige Taste zum Schließen drücken",
+
+	// Dashboard
+	"failed connections":                          "fehlgeschlagene Verbindungen",
+	"prune dead hosts":                            "tote Hosts bereinigen",
+	"none of the hosts failed %d checks in a row": "keiner der Hosts ist %d Prüfungen in Folge fehlgeschlagen",
+	"failed %d checks in a row":                   "%d Prüfungen in Folge fehlgeschlagen",
+	"archive to \"%s\" group":                     "in Gruppe \"%s\" archivieren",
+	"none":                                        "keine",
+	"exit code %d":                                "Exit-Code %d",
+	"Hosts:":                                      "Hosts:",
+	"Groups:":                                     "Gruppen:",
+	"Providers:":                                  "Anbieter:",
+	"Reachable:":                                  "Erreichbar:",
+	"checking...":                                 "wird geprüft...",
+	"not checked yet":                             "noch nicht geprüft",
+	"%d of %d (checked %s)":                       "%d von %d (geprüft %s)",
+	"Recently used:":                              "Zuletzt verwendet:",
+	"ungrouped":                                   "ohne Gruppe",
+
+	// Key bindings
+	"up":                   "hoch",
+	"down":                 "runter",
+	"save":                 "speichern",
+	"title ↔ host":         "Titel ↔ Host",
+	"copy command":         "Befehl kopieren",
+	"discard":              "verwerfen",
+	"next key from ~/.ssh": "nächster Schlüssel aus ~/.ssh",
+	"form ↔ yaml":          "Formular ↔ YAML",
+	"invalid fields first": "ungültige Felder zuerst",
+	"help":                 "Hilfe",
+	"connect":              "verbinden",
+	"fast connect":         "schnell verbinden",
+	"new":                  "neu",
+	"edit":                 "bearbeiten",
+	"clone":                "klonen",
+	"clone to group":       "in Gruppe klonen",
+	"delete":               "löschen",
+	"copy public key":      "öffentlichen Schlüssel kopieren",
+	"copy key path":        "Schlüsselpfad kopieren",
+	"edit hosts file":      "Hostdatei bearbeiten",
+	"mount sshfs":          "sshfs einhängen",
+	"sftp session":         "SFTP-Sitzung",
+	"tail log":             "Log verfolgen",
+	"open web url":         "Web-URL öffnen",
+	"summary":              "Übersicht",
+	"select":               "auswählen",
+	"set identity file":    "Schlüsseldatei setzen",
+	"pin agent key":        "Agent-Schlüssel festlegen",
+	"connect by alias":     "Per Kürzel verbinden",
+	"filter by tag":        "Nach Tag filtern",
+	"collapse group":       "Gruppe einklappen",
+	"check auth":           "Anmeldung prüfen",
+	"favorite":             "Favorit",
+	"jump host":            "Jump-Host",
+	"toggle view":          "Ansicht wechseln",
+	"sort order":           "Sortierung",
+	"confirm":              "bestätigen",
+	"check reachability":   "Erreichbarkeit prüfen",
+	"archive":              "archivieren",
+	"close":                "schließen",
+	"cancel":               "abbrechen",
+}
@@ -0,0 +1,80 @@
+// Package i18n contains message catalogs which are used to translate user interface strings.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage - is the language which is used when a message is not found in the selected catalog.
+// Messages in the source code are written in this language and are used as catalog keys.
+const DefaultLanguage = "en"
+
+// catalogs - key is a language code, value is a map of english messages to their translations.
+var catalogs = map[string]map[string]string{
+	"de": catalogDE,
+}
+
+var (
+	mu       sync.RWMutex
+	language = DefaultLanguage
+)
+
+// SetLanguage - selects message catalog. Returns false if there is no catalog for the language,
+// in that case messages are not translated.
+func SetLanguage(lang string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if _, ok := catalogs[lang]; !ok {
+		language = DefaultLanguage
+		return lang == DefaultLanguage
+	}
+
+	language = lang
+	return true
+}
+
+// Language - returns currently selected language.
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return language
+}
+
+// T - translates message into the selected language. If translation does not exist, returns the message as is.
+func T(msg string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if translated, ok := catalogs[language][msg]; ok {
+		return translated
+	}
+
+	return msg
+}
+
+// Tf - translates format string and formats it with the arguments.
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// LanguageFromLocale - extracts language code from a POSIX locale. Ex: "de_DE.UTF-8" -> "de".
+// "C" and "POSIX" locales as well as an empty string are treated as the default language.
+func LanguageFromLocale(locale string) string {
+	locale = strings.TrimSpace(locale)
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return DefaultLanguage
+	}
+
+	// Strip encoding and modifier, then territory. Ex: "de_DE.UTF-8@euro" -> "de".
+	lang, _, _ := strings.Cut(locale, ".")
+	lang, _, _ = strings.Cut(lang, "@")
+	lang, _, _ = strings.Cut(lang, "_")
+	lang, _, _ = strings.Cut(lang, "-")
+
+	return strings.ToLower(lang)
+}
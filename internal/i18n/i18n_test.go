@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLanguage(t *testing.T) {
+	t.Cleanup(func() { SetLanguage(DefaultLanguage) })
+
+	require.True(t, SetLanguage("de"))
+	require.Equal(t, "de", Language())
+	require.True(t, SetLanguage(" DE "))
+	require.Equal(t, "de", Language())
+	require.True(t, SetLanguage(DefaultLanguage))
+	require.Equal(t, DefaultLanguage, Language())
+
+	// Unknown language falls back to english
+	SetLanguage("de")
+	require.False(t, SetLanguage("xx"))
+	require.Equal(t, DefaultLanguage, Language())
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { SetLanguage(DefaultLanguage) })
+
+	require.Equal(t, "Title", T("Title"))
+	require.Equal(t, "value is required", T("value is required"))
+
+	SetLanguage("de")
+	require.Equal(t, "Titel", T("Title"))
+	require.Equal(t, "Wert ist erforderlich", T("value is required"))
+	require.Equal(t, "Hostdetails", T("host details"))
+	// Message without translation is returned as is
+	require.Equal(t, "untranslated message", T("untranslated message"))
+}
+
+func TestTf(t *testing.T) {
+	t.Cleanup(func() { SetLanguage(DefaultLanguage) })
+
+	require.Equal(t, "Login is not valid", Tf("%s is not valid", "Login"))
+
+	SetLanguage("de")
+	require.Equal(t, "Benutzer ist ungültig", Tf("%s is not valid", T("Login")))
+}
+
+func TestLanguageFromLocale(t *testing.T) {
+	tests := map[string]string{
+		"":                 DefaultLanguage,
+		"C":                DefaultLanguage,
+		"POSIX":            DefaultLanguage,
+		"de":               "de",
+		"de_DE":            "de",
+		"de_DE.UTF-8":      "de",
+		"de_AT.UTF-8@euro": "de",
+		"en-US":            "en",
+		"FR_fr":            "fr",
+	}
+
+	for locale, expected := range tests {
+		require.Equal(t, expected, LanguageFromLocale(locale), "Locale: '%s'", locale)
+	}
+}
@@ -0,0 +1,144 @@
+package host
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/grafviktor/goto/internal/utils"
+)
+
+// splitForwardSpec - splits forward specification by colons, colons inside of square brackets, which wrap IPv6
+// addresses, are ignored. Ex: "[::1]:8080:localhost:80" => ["[::1]", "8080", "localhost", "80"].
+func splitForwardSpec(spec string) []string {
+	parts := []string{}
+	depth, start := 0, 0
+	for i, r := range spec {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ':':
+			if depth == 0 {
+				parts = append(parts, spec[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, spec[start:])
+}
+
+// localBindPort - returns local port of '-L' forward entry or of a forward spec without a flag, as it's stored in
+// LocalForwards. Entries which are not local forwards or which bind a unix socket have no local port.
+func localBindPort(forward string) (string, bool) {
+	spec := strings.TrimSpace(forward)
+	if strings.HasPrefix(spec, "-") {
+		flag, flagValue, _ := strings.Cut(spec, " ")
+		if flag != "-L" {
+			return "", false
+		}
+		spec = flagValue
+	}
+
+	_, port, ok := localForwardBind(spec)
+	return port, ok
+}
+
+// localForwardBind - returns bind address and local port of '-L' forward spec. Bind address is empty when
+// it's not set.
+func localForwardBind(spec string) (string, string, bool) {
+	parts := splitForwardSpec(strings.TrimSpace(spec))
+	switch len(parts) {
+	case 2, 3:
+		// port:remote_socket or port:host:hostport
+		return "", parts[0], parts[0] != ""
+	case 4:
+		// bind_address:port:host:hostport
+		return parts[0], parts[1], parts[1] != ""
+	default:
+		return "", "", false
+	}
+}
+
+// dynamicForwardBind - returns bind address and local port of '-D' forward spec. Ex: "127.0.0.1:1080".
+func dynamicForwardBind(spec string) (string, string, bool) {
+	parts := splitForwardSpec(strings.TrimSpace(spec))
+	switch len(parts) {
+	case 1:
+		return "", parts[0], parts[0] != ""
+	case 2:
+		return parts[0], parts[1], parts[1] != ""
+	default:
+		return "", "", false
+	}
+}
+
+// LocalBindAddresses - returns local addresses which ssh listens on for the port forwards of the host and the
+// forward preset. Ex: "127.0.0.1:8080". Forwards without bind address listen on the loopback interface, "*" means
+// all interfaces. Unix sockets are skipped. Port forwards of the host are not applied to custom connect strings
+// and host aliases, Teleport and kubectl don't use them at all.
+func (h *Host) LocalBindAddresses(presetName string) []string {
+	if strings.TrimSpace(h.ConnectTemplate) != "" || h.IsKubectl() || h.IsTeleport() {
+		return nil
+	}
+
+	type bind struct{ address, port string }
+	binds := []bind{}
+	add := func(address, port string, ok bool) {
+		if _, err := strconv.ParseUint(port, 10, 16); ok && err == nil && port != "0" {
+			binds = append(binds, bind{address, port})
+		}
+	}
+
+	if h.SSHAlias == "" && !h.IsUserDefinedSSHCommand() {
+		for _, forward := range h.LocalForwards {
+			add(localForwardBind(forward))
+		}
+
+		if !utils.StringEmpty(h.DynamicForward) {
+			add(dynamicForwardBind(h.DynamicForward))
+		}
+	}
+
+	for _, forward := range h.ForwardPresets[presetName] {
+		if flag, spec, _ := strings.Cut(strings.TrimSpace(forward), " "); flag == "-L" {
+			add(localForwardBind(spec))
+		}
+	}
+
+	return lo.Uniq(lo.Map(binds, func(b bind, _ int) string {
+		address := strings.Trim(b.address, "[]")
+		switch address {
+		case "":
+			address = "127.0.0.1"
+		case "*":
+			address = ""
+		}
+
+		return net.JoinHostPort(address, b.port)
+	}))
+}
+
+// DuplicateLocalPort - returns a local port which is bound by more than one local forward, because ssh cannot set
+// up such forwards. Forwards are either '-L' entries of a forward preset or specs of LocalForwards, ex:
+// "8080:localhost:80". Bind addresses are not compared, the port must be unique.
+func DuplicateLocalPort(forwards []string) (string, bool) {
+	bound := map[string]bool{}
+	for _, forward := range forwards {
+		port, ok := localBindPort(forward)
+		if !ok {
+			continue
+		}
+
+		if bound[port] {
+			return port, true
+		}
+		bound[port] = true
+	}
+
+	return "", false
+}
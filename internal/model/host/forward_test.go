@@ -0,0 +1,103 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateLocalPort(t *testing.T) {
+	tests := []struct {
+		name      string
+		forwards  []string
+		port      string
+		duplicate bool
+	}{
+		{"no forwards", nil, "", false},
+		{
+			name:     "unique ports",
+			forwards: []string{"-L 5432:localhost:5432", "-L 8080:localhost:80", "-L 127.0.0.1:9090:localhost:9090"},
+		},
+		{
+			name:      "same port in the last entry",
+			forwards:  []string{"-L 5432:localhost:5432", "-L 8080:localhost:80", "-L 5432:db2:5432"},
+			port:      "5432",
+			duplicate: true,
+		},
+		{
+			name:      "same port with different bind addresses",
+			forwards:  []string{"-L 127.0.0.1:8080:localhost:80", "-L 8443:localhost:443", "-L [::1]:8080:localhost:81"},
+			port:      "8080",
+			duplicate: true,
+		},
+		{
+			name:      "remote socket forward",
+			forwards:  []string{"-L 2375:/var/run/docker.sock", "-L  2375:localhost:2375 "},
+			port:      "2375",
+			duplicate: true,
+		},
+		{
+			name:     "remote forwards and local sockets are ignored",
+			forwards: []string{"-R 8080:localhost:80", "-L 8080:localhost:80", "-R 8080:localhost:81", "-L /tmp/sock:/var/run/sock"},
+		},
+		{
+			name:      "local forwards of the host",
+			forwards:  []string{"8080:localhost:80", " 8080:db:5432 "},
+			port:      "8080",
+			duplicate: true,
+		},
+		{
+			name:      "local forwards of the host together with a preset",
+			forwards:  []string{"8080:localhost:80", "5432:db:5432", "-L 8443:localhost:443", "-L 5432:db2:5432"},
+			port:      "5432",
+			duplicate: true,
+		},
+		{
+			name:     "unique local forwards of the host and a preset",
+			forwards: []string{"8080:localhost:80", "*:5432:db:5432", "-L 8443:localhost:443", "-R 5432:db2:5432"},
+		},
+		{
+			name:     "host port is not a local port",
+			forwards: []string{"-L 8080:localhost:80", "-L 9090:localhost:8080", "-L [::1]:80:[fe80::1]:8080"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, duplicate := DuplicateLocalPort(tt.forwards)
+			require.Equal(t, tt.duplicate, duplicate)
+			require.Equal(t, tt.port, port)
+		})
+	}
+}
+
+func TestLocalBindAddresses(t *testing.T) {
+	h := Host{
+		Address: "localhost",
+		LocalForwards: []string{
+			"8080:localhost:80", "*:5432:db:5432", "[::1]:9090:localhost:9090", "2375:/var/run/docker.sock",
+		},
+		DynamicForward: "1080",
+		ForwardPresets: map[string][]string{
+			"web": {
+				"-L 127.0.0.1:8443:localhost:443", "-R 9000:localhost:3000",
+				"-L /tmp/app.sock:/run/app.sock", "-L 8080:localhost:81",
+			},
+		},
+	}
+
+	expected := []string{"127.0.0.1:8080", ":5432", "[::1]:9090", "127.0.0.1:2375", "127.0.0.1:1080"}
+	require.Equal(t, expected, h.LocalBindAddresses(""))
+	// Remote forwards and unix sockets are skipped, the same address is returned once
+	require.Equal(t, append(expected, "127.0.0.1:8443"), h.LocalBindAddresses("web"))
+
+	h.DynamicForward = "0.0.0.0:1081"
+	require.Contains(t, h.LocalBindAddresses(""), "0.0.0.0:1081")
+
+	// Port forwards of the host are not applied to custom connect strings, but forward presets are
+	h.Address = "root@localhost -p 2222"
+	require.Equal(t, []string{"127.0.0.1:8443", "127.0.0.1:8080"}, h.LocalBindAddresses("web"))
+
+	h.Protocol = "teleport"
+	require.Empty(t, h.LocalBindAddresses("web"))
+}
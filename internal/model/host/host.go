@@ -3,9 +3,15 @@ package host
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/samber/lo"
+
+	"github.com/grafviktor/goto/internal/constant"
 	"github.com/grafviktor/goto/internal/model/ssh"
+	"github.com/grafviktor/goto/internal/utils"
 )
 
 // NewHost - constructs new Host model.
@@ -24,31 +30,232 @@ func NewHost(id int, title, description, address, loginName, identityFilePath, r
 
 // Host model definition.
 type Host struct {
-	ID               int         `yaml:"-"`
-	Title            string      `yaml:"title"`
-	Description      string      `yaml:"description,omitempty"`
-	Address          string      `yaml:"address"`
-	RemotePort       string      `yaml:"network_port,omitempty"`
-	LoginName        string      `yaml:"username,omitempty"`
-	IdentityFilePath string      `yaml:"identity_file_path,omitempty"`
-	Password         string      `yaml:"password,omitempty"`
-	SSHClientConfig  *ssh.Config `yaml:"-"`
+	ID          int    `yaml:"-"`
+	Title       string `yaml:"title"`
+	Description string `yaml:"description,omitempty"`
+	Group       string `yaml:"group,omitempty"`
+	// Tags are free-form labels, unlike group a host can have several tags. Host list can be filtered by tag.
+	Tags             []string `yaml:"tags,omitempty"`
+	Address          string   `yaml:"address"`
+	RemotePort       string   `yaml:"network_port,omitempty"`
+	LoginName        string   `yaml:"username,omitempty"`
+	IdentityFilePath string   `yaml:"identity_file_path,omitempty"`
+	// IdentitiesOnly is set when ssh should use the identity file only, instead of trying all ssh-agent keys.
+	// The option is ignored when identity file is not set.
+	IdentitiesOnly bool `yaml:"identities_only,omitempty"`
+	// ForwardAgent is set when connection to the local ssh-agent should be forwarded to the remote host.
+	// The option is ignored when user-defined connect string is used.
+	ForwardAgent bool `yaml:"forward_agent,omitempty"`
+	// Compression is set when ssh should compress all data, it's ignored when user-defined connect string is used.
+	Compression bool `yaml:"compression,omitempty"`
+	// CompressionLevel is a number from 1 to 9, it's applied only when Compression is set.
+	CompressionLevel string `yaml:"compression_level,omitempty"`
+	// IdentityFiles are alternative identity files, user picks one of them when connecting to the host.
+	// See IdentityFileCandidates.
+	IdentityFiles   []string `yaml:"identity_files,omitempty"`
+	Password        string   `yaml:"password,omitempty"`
+	PasswordCommand string   `yaml:"password_command,omitempty"`
+	// UsePassword can be set to false to keep password and password command for reference, while
+	// connecting using keys. Nil means true. See UsesPassword.
+	UsePassword *bool `yaml:"use_password,omitempty"`
+	// EnvFile is a file with KEY=VALUE lines, variables are set in the environment of ssh process.
+	EnvFile string `yaml:"env_file,omitempty"`
+	// TermType overrides TERM variable in the environment of ssh process, ssh passes it to the remote host.
+	TermType     string `yaml:"term_type,omitempty"`
+	GatewayPorts string `yaml:"gateway_ports,omitempty"`
+	// EscapeChar is a single character or "none" to disable escapes, for instance for binary-safe sessions.
+	EscapeChar string `yaml:"escape_char,omitempty"`
+	// BindAddress is a local IP address of the outgoing connection, it's used on machines with several interfaces.
+	BindAddress string `yaml:"bind_address,omitempty"`
+	// HostKeyAlias is used instead of the address to look up the host key, so the same host can be reached
+	// using different addresses, for instance through port forwards.
+	HostKeyAlias string `yaml:"host_key_alias,omitempty"`
+	// StrictHostKeyChecking is one of "yes", "no" or "accept-new", ssh default is used when empty.
+	StrictHostKeyChecking string `yaml:"strict_host_key_checking,omitempty"`
+	// ConnectTimeout is a number of seconds to wait for the remote host, system default is used when empty.
+	ConnectTimeout string `yaml:"connect_timeout,omitempty"`
+	// KeepAliveInterval is a number of seconds after which ssh sends keepalive message, so firewalls don't
+	// drop idle sessions. KeepAliveCountMax is a number of unanswered messages after which ssh disconnects.
+	KeepAliveInterval string `yaml:"keep_alive_interval,omitempty"`
+	KeepAliveCountMax string `yaml:"keep_alive_count_max,omitempty"`
+	SSHAlias          string `yaml:"ssh_alias,omitempty"`
+	// ProxyJumpPool contains jump hosts which are interchangeable, one of them is chosen for every connection
+	// according to ProxyJumpStrategy, which is one of ProxyJumpRandom or ProxyJumpRoundRobin.
+	ProxyJumpPool     []string `yaml:"proxy_jump_pool,omitempty"`
+	ProxyJumpStrategy string   `yaml:"proxy_jump_strategy,omitempty"`
+	// LogPath is a log file on the remote host, which is followed using 'tail -f'. See CmdSSHTailLog.
+	LogPath string `yaml:"log_path,omitempty"`
+	// Sudo makes remote commands run using sudo, interactive login is not affected.
+	Sudo bool `yaml:"sudo,omitempty"`
+	// Alias is a unique short code which is used to connect to the host from the quick connect box.
+	// Not copied when host is cloned.
+	Alias string `yaml:"alias,omitempty"`
+	// Protocol is one of constant.ProtocolSSH, constant.ProtocolTeleport or constant.ProtocolKubectl,
+	// empty value means ssh.
+	Protocol string `yaml:"protocol,omitempty"`
+	// TeleportCluster is only used when host is connected through Teleport.
+	TeleportCluster string `yaml:"teleport_cluster,omitempty"`
+	// KubeNamespace, KubePod and KubeContainer are only used when host is a Kubernetes pod.
+	KubeNamespace string `yaml:"kube_namespace,omitempty"`
+	KubePod       string `yaml:"kube_pod,omitempty"`
+	KubeContainer string `yaml:"kube_container,omitempty"`
+	// ConnectTemplate is a Go template of the entire connect command, it overrides the command which
+	// is assembled from the host attributes. See RenderConnectTemplate.
+	ConnectTemplate string `yaml:"connect_template,omitempty"`
+	Priority        int    `yaml:"priority,omitempty"`
+	WebURL          string `yaml:"web_url,omitempty"`
+	Banner          string `yaml:"banner,omitempty"`
+	// Notes are displayed right before the host is connected, any key dismisses them.
+	Notes string `yaml:"notes,omitempty"`
+	// ConfirmByName is set for the most sensitive hosts, user must type the exact title of the host to connect.
+	ConfirmByName bool `yaml:"confirm_by_name,omitempty"`
+	// IsFavorite is toggled in the host list, favorite hosts are displayed on top of the list.
+	IsFavorite bool `yaml:"favorite,omitempty"`
+	// LocalForwards are '-L' specs which are applied to every connection. Ex: "8080:localhost:80".
+	LocalForwards []string `yaml:"local_forwards,omitempty"`
+	// RemoteForwards are '-R' specs which are applied to every connection. Ex: "9000:localhost:3000".
+	RemoteForwards []string `yaml:"remote_forwards,omitempty"`
+	// DynamicForward is a local port of SOCKS proxy, connections are forwarded through the remote host. Ex: "1080".
+	DynamicForward string `yaml:"dynamic_forward,omitempty"`
+	// BackgroundTunnel is set for hosts which are only used for port forwarding, ssh goes to background right
+	// after authentication and neither a terminal, nor a remote command is started. User is notified when
+	// the tunnel is established.
+	BackgroundTunnel bool `yaml:"background_tunnel,omitempty"`
+	// AutoConnect is set for background tunnels which are established on app start, see AutoConnectHosts.
+	AutoConnect bool `yaml:"auto_connect,omitempty"`
+	// ExtraOptions are raw 'Key=Value' ssh_config options which are not modelled by the host attributes,
+	// every entry is passed to ssh as '-o Key=Value'. Ex: "Ciphers=aes256-ctr,aes128-ctr".
+	ExtraOptions   []string            `yaml:"extra_options,omitempty"`
+	ForwardPresets map[string][]string `yaml:"forward_presets,omitempty"`
+	Profiles       map[string]Profile  `yaml:"profiles,omitempty"`
+	LastConnected  time.Time           `yaml:"last_connected,omitempty"`
+	// ConnectCount is a number of ssh sessions which were started to the host. Not copied when host is cloned.
+	ConnectCount int `yaml:"connect_count,omitempty"`
+	// Provider is a cloud provider of the host, one of ProviderAWS, ProviderGCP, ProviderAzure or any other name.
+	// It's displayed as an icon in the host list and hosts are grouped by it on the dashboard.
+	Provider string `yaml:"provider,omitempty"`
+	// RemoteOS is an operating system of the remote host, it's detected after connection if user opted in.
+	// Not copied when host is cloned. See ssh.ParseRemoteOS.
+	RemoteOS string `yaml:"remote_os,omitempty"`
+	// FailedChecks is a number of consecutive failed reachability checks. Not copied when host is cloned.
+	FailedChecks int `yaml:"failed_checks,omitempty"`
+	// LastConnectResult is a result of the last ssh session. Not copied when host is cloned.
+	LastConnectResult ConnectResult `yaml:"last_connect_result,omitempty"`
+	SSHClientConfig   *ssh.Config   `yaml:"-"`
+}
+
+// ConnectResult - is a result of ssh session. Zero value means that the host was never connected to,
+// or the result is unknown.
+type ConnectResult struct {
+	Time     time.Time `yaml:"time,omitempty"`
+	ExitCode int       `yaml:"exit_code,omitempty"`
+	Error    string    `yaml:"error,omitempty"`
+}
+
+// Failed - returns true if ssh session ended with an error.
+func (r ConnectResult) Failed() bool {
+	return r.ExitCode != 0 || r.Error != ""
 }
 
 // Clone host model.
 func (h *Host) Clone() Host {
 	newHost := Host{
-		Title:            h.Title,
-		Description:      h.Description,
-		Address:          h.Address,
-		LoginName:        h.LoginName,
-		IdentityFilePath: h.IdentityFilePath,
-		RemotePort:       h.RemotePort,
-		Password:         h.Password,
+		Title:                 h.Title,
+		Description:           h.Description,
+		Group:                 h.Group,
+		Address:               h.Address,
+		LoginName:             h.LoginName,
+		IdentityFilePath:      h.IdentityFilePath,
+		IdentitiesOnly:        h.IdentitiesOnly,
+		ForwardAgent:          h.ForwardAgent,
+		Compression:           h.Compression,
+		CompressionLevel:      h.CompressionLevel,
+		RemotePort:            h.RemotePort,
+		Password:              h.Password,
+		PasswordCommand:       h.PasswordCommand,
+		EnvFile:               h.EnvFile,
+		TermType:              h.TermType,
+		DynamicForward:        h.DynamicForward,
+		BackgroundTunnel:      h.BackgroundTunnel,
+		AutoConnect:           h.AutoConnect,
+		GatewayPorts:          h.GatewayPorts,
+		EscapeChar:            h.EscapeChar,
+		BindAddress:           h.BindAddress,
+		HostKeyAlias:          h.HostKeyAlias,
+		StrictHostKeyChecking: h.StrictHostKeyChecking,
+		ConnectTimeout:        h.ConnectTimeout,
+		KeepAliveInterval:     h.KeepAliveInterval,
+		KeepAliveCountMax:     h.KeepAliveCountMax,
+		LogPath:               h.LogPath,
+		Sudo:                  h.Sudo,
+		SSHAlias:              h.SSHAlias,
+		ProxyJumpStrategy:     h.ProxyJumpStrategy,
+		Protocol:              h.Protocol,
+		TeleportCluster:       h.TeleportCluster,
+		KubeNamespace:         h.KubeNamespace,
+		KubePod:               h.KubePod,
+		KubeContainer:         h.KubeContainer,
+		ConnectTemplate:       h.ConnectTemplate,
+		Priority:              h.Priority,
+		WebURL:                h.WebURL,
+		Provider:              h.Provider,
+		Banner:                h.Banner,
+		Notes:                 h.Notes,
+		ConfirmByName:         h.ConfirmByName,
+		IsFavorite:            h.IsFavorite,
+	}
+
+	if h.Tags != nil {
+		newHost.Tags = append([]string(nil), h.Tags...)
+	}
+
+	if h.IdentityFiles != nil {
+		newHost.IdentityFiles = append([]string(nil), h.IdentityFiles...)
+	}
+
+	if h.LocalForwards != nil {
+		newHost.LocalForwards = append([]string(nil), h.LocalForwards...)
+	}
+
+	if h.RemoteForwards != nil {
+		newHost.RemoteForwards = append([]string(nil), h.RemoteForwards...)
+	}
+
+	if h.ExtraOptions != nil {
+		newHost.ExtraOptions = append([]string(nil), h.ExtraOptions...)
+	}
+
+	if h.ProxyJumpPool != nil {
+		newHost.ProxyJumpPool = append([]string(nil), h.ProxyJumpPool...)
+	}
+
+	if h.UsePassword != nil {
+		usePassword := *h.UsePassword
+		newHost.UsePassword = &usePassword
+	}
+
+	if h.ForwardPresets != nil {
+		newHost.ForwardPresets = make(map[string][]string, len(h.ForwardPresets))
+		for name, forwards := range h.ForwardPresets {
+			newHost.ForwardPresets[name] = append([]string(nil), forwards...)
+		}
+	}
+
+	if h.Profiles != nil {
+		newHost.Profiles = make(map[string]Profile, len(h.Profiles))
+		for name, profile := range h.Profiles {
+			newHost.Profiles[name] = profile
+		}
 	}
+
 	return newHost
 }
 
+// UsesPassword - returns false when user opted out from passing password or password command to sshpass.
+func (h *Host) UsesPassword() bool {
+	return h.UsePassword == nil || *h.UsePassword
+}
+
 // IsUserDefinedSSHCommand returns true if the address contains spaces or "@" symbol,
 // true means that user uses a custom config and not relying on LoginName, IdentityFilePath
 // and RemotePort.
@@ -60,27 +267,388 @@ func (h *Host) IsUserDefinedSSHCommand() bool {
 	return containsSpace || containsAtSymbol
 }
 
+// IsTeleport - returns true if host is connected through Teleport instead of a plain ssh client.
+func (h *Host) IsTeleport() bool {
+	return strings.EqualFold(strings.TrimSpace(h.Protocol), constant.ProtocolTeleport)
+}
+
+// ScratchHostID identifies a throwaway host, which lives in memory for the session and is never stored.
+const ScratchHostID = -1
+
+// IsScratch - returns true if the host is a throwaway host, which is never stored. See ScratchHostID.
+func (h *Host) IsScratch() bool {
+	return h.ID == ScratchHostID
+}
+
+// IsKubectl - returns true if host is a Kubernetes pod which is connected using kubectl exec.
+func (h *Host) IsKubectl() bool {
+	return strings.EqualFold(strings.TrimSpace(h.Protocol), constant.ProtocolKubectl)
+}
+
+// AutoConnectHosts - returns hosts which are connected to on app start. Only background tunnels are connected
+// automatically, a host which opens an interactive shell would take over the terminal before the host list
+// is even displayed. Connect template replaces the whole command including background flags, so such hosts
+// are skipped as well as hosts which user must confirm or acknowledge before connecting.
+func AutoConnectHosts(hosts []Host) []Host {
+	return lo.Filter(hosts, func(h Host, _ int) bool {
+		return h.AutoConnect && h.BackgroundTunnel && !h.IsTeleport() && !h.IsKubectl() &&
+			strings.TrimSpace(h.ConnectTemplate) == "" && !h.ConfirmByName && strings.TrimSpace(h.Banner) == ""
+	})
+}
+
+// WebURLAddressPlaceholder is replaced with the host address when web url is opened. Ex: https://{address}:8443.
+const WebURLAddressPlaceholder = "{address}"
+
+// ResolveWebURL - returns web url of the host where address placeholder is substituted. When a host uses
+// a custom connect string or ssh alias, hostname is taken from ssh config if it's loaded.
+func (h *Host) ResolveWebURL() string {
+	address := strings.TrimSpace(h.Address)
+	if (h.SSHAlias != "" || h.IsUserDefinedSSHCommand()) && h.SSHClientConfig != nil {
+		address = h.SSHClientConfig.Hostname
+	}
+
+	return strings.ReplaceAll(strings.TrimSpace(h.WebURL), WebURLAddressPlaceholder, address)
+}
+
+// sshOptionsWithArgument contains ssh flags which require an argument, see "man ssh".
+const sshOptionsWithArgument = "BbcDEeFIiJLlmOoPpQRSWw"
+
+// HasDestination returns true if the address contains a hostname which ssh can connect to.
+// An address which consists of whitespaces only, or a custom connect string without
+// a hostname, for instance "user@" or "-p 2222", would produce a meaningless command.
+func (h *Host) HasDestination() bool {
+	// Address is not used when connecting to a pod.
+	if h.IsKubectl() {
+		return strings.TrimSpace(h.KubePod) != ""
+	}
+
+	if strings.TrimSpace(h.SSHAlias) != "" {
+		return true
+	}
+
+	if !h.IsUserDefinedSSHCommand() {
+		return len(strings.Fields(h.Address)) > 0
+	}
+
+	return Destination(h.Address) != ""
+}
+
+// Destination returns the first token of a custom connect string which is not an ssh option or
+// its argument, for instance "root@web-01" in "-p 2222 root@web-01". A login name without
+// a hostname, like "user@", is not a destination. Returns an empty string if there is none.
+func Destination(address string) string {
+	fields := strings.Fields(address)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.HasPrefix(field, "-") {
+			// Skip option argument, for instance "2222" in "-p 2222"
+			if len(field) == 2 && strings.ContainsRune(sshOptionsWithArgument, rune(field[1])) {
+				i++
+			}
+
+			continue
+		}
+
+		if _, hostname, found := strings.Cut(field, "@"); found && hostname == "" {
+			continue
+		}
+
+		return field
+	}
+
+	return ""
+}
+
+// ConnectsToLoopback - returns true if the host connects to the local machine. When address is a host alias
+// or a custom connect string with ssh options, hostname which is resolved using ssh config is checked instead.
+func (h *Host) ConnectsToLoopback() bool {
+	if h.SSHAlias != "" || strings.Contains(strings.TrimSpace(h.Address), " ") {
+		return h.SSHClientConfig != nil && utils.IsLoopbackHost(h.SSHClientConfig.Hostname)
+	}
+
+	hostname := strings.TrimSpace(h.Address)
+	if _, afterLogin, found := strings.Cut(hostname, "@"); found {
+		hostname = afterLogin
+	}
+
+	return utils.IsLoopbackHost(hostname)
+}
+
+// HasTag - returns true if the host is tagged with the tag. Tags are compared case-insensitively.
+func (h *Host) HasTag(tag string) bool {
+	tag = strings.TrimSpace(tag)
+
+	return lo.ContainsBy(h.Tags, func(hostTag string) bool {
+		return strings.EqualFold(strings.TrimSpace(hostTag), tag)
+	})
+}
+
+// IdentityFileCandidates - returns identity file of the host followed by alternative identity files,
+// empty and duplicate values are skipped. User is asked to choose one when there are several candidates.
+func (h *Host) IdentityFileCandidates() []string {
+	candidates := append([]string{h.IdentityFilePath}, h.IdentityFiles...)
+	candidates = lo.Map(candidates, func(path string, _ int) string { return strings.TrimSpace(path) })
+
+	return lo.Uniq(lo.Compact(candidates))
+}
+
+// WithIdentityFile - returns a copy of the host which uses the identity file. Empty value leaves the
+// identity file of the host as is.
+func (h *Host) WithIdentityFile(identityFile string) Host {
+	withIdentity := *h
+	if strings.TrimSpace(identityFile) != "" {
+		withIdentity.IdentityFilePath = identityFile
+	}
+
+	return withIdentity
+}
+
+// ResolvedIdentityFile - returns path to the identity file which ssh uses for the host. Identity file of the host
+// takes precedence over the one from ssh config. Leading "~" is expanded. Empty string means the file is unknown.
+func (h *Host) ResolvedIdentityFile() string {
+	identityFile := strings.TrimSpace(h.IdentityFilePath)
+	if identityFile == "" && h.SSHClientConfig != nil {
+		identityFile = strings.TrimSpace(h.SSHClientConfig.IdentityFile)
+	}
+
+	return utils.ExpandTilde(identityFile)
+}
+
+// ForwardPresetNames - returns sorted names of the port forwarding presets.
+func (h *Host) ForwardPresetNames() []string {
+	names := make([]string, 0, len(h.ForwardPresets))
+	for name := range h.ForwardPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// CmdSSHConnect - returns SSH command for connecting to a remote host. If host has a pool of jump hosts,
+// one of them is chosen every time the command is built, but round-robin strategy moves on to the next jump
+// host only when the connection is launched, see WithProxyJumpSelected.
 func (h *Host) CmdSSHConnect() string {
+	return h.cmdSSHConnect(h.connectOptions(h.proxyJumpOptions()))
+}
+
+// CmdSSHConnectPreview - returns SSH command which is displayed to the user. Unlike CmdSSHConnect, a jump
+// host is not chosen from the pool, all of them are displayed instead. Ex: "-J {bastion1|bastion2}".
+func (h *Host) CmdSSHConnectPreview() string {
+	pool := lo.Compact(lo.Map(h.ProxyJumpPool, func(jumpHost string, _ int) string { return strings.TrimSpace(jumpHost) }))
+	if len(pool) <= 1 {
+		return h.CmdSSHConnect()
+	}
+
+	return h.cmdSSHConnect(h.connectOptions([]ssh.Option{
+		ssh.OptionProxyJump{Value: fmt.Sprintf("{%s}", strings.Join(pool, "|"))},
+	}))
+}
+
+// CmdSSHConnectWithForwardPreset - returns SSH command for connecting to a remote host, port forwarding
+// flags of the preset are added to the command. Unknown preset name is ignored.
+func (h *Host) CmdSSHConnectWithForwardPreset(presetName string) string {
+	forwards := h.proxyJumpOptions()
+	for _, forward := range h.ForwardPresets[presetName] {
+		forwards = append(forwards, ssh.OptionPortForward{Value: forward})
+	}
+
+	return h.cmdSSHConnect(h.connectOptions(forwards))
+}
+
+// connectOptions - adds background flags to the options of an interactive connection when host is a background
+// tunnel. Commands which run a remote command, for instance CmdSSHCheckAuth, never go to background.
+func (h *Host) connectOptions(options []ssh.Option) []ssh.Option {
+	return append(options, ssh.OptionBackground{Value: h.BackgroundTunnel})
+}
+
+// WithProxyJumpSelected - returns a copy of the host which pool is replaced with the jump host chosen for the
+// connection, so all commands of the connection use the same jump host. It's called once when the connection
+// is launched, round-robin strategy moves on to the next jump host then. Host without a pool is left as is.
+func (h *Host) WithProxyJumpSelected() Host {
+	withJumpHost := *h
+	if jumpHost := proxyJumpSelector.Select(h.ProxyJumpStrategy, h.ProxyJumpPool); jumpHost != "" {
+		withJumpHost.ProxyJumpPool = []string{jumpHost}
+	}
+
+	return withJumpHost
+}
+
+// proxyJumpOptions - returns ProxyJump option with a jump host which is chosen from the pool, or nothing
+// when the pool is empty. The rotation of the pool is not changed.
+func (h *Host) proxyJumpOptions() []ssh.Option {
+	jumpHost := proxyJumpSelector.Peek(h.ProxyJumpStrategy, h.ProxyJumpPool)
+	if jumpHost == "" {
+		return nil
+	}
+
+	return []ssh.Option{ssh.OptionProxyJump{Value: jumpHost}}
+}
+
+// CmdSSHFastConnect - returns SSH command which doesn't read ssh config files, connection
+// parameters are taken from the host attributes only. Host alias cannot be resolved without
+// ssh config, in that case a regular connect command is returned.
+func (h *Host) CmdSSHFastConnect() string {
+	if h.SSHAlias != "" {
+		return h.CmdSSHConnect()
+	}
+
+	return h.cmdSSHConnect(h.connectOptions(append(h.proxyJumpOptions(), ssh.OptionNoConfig{})))
+}
+
+// CmdSSHTailLog - returns SSH command which follows LogPath of the host right after connecting. Remote command
+// cannot be added to Teleport, kubectl and connect template commands, so a regular connect command is returned for
+// such hosts, as well as for hosts without a log path.
+func (h *Host) CmdSSHTailLog() string {
+	if strings.TrimSpace(h.LogPath) == "" || h.IsTeleport() || h.IsKubectl() || strings.TrimSpace(h.ConnectTemplate) != "" {
+		return h.CmdSSHConnect()
+	}
+
+	command := h.cmdSSHConnect(append(h.proxyJumpOptions(), ssh.OptionForceTTY{}))
+	return command + ssh.RemoteCommand(h.remoteCommand(ssh.TailLogCommand(h.LogPath)))
+}
+
+// remoteCommand - prepends sudo to the remote command when the host requires it.
+func (h *Host) remoteCommand(command string) string {
+	if h.Sudo {
+		return ssh.SudoCommand(command)
+	}
+
+	return command
+}
+
+// cmdSSHConnect - builds connect command, extraOptions are placed before the address. When host has a connect
+// template, the command is rendered from the template and extraOptions are not applied.
+func (h *Host) cmdSSHConnect(extraOptions []ssh.Option) string {
+	if strings.TrimSpace(h.ConnectTemplate) != "" {
+		// Template is validated when host is saved, but hosts file can be edited manually.
+		if command, err := RenderConnectTemplate(h.ConnectTemplate, *h); err == nil {
+			return command
+		}
+	}
+
+	// Pods are not connected using ssh, so extraOptions are not applied.
+	if h.IsKubectl() {
+		return ssh.KubectlExecCommand(
+			ssh.OptionKubeNamespace{Value: h.KubeNamespace},
+			ssh.OptionKubePod{Value: h.KubePod},
+			ssh.OptionKubeContainer{Value: h.KubeContainer},
+		)
+	}
+
+	// Teleport manages keys, passwords and ssh options by itself, so extraOptions are not applied either.
+	if h.IsTeleport() {
+		return ssh.TeleportConnectCommand(
+			ssh.OptionTeleportCluster{Value: h.TeleportCluster},
+			ssh.OptionRemotePort{Value: h.RemotePort},
+			ssh.OptionLoginName{Value: h.LoginName},
+			ssh.OptionAddress{Value: h.Address},
+		)
+	}
+
+	// Host alias from ~/.ssh/config already contains all connection parameters.
+	if h.SSHAlias != "" {
+		return ssh.ConnectCommand(append(extraOptions, ssh.OptionAddress{Value: h.SSHAlias})...)
+	}
+
 	if h.IsUserDefinedSSHCommand() {
-		return ssh.ConnectCommand(ssh.OptionAddress{Value: h.Address})
+		return ssh.ConnectCommand(append(extraOptions, ssh.OptionAddress{Value: h.Address})...)
 	}
 
 	options := []ssh.Option{
+		// ssh uses the first value of an option, so extra options go first to override the modelled ones.
+		ssh.OptionExtra{Value: h.ExtraOptions},
 		ssh.OptionPrivateKey{Value: h.IdentityFilePath},
+		// Without an identity file ssh would have no keys to offer at all, so the option is skipped.
+		ssh.OptionIdentitiesOnly{Value: h.IdentitiesOnly && strings.TrimSpace(h.IdentityFilePath) != ""},
 		ssh.OptionRemotePort{Value: h.RemotePort},
 		ssh.OptionLoginName{Value: h.LoginName},
-		ssh.OptionAddress{Value: h.Address},
+		ssh.OptionGatewayPorts{Value: h.GatewayPorts},
+		ssh.OptionEscapeChar{Value: h.EscapeChar},
+		ssh.OptionBindAddress{Value: h.BindAddress},
+		ssh.OptionHostKeyAlias{Value: h.HostKeyAlias},
+		ssh.OptionStrictHostKeyChecking{Value: h.StrictHostKeyChecking},
+		ssh.OptionConnectTimeout{Value: h.ConnectTimeout},
+		ssh.OptionServerAliveInterval{Value: h.KeepAliveInterval},
+		ssh.OptionServerAliveCountMax{Value: h.KeepAliveCountMax},
+		ssh.OptionForwardAgent{Value: h.ForwardAgent},
+		ssh.OptionCompression{Value: h.Compression},
+		ssh.OptionCompressionLevel{Value: lo.Ternary(h.Compression, h.CompressionLevel, "")},
+		ssh.OptionLocalForward{Value: h.LocalForwards},
+		ssh.OptionRemoteForward{Value: h.RemoteForwards},
+		ssh.OptionDynamicForward{Value: h.DynamicForward},
+	}
+	options = append(options, extraOptions...)
+	options = append(options, ssh.OptionAddress{Value: h.Address})
+
+	if !h.UsesPassword() {
+		return ssh.ConnectCommand(options...)
+	}
+
+	if h.PasswordCommand != "" {
+		// Password is read from SSHPASS environment variable which is set when the process is launched.
+		return fmt.Sprintf("sshpass -e %s", ssh.ConnectCommand(options...))
 	}
 
 	if h.Password != "" {
-		return fmt.Sprintf("sshpass -p '%s' %s", h.Password, ssh.ConnectCommand(options...))
+		return fmt.Sprintf("%s %s", sshpassPasswordOption(h.Password), ssh.ConnectCommand(options...))
 	}
 
 	return ssh.ConnectCommand(options...)
 }
 
+func sshpassPasswordOption(password string) string {
+	return fmt.Sprintf("sshpass -p '%s'", password)
+}
+
+// RedactPassword - hides password of the host in the command which is built by CmdSSHConnect and alike,
+// so that the command can be displayed or shared.
+func RedactPassword(command, password string) string {
+	if password == "" {
+		return command
+	}
+
+	return strings.Replace(command, sshpassPasswordOption(password), sshpassPasswordOption("*****"), 1)
+}
+
+// CmdSSHProbeRemoteOS - returns SSH command which prints the name of the remote operating system. The command
+// runs in background, that's why ssh is not allowed to ask for a password.
+func (h *Host) CmdSSHProbeRemoteOS() string {
+	if h.IsKubectl() {
+		return ssh.KubectlExecCommand(
+			ssh.OptionKubeNamespace{Value: h.KubeNamespace},
+			ssh.OptionKubePod{Value: h.KubePod},
+			ssh.OptionKubeContainer{Value: h.KubeContainer},
+			ssh.OptionKubeCommand{Value: ssh.RemoteOSProbeCommand},
+		)
+	}
+
+	options := append(h.proxyJumpOptions(), ssh.OptionBatchMode{})
+	return fmt.Sprintf("%s %s", h.cmdSSHConnect(options), ssh.RemoteOSProbeCommand)
+}
+
+// CmdSSHCheckAuth - returns SSH command which logs in to the remote host and exits without opening a shell.
+// It's used to check that user can authenticate, so ssh is not allowed to ask for a password.
+func (h *Host) CmdSSHCheckAuth() string {
+	if h.IsKubectl() {
+		return ssh.KubectlExecCommand(
+			ssh.OptionKubeNamespace{Value: h.KubeNamespace},
+			ssh.OptionKubePod{Value: h.KubePod},
+			ssh.OptionKubeContainer{Value: h.KubeContainer},
+			ssh.OptionKubeCommand{Value: ssh.AuthCheckCommand},
+		)
+	}
+
+	options := append(h.proxyJumpOptions(), ssh.OptionBatchMode{})
+	return fmt.Sprintf("%s %s", h.cmdSSHConnect(options), ssh.AuthCheckCommand)
+}
+
 // CmdSSHConfig - returns SSH command for loading host default configuration.
 func (h *Host) CmdSSHConfig() string {
+	if h.SSHAlias != "" {
+		return ssh.LoadConfigCommand(ssh.OptionReadConfig{Value: h.SSHAlias})
+	}
+
 	if h.IsUserDefinedSSHCommand() {
 		return ssh.LoadConfigCommand(ssh.OptionReadConfig{Value: h.Address})
 	}
@@ -107,3 +675,42 @@ func (h *Host) CmdSSHCopyID() string {
 		ssh.OptionAddress{Value: hostname},
 	)
 }
+
+// CmdSSHFS - returns sshfs command for mounting remote file system into mountPoint.
+func (h *Host) CmdSSHFS(mountPoint string) string {
+	hostname, loginName, remotePort, identityFile := h.fileTransferParameters()
+
+	return ssh.SSHFSCommand(
+		mountPoint,
+		ssh.OptionAddress{Value: hostname},
+		ssh.OptionLoginName{Value: loginName},
+		ssh.OptionRemotePort{Value: remotePort},
+		ssh.OptionPrivateKey{Value: identityFile},
+	)
+}
+
+// CmdSFTP - returns sftp command for an interactive file transfer session with the remote host.
+func (h *Host) CmdSFTP() string {
+	hostname, loginName, remotePort, identityFile := h.fileTransferParameters()
+
+	return ssh.SFTPCommand(
+		ssh.OptionAddress{Value: hostname},
+		ssh.OptionLoginName{Value: loginName},
+		ssh.OptionRemotePort{Value: remotePort},
+		ssh.OptionPrivateKey{Value: identityFile},
+	)
+}
+
+// fileTransferParameters - returns address, login name, port and identity file of sshfs and sftp commands.
+// Connection parameters of custom connect string or ssh alias are only known from ssh config.
+func (h *Host) fileTransferParameters() (hostname, loginName, remotePort, identityFile string) {
+	if h.SSHAlias == "" && !h.IsUserDefinedSSHCommand() {
+		return h.Address, h.LoginName, h.RemotePort, h.IdentityFilePath
+	}
+
+	if h.SSHClientConfig == nil {
+		return h.SSHAlias, "", "", ""
+	}
+
+	return h.SSHClientConfig.Hostname, h.SSHClientConfig.User, h.SSHClientConfig.Port, h.SSHClientConfig.IdentityFile
+}
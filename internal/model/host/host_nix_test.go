@@ -26,6 +26,31 @@ func TestIsUserDefinedSSHCommand(t *testing.T) {
 			},
 			expected: "ssh -i /tmp -p 2222 -l root localhost",
 		},
+		{
+			name: "NOT user defined ssh command with gateway ports",
+			host: Host{
+				Address:      "localhost",
+				GatewayPorts: "clientspecified",
+			},
+			expected: "ssh -o GatewayPorts=clientspecified localhost",
+		},
+		{
+			name: "NOT user defined ssh command with password",
+			host: Host{
+				Address:  "localhost",
+				Password: "secret",
+			},
+			expected: "sshpass -p 'secret' ssh localhost",
+		},
+		{
+			name: "NOT user defined ssh command with password command",
+			host: Host{
+				Address:         "localhost",
+				Password:        "ignored",
+				PasswordCommand: "pass show servers/db",
+			},
+			expected: "sshpass -e ssh localhost",
+		},
 		{
 			name: "User defined ssh command",
 			host: Host{
@@ -40,9 +65,30 @@ func TestIsUserDefinedSSHCommand(t *testing.T) {
 				RemotePort:       "2222",
 				LoginName:        "root",
 				IdentityFilePath: "/tmp",
+				GatewayPorts:     "yes",
 			},
 			expected: "ssh username@localhost",
 		},
+		{
+			name: "SSH config alias",
+			host: Host{
+				SSHAlias: "prod-db",
+			},
+			expected: "ssh prod-db",
+		},
+		{
+			name: "SSH config alias - other parameters ignored",
+			host: Host{
+				SSHAlias:         "prod-db",
+				Address:          "username@localhost",
+				RemotePort:       "2222",
+				LoginName:        "root",
+				IdentityFilePath: "/tmp",
+				GatewayPorts:     "yes",
+				Password:         "secret",
+			},
+			expected: "ssh prod-db",
+		},
 	}
 
 	for _, tt := range tests {
@@ -53,6 +99,139 @@ func TestIsUserDefinedSSHCommand(t *testing.T) {
 	}
 }
 
+func TestCmdSSHConnectWithForwardPreset(t *testing.T) {
+	presets := map[string][]string{
+		"db":  {"-L 5432:localhost:5432"},
+		"web": {"-L 8080:localhost:80", "-R 9000:localhost:9000"},
+	}
+
+	tests := []struct {
+		name     string
+		host     Host
+		preset   string
+		expected string
+	}{
+		{
+			name:     "Single forward",
+			host:     Host{Address: "localhost", LoginName: "root", ForwardPresets: presets},
+			preset:   "db",
+			expected: "ssh -l root -L 5432:localhost:5432 localhost",
+		},
+		{
+			name:     "Multiple forwards",
+			host:     Host{Address: "localhost", ForwardPresets: presets},
+			preset:   "web",
+			expected: "ssh -L 8080:localhost:80 -R 9000:localhost:9000 localhost",
+		},
+		{
+			name:     "No preset selected",
+			host:     Host{Address: "localhost", ForwardPresets: presets},
+			preset:   "",
+			expected: "ssh localhost",
+		},
+		{
+			name:     "Unknown preset",
+			host:     Host{Address: "localhost", ForwardPresets: presets},
+			preset:   "unknown",
+			expected: "ssh localhost",
+		},
+		{
+			name:     "User defined ssh command",
+			host:     Host{Address: "root@localhost", ForwardPresets: presets},
+			preset:   "db",
+			expected: "ssh -L 5432:localhost:5432 root@localhost",
+		},
+		{
+			name:     "SSH config alias",
+			host:     Host{SSHAlias: "prod-db", ForwardPresets: presets},
+			preset:   "db",
+			expected: "ssh -L 5432:localhost:5432 prod-db",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.host.CmdSSHConnectWithForwardPreset(tt.preset)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCmdSSHConnectWithProfile(t *testing.T) {
+	h := Host{
+		Address:   "jump.internal",
+		LoginName: "root",
+		Profiles: map[string]Profile{
+			"work-hours": {Address: "jump.office.example.com", LoginName: "admin"},
+			"off-hours":  {RemotePort: "2222"},
+		},
+	}
+
+	profiled := h.WithProfile("work-hours")
+	require.Equal(t, "ssh -l admin jump.office.example.com", profiled.CmdSSHConnect())
+	profiled = h.WithProfile("off-hours")
+	require.Equal(t, "ssh -p 2222 -l root jump.internal", profiled.CmdSSHConnect())
+	require.Equal(t, "ssh -l root jump.internal", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_IdentitiesOnly(t *testing.T) {
+	h := Host{Address: "localhost", IdentityFilePath: "/tmp/id_rsa", IdentitiesOnly: true}
+	require.Equal(t, "ssh -i /tmp/id_rsa -o IdentitiesOnly=yes localhost", h.CmdSSHConnect())
+
+	// Without identity file the option is skipped, otherwise ssh would not offer any key
+	h.IdentityFilePath = " "
+	require.Equal(t, "ssh localhost", h.CmdSSHConnect())
+
+	h = Host{Address: "localhost", IdentityFilePath: "/tmp/id_rsa"}
+	require.Equal(t, "ssh -i /tmp/id_rsa localhost", h.CmdSSHConnect())
+}
+
+func TestCmdSSHFastConnect(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     Host
+		expected string
+	}{
+		{
+			name: "Only host attributes are used",
+			host: Host{
+				Address:          "localhost",
+				RemotePort:       "2222",
+				LoginName:        "root",
+				IdentityFilePath: "/tmp",
+				SSHClientConfig:  &ssh.Config{Hostname: "ignored", Port: "22", User: "ignored"},
+			},
+			expected: "ssh -i /tmp -p 2222 -l root -F none localhost",
+		},
+		{
+			name:     "Address only",
+			host:     Host{Address: "localhost"},
+			expected: "ssh -F none localhost",
+		},
+		{
+			name:     "User defined ssh command",
+			host:     Host{Address: "-p 2222 root@localhost"},
+			expected: "ssh -F none -p 2222 root@localhost",
+		},
+		{
+			name:     "With password",
+			host:     Host{Address: "localhost", Password: "secret"},
+			expected: "sshpass -p 'secret' ssh -F none localhost",
+		},
+		{
+			name:     "SSH config alias cannot be resolved without config",
+			host:     Host{SSHAlias: "prod-db"},
+			expected: "ssh prod-db",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.host.CmdSSHFastConnect())
+		})
+	}
+}
+
 func TestCmdSSHConfig(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -86,6 +265,15 @@ func TestCmdSSHConfig(t *testing.T) {
 			},
 			expected: "ssh -G username@localhost",
 		},
+		{
+			name: "SSH config alias - other parameters ignored",
+			host: Host{
+				SSHAlias:   "prod-db",
+				Address:    "localhost",
+				RemotePort: "2222",
+			},
+			expected: "ssh -G prod-db",
+		},
 	}
 
 	for _, tt := range tests {
@@ -123,3 +311,125 @@ func TestCmdSSHCopyID(t *testing.T) {
 		})
 	}
 }
+
+func TestCmdSSHFS(t *testing.T) {
+	tests := []struct {
+		name       string
+		host       Host
+		mountPoint string
+		expected   string
+	}{
+		{
+			name:       "Address only",
+			host:       Host{Address: "localhost"},
+			mountPoint: "/mnt/remote",
+			expected:   "sshfs localhost:/ /mnt/remote",
+		},
+		{
+			name:       "With login, port and identity file",
+			host:       Host{Address: "localhost", LoginName: "root", RemotePort: "2222", IdentityFilePath: "/tmp/id_rsa"},
+			mountPoint: "/mnt/remote",
+			expected:   "sshfs root@localhost:/ /mnt/remote -p 2222 -o IdentityFile=/tmp/id_rsa",
+		},
+		{
+			name:       "With identity file, without port",
+			host:       Host{Address: "localhost", LoginName: "root", IdentityFilePath: "/tmp/id_rsa"},
+			mountPoint: "/mnt/remote",
+			expected:   "sshfs root@localhost:/ /mnt/remote -o IdentityFile=/tmp/id_rsa",
+		},
+		{
+			name:       "With port, without identity file",
+			host:       Host{Address: "localhost", RemotePort: "2222"},
+			mountPoint: "/mnt/remote",
+			expected:   "sshfs localhost:/ /mnt/remote -p 2222",
+		},
+		{
+			name:       "Mount point with spaces",
+			host:       Host{Address: "localhost"},
+			mountPoint: "/mnt/remote host",
+			expected:   `sshfs localhost:/ "/mnt/remote host"`,
+		},
+		{
+			name: "User defined ssh command - parameters are taken from ssh config",
+			host: Host{
+				Address:         "-p 2222 root@localhost",
+				SSHClientConfig: &ssh.Config{Hostname: "localhost", User: "root", Port: "2222", IdentityFile: "/tmp/id_rsa"},
+			},
+			mountPoint: "/mnt/remote",
+			expected:   "sshfs root@localhost:/ /mnt/remote -p 2222 -o IdentityFile=/tmp/id_rsa",
+		},
+		{
+			name:       "SSH config alias without loaded config",
+			host:       Host{SSHAlias: "prod-db", LoginName: "ignored", RemotePort: "2222"},
+			mountPoint: "/mnt/remote",
+			expected:   "sshfs prod-db:/ /mnt/remote",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.host.CmdSSHFS(tt.mountPoint)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCmdSFTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     Host
+		expected string
+	}{
+		{
+			name:     "Address only",
+			host:     Host{Address: "localhost"},
+			expected: "sftp localhost",
+		},
+		{
+			name:     "With login, port and identity file",
+			host:     Host{Address: "localhost", LoginName: "root", RemotePort: "2222", IdentityFilePath: "/tmp/id_rsa"},
+			expected: "sftp -P 2222 -i /tmp/id_rsa root@localhost",
+		},
+		{
+			name: "User defined ssh command - parameters are taken from ssh config",
+			host: Host{
+				Address:         "-p 2222 root@localhost",
+				SSHClientConfig: &ssh.Config{Hostname: "localhost", User: "root", Port: "2222", IdentityFile: "/tmp/id_rsa"},
+			},
+			expected: "sftp -P 2222 -i /tmp/id_rsa root@localhost",
+		},
+		{
+			name:     "User defined ssh command without loaded config",
+			host:     Host{Address: "-p 2222 root@localhost"},
+			expected: "sftp",
+		},
+		{
+			name:     "SSH config alias without loaded config",
+			host:     Host{SSHAlias: "prod-db", LoginName: "ignored", RemotePort: "2222"},
+			expected: "sftp prod-db",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.host.CmdSFTP())
+		})
+	}
+}
+
+func TestCmdSSHProbeRemoteOS(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root"}
+	require.Equal(t, "ssh -l root -o BatchMode=yes localhost uname -s", h.CmdSSHProbeRemoteOS())
+
+	h = Host{SSHAlias: "prod"}
+	require.Equal(t, "ssh -o BatchMode=yes prod uname -s", h.CmdSSHProbeRemoteOS())
+}
+
+func TestCmdSSHCheckAuth(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", RemotePort: "2222"}
+	require.Equal(t, "ssh -p 2222 -l root -o BatchMode=yes localhost true", h.CmdSSHCheckAuth())
+
+	// Authentication check of a background tunnel doesn't go to background
+	h.BackgroundTunnel = true
+	require.Equal(t, "ssh -p 2222 -l root -o BatchMode=yes localhost true", h.CmdSSHCheckAuth())
+}
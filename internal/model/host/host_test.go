@@ -1,10 +1,16 @@
 package host
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
+
+	"github.com/grafviktor/goto/internal/model/ssh"
+	"github.com/grafviktor/goto/internal/utils"
 )
 
 func TestNewHost(t *testing.T) {
@@ -30,13 +36,48 @@ func TestNewHost(t *testing.T) {
 func TestCloneHost(t *testing.T) {
 	// Create a host to clone
 	originalHost := Host{
-		ID:               1,
-		Title:            "TestTitle",
-		Description:      "TestDescription",
-		Address:          "TestAddress",
-		RemotePort:       "1234",
-		LoginName:        "TestUser",
-		IdentityFilePath: "/path/to/private/key",
+		ID:                    1,
+		Title:                 "TestTitle",
+		Description:           "TestDescription",
+		Tags:                  []string{"web", "prod"},
+		Address:               "TestAddress",
+		RemotePort:            "1234",
+		LoginName:             "TestUser",
+		IdentityFilePath:      "/path/to/private/key",
+		IdentityFiles:         []string{"/path/to/another/key"},
+		ForwardAgent:          true,
+		Compression:           true,
+		CompressionLevel:      "6",
+		SSHAlias:              "TestAlias",
+		EnvFile:               "~/.config/env/test.env",
+		EscapeChar:            "none",
+		BindAddress:           "192.168.1.10",
+		HostKeyAlias:          "db-primary",
+		StrictHostKeyChecking: "accept-new",
+		ConnectTimeout:        "10",
+		KeepAliveInterval:     "30",
+		KeepAliveCountMax:     "3",
+		LogPath:               "/var/log/syslog",
+		Sudo:                  true,
+		UsePassword:           lo.ToPtr(false),
+		Protocol:              "teleport",
+		TeleportCluster:       "production",
+		KubeNamespace:         "default",
+		KubePod:               "web-0",
+		KubeContainer:         "nginx",
+		WebURL:                "https://{address}:8443",
+		Notes:                 "Production",
+		ConfirmByName:         true,
+		IsFavorite:            true,
+		LocalForwards:         []string{"8080:localhost:80"},
+		RemoteForwards:        []string{"9000:localhost:3000"},
+		ExtraOptions:          []string{"RekeyLimit=1G"},
+		Provider:              "aws",
+		DynamicForward:        "1080",
+		BackgroundTunnel:      true,
+		AutoConnect:           true,
+		ForwardPresets:        map[string][]string{"db": {"-L 5432:localhost:5432"}},
+		Profiles:              map[string]Profile{"off-hours": {Address: "vpn.example.com"}},
 	}
 
 	// Clone the host
@@ -62,6 +103,58 @@ func TestCloneHost(t *testing.T) {
 	if clonedHost.Address == originalHost.Address {
 		t.Error("Modifying the cloned host should not affect the original host")
 	}
+
+	clonedHost.ForwardPresets["db"][0] = "-L 3306:localhost:3306"
+	require.Equal(t, "-L 5432:localhost:5432", originalHost.ForwardPresets["db"][0])
+
+	clonedHost.Profiles["off-hours"] = Profile{Address: "modified.example.com"}
+	require.Equal(t, "vpn.example.com", originalHost.Profiles["off-hours"].Address)
+}
+
+func TestForwardPresetNames(t *testing.T) {
+	h := Host{ForwardPresets: map[string][]string{"web": nil, "db": nil, "cache": nil}}
+	require.Equal(t, []string{"cache", "db", "web"}, h.ForwardPresetNames())
+	require.Empty(t, (&Host{}).ForwardPresetNames())
+}
+
+func TestProfileNames(t *testing.T) {
+	h := Host{Profiles: map[string]Profile{"work-hours": {}, "off-hours": {}}}
+	require.Equal(t, []string{"off-hours", "work-hours"}, h.ProfileNames())
+	require.Empty(t, (&Host{}).ProfileNames())
+}
+
+func TestWithProfile(t *testing.T) {
+	base := Host{
+		ID:               1,
+		Title:            "db",
+		Address:          "db.internal",
+		RemotePort:       "22",
+		LoginName:        "root",
+		IdentityFilePath: "~/.ssh/id_work",
+		Profiles: map[string]Profile{
+			"off-hours": {Address: "db.example.com", RemotePort: "2222", IdentityFilePath: "~/.ssh/id_home"},
+			"blank":     {Address: "  "},
+		},
+	}
+
+	profiled := base.WithProfile("off-hours")
+	// Overridden attributes
+	require.Equal(t, "db.example.com", profiled.Address)
+	require.Equal(t, "2222", profiled.RemotePort)
+	require.Equal(t, "~/.ssh/id_home", profiled.IdentityFilePath)
+	// Attributes which are not set in the profile are taken from the base host
+	require.Equal(t, 1, profiled.ID)
+	require.Equal(t, "db", profiled.Title)
+	require.Equal(t, "root", profiled.LoginName)
+	// Base host is not modified
+	require.Equal(t, "db.internal", base.Address)
+	require.Equal(t, "22", base.RemotePort)
+
+	// Blank values do not override the base host
+	require.Equal(t, "db.internal", base.WithProfile("blank").Address)
+	// Unknown and empty profile names return an unchanged copy
+	require.Equal(t, base, base.WithProfile("unknown"))
+	require.Equal(t, base, base.WithProfile(""))
 }
 
 func TestCmdSSHConnect(t *testing.T) {
@@ -99,3 +192,490 @@ func TestCmdSSHConnect(t *testing.T) {
 		})
 	}
 }
+
+func TestHasDestination(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		expected bool
+	}{
+		{"Hostname", "localhost", true},
+		{"Empty address", "", false},
+		{"Whitespaces only", "   ", false},
+		{"Custom connect string", "root@localhost", true},
+		{"Custom connect string with options", "-p 2222 -i ~/.ssh/id_rsa root@localhost", true},
+		{"Custom connect string without hostname", "root@", false},
+		{"Custom connect string with options only", "-p 2222 -v", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host := Host{Address: tt.address}
+			require.Equal(t, tt.expected, host.HasDestination())
+		})
+	}
+}
+
+func TestHasDestination_SSHAlias(t *testing.T) {
+	host := Host{SSHAlias: "prod-db"}
+	require.True(t, host.HasDestination())
+}
+
+func TestResolveWebURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     Host
+		expected string
+	}{
+		{
+			name:     "Address is substituted",
+			host:     Host{Address: "web-01.example.com", WebURL: "https://{address}:8443/admin"},
+			expected: "https://web-01.example.com:8443/admin",
+		},
+		{
+			name:     "Address is substituted several times",
+			host:     Host{Address: " 10.0.0.1 ", WebURL: "http://{address}/?redirect=http://{address}/login"},
+			expected: "http://10.0.0.1/?redirect=http://10.0.0.1/login",
+		},
+		{
+			name:     "Url without placeholder",
+			host:     Host{Address: "localhost", WebURL: "https://admin.example.com"},
+			expected: "https://admin.example.com",
+		},
+		{
+			name: "Custom connect string - hostname is taken from ssh config",
+			host: Host{
+				Address:         "-p 2222 root@web-01",
+				WebURL:          "https://{address}",
+				SSHClientConfig: &ssh.Config{Hostname: "web-01.example.com"},
+			},
+			expected: "https://web-01.example.com",
+		},
+		{
+			name: "SSH alias - hostname is taken from ssh config",
+			host: Host{
+				SSHAlias:        "prod",
+				WebURL:          "https://{address}",
+				SSHClientConfig: &ssh.Config{Hostname: "10.0.0.2"},
+			},
+			expected: "https://10.0.0.2",
+		},
+		{
+			name:     "Empty url",
+			host:     Host{Address: "localhost"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.host.ResolveWebURL())
+		})
+	}
+}
+
+func TestRenderConnectTemplate(t *testing.T) {
+	h := Host{Address: "web.example.com", RemotePort: "2222", LoginName: "admin"}
+	actual, err := RenderConnectTemplate("ssh -p {{.RemotePort}} {{.LoginName}}@{{.Address}}", h)
+	require.NoError(t, err)
+	require.Equal(t, "ssh -p 2222 admin@web.example.com", actual)
+
+	// Connect template overrides the command which is assembled from the host attributes
+	h.ConnectTemplate = "mosh {{.LoginName}}@{{.Address}}"
+	require.Equal(t, "mosh admin@web.example.com", h.CmdSSHConnect())
+	require.Equal(t, "mosh admin@web.example.com", h.CmdSSHFastConnect())
+}
+
+func TestValidateConnectTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    string
+		expectError bool
+	}{
+		{"Empty template", "  ", false},
+		{"Valid template", "ssh {{.LoginName}}@{{.Address}}", false},
+		{"Template without actions", "ssh localhost", false},
+		{"Parse error", "ssh {{.Address", true},
+		{"Unknown attribute", "ssh {{.Hostname}}", true},
+		{"Empty command", "{{if .Password}}ssh{{end}}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConnectTemplate(tt.template)
+			require.Equal(t, tt.expectError, err != nil, "Template: %q, error: %v", tt.template, err)
+		})
+	}
+}
+
+func TestCmdSSHConnect_BrokenConnectTemplate(t *testing.T) {
+	// Hosts file can be edited manually, broken template is ignored
+	h := Host{Address: "localhost", ConnectTemplate: "ssh {{.Unknown}}"}
+	expected := Host{Address: "localhost"}
+	require.Equal(t, expected.CmdSSHConnect(), h.CmdSSHConnect())
+}
+
+func TestConnectsToLoopback(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     Host
+		expected bool
+	}{
+		{"Loopback address", Host{Address: "127.0.0.1"}, true},
+		{"Loopback address with login name", Host{Address: "root@localhost"}, true},
+		{"Remote address", Host{Address: "root@example.com"}, false},
+		{"Alias resolved to loopback", Host{SSHAlias: "tunnel", SSHClientConfig: &ssh.Config{Hostname: "localhost"}}, true},
+		{"Alias which is not resolved yet", Host{SSHAlias: "tunnel"}, false},
+		{"Custom command resolved to remote", Host{Address: "-p 2222 root@tunnel", SSHClientConfig: &ssh.Config{Hostname: "example.com"}}, false},
+		{"Custom command resolved to loopback", Host{Address: "-p 2222 root@tunnel", SSHClientConfig: &ssh.Config{Hostname: "::1"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.host.ConnectsToLoopback())
+		})
+	}
+}
+
+func TestHasTag(t *testing.T) {
+	h := Host{Tags: []string{"Web", " prod "}}
+	require.True(t, h.HasTag("web"))
+	require.True(t, h.HasTag("PROD"))
+	require.False(t, h.HasTag("db"))
+	require.False(t, (&Host{}).HasTag("web"))
+}
+
+func TestResolvedIdentityFile(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	h := Host{}
+	require.Empty(t, h.ResolvedIdentityFile())
+
+	// Identity file from ssh config is used when the host doesn't have one, "~" is expanded
+	h.SSHClientConfig = &ssh.Config{IdentityFile: "~/.ssh/id_ed25519"}
+	require.Equal(t, filepath.Join(homeDir, ".ssh/id_ed25519"), h.ResolvedIdentityFile())
+
+	// Identity file of the host takes precedence
+	h.IdentityFilePath = " ~/.ssh/work_key "
+	require.Equal(t, filepath.Join(homeDir, ".ssh/work_key"), h.ResolvedIdentityFile())
+
+	h.IdentityFilePath = "/keys/id_rsa"
+	require.Equal(t, "/keys/id_rsa", h.ResolvedIdentityFile())
+}
+
+func TestIdentityFileCandidates(t *testing.T) {
+	h := Host{IdentityFiles: []string{"~/.ssh/id_ed25519", " ", "~/.ssh/id_rsa"}}
+	require.Equal(t, []string{"~/.ssh/id_ed25519", "~/.ssh/id_rsa"}, h.IdentityFileCandidates())
+
+	// Identity file of the host goes first, duplicates are skipped
+	h.IdentityFilePath = "~/.ssh/id_rsa"
+	require.Equal(t, []string{"~/.ssh/id_rsa", "~/.ssh/id_ed25519"}, h.IdentityFileCandidates())
+
+	require.Equal(t, "~/.ssh/id_ed25519", h.WithIdentityFile("~/.ssh/id_ed25519").IdentityFilePath)
+	require.Equal(t, "~/.ssh/id_rsa", h.WithIdentityFile("").IdentityFilePath)
+	require.Equal(t, "~/.ssh/id_rsa", h.IdentityFilePath)
+}
+
+func TestCmdSSHConnect_UsePassword(t *testing.T) {
+	h := Host{Address: "node1", LoginName: "root", Password: "secret"}
+	command := ssh.BaseCMD() + " -l root node1"
+	// Password is used by default
+	require.True(t, h.UsesPassword())
+	require.Equal(t, "sshpass -p 'secret' "+command, h.CmdSSHConnect())
+
+	h.UsePassword = lo.ToPtr(true)
+	require.Equal(t, "sshpass -p 'secret' "+command, h.CmdSSHConnect())
+
+	// Password is kept for reference only
+	h.UsePassword = lo.ToPtr(false)
+	require.False(t, h.UsesPassword())
+	require.Equal(t, command, h.CmdSSHConnect())
+	require.NotContains(t, h.CmdSSHFastConnect(), "secret")
+
+	h.Password = ""
+	h.PasswordCommand = "pass show node1"
+	require.Equal(t, command, h.CmdSSHConnect())
+}
+
+func TestRedactPassword(t *testing.T) {
+	h := Host{Address: "node1", Password: "root"}
+	require.Equal(t, "sshpass -p '*****' "+ssh.BaseCMD()+" node1", RedactPassword(h.CmdSSHConnect(), h.Password))
+
+	// Commands without a password are not changed
+	h.Password = ""
+	require.Equal(t, h.CmdSSHConnect(), RedactPassword(h.CmdSSHConnect(), h.Password))
+}
+
+func TestCmdSSHConnect_EscapeChar(t *testing.T) {
+	tests := []struct {
+		escapeChar string
+		expected   string
+	}{
+		{"", ssh.BaseCMD() + " -l root localhost"},
+		{"^", ssh.BaseCMD() + " -l root -e ^ localhost"},
+		{"none", ssh.BaseCMD() + " -l root -e none localhost"},
+		{" % ", ssh.BaseCMD() + " -l root -e % localhost"},
+	}
+
+	for _, tt := range tests {
+		h := Host{Address: "localhost", LoginName: "root", EscapeChar: tt.escapeChar}
+		require.Equal(t, tt.expected, h.CmdSSHConnect(), "Unexpected command for escape character %q", tt.escapeChar)
+	}
+}
+
+func TestCmdSSHConnect_BindAddress(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", BindAddress: "192.168.1.10"}
+	require.Equal(t, ssh.BaseCMD()+" -l root -b 192.168.1.10 localhost", h.CmdSSHConnect())
+	require.Equal(t, ssh.BaseCMD()+" -l root -b 192.168.1.10 -F none localhost", h.CmdSSHFastConnect())
+
+	h.BindAddress = "fe80::1"
+	require.Equal(t, ssh.BaseCMD()+" -l root -b fe80::1 localhost", h.CmdSSHConnect())
+
+	h.BindAddress = ""
+	require.Equal(t, ssh.BaseCMD()+" -l root localhost", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_ForwardAgent(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", ForwardAgent: true}
+	require.Equal(t, ssh.BaseCMD()+" -l root -o ForwardAgent=yes localhost", h.CmdSSHConnect())
+
+	h.ForwardAgent = false
+	require.Equal(t, ssh.BaseCMD()+" -l root localhost", h.CmdSSHConnect())
+
+	// User-defined connect string is passed to ssh as is
+	h = Host{Address: "root@localhost -p 2222", ForwardAgent: true}
+	require.Equal(t, ssh.BaseCMD()+" root@localhost -p 2222", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_Compression(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", Compression: true, CompressionLevel: "6"}
+	require.Equal(t, ssh.BaseCMD()+" -l root -C -o CompressionLevel=6 localhost", h.CmdSSHConnect())
+
+	h.CompressionLevel = ""
+	require.Equal(t, ssh.BaseCMD()+" -l root -C localhost", h.CmdSSHConnect())
+
+	// Compression level is not applied without compression
+	h = Host{Address: "localhost", LoginName: "root", CompressionLevel: "6"}
+	require.Equal(t, ssh.BaseCMD()+" -l root localhost", h.CmdSSHConnect())
+
+	// User-defined connect string is passed to ssh as is
+	h = Host{Address: "root@localhost -p 2222", Compression: true, CompressionLevel: "6"}
+	require.Equal(t, ssh.BaseCMD()+" root@localhost -p 2222", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_LocalForwards(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", LocalForwards: []string{"8080:localhost:80", "5432:db:5432"}}
+	require.Equal(t, ssh.BaseCMD()+" -l root -L 8080:localhost:80 -L 5432:db:5432 localhost", h.CmdSSHConnect())
+
+	h.LocalForwards = nil
+	require.Equal(t, ssh.BaseCMD()+" -l root localhost", h.CmdSSHConnect())
+
+	// User-defined connect string is passed to ssh as is
+	h = Host{Address: "root@localhost -p 2222", LocalForwards: []string{"8080:localhost:80"}}
+	require.Equal(t, ssh.BaseCMD()+" root@localhost -p 2222", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_RemoteForwards(t *testing.T) {
+	h := Host{
+		Address:        "localhost",
+		LoginName:      "root",
+		LocalForwards:  []string{"8080:localhost:80"},
+		RemoteForwards: []string{"9000:localhost:3000", "*:8081:localhost:81"},
+	}
+	expected := ssh.BaseCMD() + " -l root -L 8080:localhost:80 -R 9000:localhost:3000 -R *:8081:localhost:81 localhost"
+	require.Equal(t, expected, h.CmdSSHConnect())
+
+	// User-defined connect string is passed to ssh as is
+	h = Host{Address: "root@localhost -p 2222", RemoteForwards: []string{"9000:localhost:3000"}}
+	require.Equal(t, ssh.BaseCMD()+" root@localhost -p 2222", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_ExtraOptions(t *testing.T) {
+	h := Host{
+		Address:        "localhost",
+		LoginName:      "root",
+		ConnectTimeout: "10",
+		DynamicForward: "1080",
+		ExtraOptions:   []string{"ConnectTimeout=30", "Ciphers=aes256-ctr,aes128-ctr"},
+	}
+	// Extra options precede all modelled options, so ssh takes ConnectTimeout from the extra options
+	expected := ssh.BaseCMD() + " -o ConnectTimeout=30 -o Ciphers=aes256-ctr,aes128-ctr" +
+		" -l root -o ConnectTimeout=10 -D 1080 localhost"
+	require.Equal(t, expected, h.CmdSSHConnect())
+
+	// User-defined connect string is passed to ssh as is
+	h = Host{Address: "root@localhost -p 2222", ExtraOptions: []string{"RekeyLimit=1G"}}
+	require.Equal(t, ssh.BaseCMD()+" root@localhost -p 2222", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_DynamicForward(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", DynamicForward: "1080"}
+	require.Equal(t, ssh.BaseCMD()+" -l root -D 1080 localhost", h.CmdSSHConnect())
+
+	h.DynamicForward = ""
+	require.Equal(t, ssh.BaseCMD()+" -l root localhost", h.CmdSSHConnect())
+
+	// User-defined connect string is passed to ssh as is
+	h = Host{Address: "root@localhost -p 2222", DynamicForward: "1080"}
+	require.Equal(t, ssh.BaseCMD()+" root@localhost -p 2222", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_BackgroundTunnel(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", LocalForwards: []string{"5432:db:5432"}, BackgroundTunnel: true}
+	// Remote command is not attached, the address is the last argument
+	expected := ssh.BaseCMD() + " -l root -L 5432:db:5432 -f -N -T localhost"
+	require.Equal(t, expected, h.CmdSSHConnect())
+	require.Equal(t, expected, h.CmdSSHConnectWithForwardPreset("unknown"))
+	require.Equal(t, ssh.BaseCMD()+" -l root -L 5432:db:5432 -F none -f -N -T localhost", h.CmdSSHFastConnect())
+
+	h.BackgroundTunnel = false
+	require.Equal(t, ssh.BaseCMD()+" -l root -L 5432:db:5432 localhost", h.CmdSSHConnect())
+
+	// Host alias gets the flags as well
+	h = Host{SSHAlias: "tunnel", BackgroundTunnel: true}
+	require.Equal(t, ssh.BaseCMD()+" -f -N -T tunnel", h.CmdSSHConnect())
+}
+
+func TestAutoConnectHosts(t *testing.T) {
+	hosts := []Host{
+		{ID: 1, AutoConnect: true, BackgroundTunnel: true},
+		// Interactive shell is never started automatically
+		{ID: 2, AutoConnect: true},
+		{ID: 3, BackgroundTunnel: true},
+		{ID: 4, AutoConnect: true, BackgroundTunnel: true, Protocol: "kubectl"},
+		{ID: 5, AutoConnect: true, BackgroundTunnel: true, Address: "-L 8080:localhost:80 root@localhost"},
+		// Template replaces background flags
+		{ID: 6, AutoConnect: true, BackgroundTunnel: true, ConnectTemplate: "ssh {{.Address}}"},
+		// Hosts which must be confirmed or acknowledged before connecting
+		{ID: 7, AutoConnect: true, BackgroundTunnel: true, ConfirmByName: true},
+		{ID: 8, AutoConnect: true, BackgroundTunnel: true, Banner: "Production"},
+	}
+
+	autoConnectHosts := AutoConnectHosts(hosts)
+	require.Equal(t, []int{1, 5}, lo.Map(autoConnectHosts, func(h Host, _ int) int { return h.ID }))
+	require.Empty(t, AutoConnectHosts(nil))
+}
+
+func TestCmdSSHConnect_HostKeyAlias(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", RemotePort: "2222", HostKeyAlias: "db-primary"}
+	require.Equal(t, ssh.BaseCMD()+" -p 2222 -l root -o HostKeyAlias=db-primary localhost", h.CmdSSHConnect())
+
+	h.HostKeyAlias = ""
+	require.Equal(t, ssh.BaseCMD()+" -p 2222 -l root localhost", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_StrictHostKeyChecking(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", StrictHostKeyChecking: "no"}
+	require.Equal(t, ssh.BaseCMD()+" -l root -o StrictHostKeyChecking=no localhost", h.CmdSSHConnect())
+
+	// ssh default is used when the mode is not set
+	h.StrictHostKeyChecking = ""
+	require.Equal(t, ssh.BaseCMD()+" -l root localhost", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_ConnectTimeout(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", ConnectTimeout: "10"}
+	require.Equal(t, ssh.BaseCMD()+" -l root -o ConnectTimeout=10 localhost", h.CmdSSHConnect())
+
+	// System default is used when timeout is not set
+	h.ConnectTimeout = ""
+	require.Equal(t, ssh.BaseCMD()+" -l root localhost", h.CmdSSHConnect())
+}
+
+func TestCmdSSHConnect_KeepAlive(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", KeepAliveInterval: "30", KeepAliveCountMax: "3"}
+	expected := ssh.BaseCMD() + " -l root -o ServerAliveInterval=30 -o ServerAliveCountMax=3 localhost"
+	require.Equal(t, expected, h.CmdSSHConnect())
+
+	h.KeepAliveCountMax = ""
+	require.Equal(t, ssh.BaseCMD()+" -l root -o ServerAliveInterval=30 localhost", h.CmdSSHConnect())
+
+	h.KeepAliveInterval = ""
+	require.Equal(t, ssh.BaseCMD()+" -l root localhost", h.CmdSSHConnect())
+}
+
+func TestCmdSSHTailLog(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", RemotePort: "2222", LogPath: "/var/log/my app/it's.log"}
+	expected := ssh.BaseCMD() + ` -p 2222 -l root -t localhost "tail -f '/var/log/my app/it'\''s.log'"`
+	require.Equal(t, expected, h.CmdSSHTailLog())
+
+	// Remote command is passed to ssh as a single argument and the path is quoted for the remote shell
+	process := utils.BuildProcess(h.CmdSSHTailLog())
+	require.Equal(t, `tail -f '/var/log/my app/it'\''s.log'`, process.Args[len(process.Args)-1])
+
+	// Double quote in the path does not break the remote command argument
+	h.LogPath = `/var/log/my "app".log`
+	process = utils.BuildProcess(h.CmdSSHTailLog())
+	require.Equal(t, "localhost", process.Args[len(process.Args)-2])
+	require.Equal(t, `tail -f '/var/log/my "app".log'`, process.Args[len(process.Args)-1])
+
+	h.LogPath = " "
+	require.Equal(t, h.CmdSSHConnect(), h.CmdSSHTailLog())
+
+	// Remote command cannot be added to Teleport connect command
+	h = Host{Address: "node1", Protocol: "teleport", LogPath: "/var/log/syslog"}
+	require.Equal(t, "tsh ssh node1", h.CmdSSHTailLog())
+}
+
+func TestCmdSSHTailLog_Sudo(t *testing.T) {
+	h := Host{Address: "localhost", LoginName: "root", Sudo: true}
+
+	// Interactive login is not affected
+	require.Equal(t, ssh.BaseCMD()+" -l root localhost", h.CmdSSHConnect())
+	require.Equal(t, ssh.BaseCMD()+" -l root -F none localhost", h.CmdSSHFastConnect())
+	require.Equal(t, h.CmdSSHConnect(), h.CmdSSHTailLog())
+	require.NotContains(t, h.CmdSSHConnectWithForwardPreset("db"), "sudo")
+
+	// Remote command is wrapped
+	h.LogPath = "/var/log/auth.log"
+	require.Equal(t, ssh.BaseCMD()+` -l root -t localhost "sudo tail -f '/var/log/auth.log'"`, h.CmdSSHTailLog())
+
+	h.Sudo = false
+	require.Equal(t, ssh.BaseCMD()+` -l root -t localhost "tail -f '/var/log/auth.log'"`, h.CmdSSHTailLog())
+}
+
+func TestCmdSSHConnect_Teleport(t *testing.T) {
+	h := Host{
+		Address:          "node1",
+		LoginName:        "root",
+		RemotePort:       "2222",
+		IdentityFilePath: "/path/to/private/key",
+		PasswordCommand:  "pass show node1",
+		GatewayPorts:     "yes",
+		Protocol:         "teleport",
+		TeleportCluster:  "production",
+	}
+	// Keys, passwords and ssh options are managed by Teleport
+	require.Equal(t, "tsh ssh --cluster=production -p 2222 root@node1", h.CmdSSHConnect())
+	require.Equal(t, "tsh ssh --cluster=production -p 2222 root@node1", h.CmdSSHConnectWithForwardPreset("db"))
+
+	h = Host{Address: "node1", Protocol: " Teleport "}
+	require.True(t, h.IsTeleport())
+	require.Equal(t, "tsh ssh node1", h.CmdSSHConnect())
+
+	h.Protocol = "ssh"
+	require.False(t, h.IsTeleport())
+}
+
+func TestCmdSSHConnect_Kubectl(t *testing.T) {
+	h := Host{
+		Address:         "cluster.example.com",
+		LoginName:       "root",
+		PasswordCommand: "pass show cluster",
+		Protocol:        "kubectl",
+		KubeNamespace:   "production",
+		KubePod:         "web-0",
+		KubeContainer:   "nginx",
+	}
+	require.True(t, h.IsKubectl())
+	require.Equal(t, "kubectl exec -it -n production web-0 -c nginx -- /bin/sh", h.CmdSSHConnect())
+	require.Equal(t, "kubectl exec -it -n production web-0 -c nginx -- /bin/sh", h.CmdSSHFastConnect())
+	require.Equal(t, "kubectl exec -n production web-0 -c nginx -- uname -s", h.CmdSSHProbeRemoteOS())
+}
+
+func TestHasDestination_Kubectl(t *testing.T) {
+	// Address is not required for pods, but pod name is
+	require.True(t, (&Host{Protocol: "kubectl", KubePod: "web-0"}).HasDestination())
+	require.False(t, (&Host{Protocol: "kubectl", Address: "localhost", KubePod: " "}).HasDestination())
+}
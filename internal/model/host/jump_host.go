@@ -0,0 +1,91 @@
+package host
+
+import (
+	"net"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// JumpDestination - returns destination which other hosts use to jump through this host, ex: "root@bastion:2222".
+// Empty string means that the host can't be a jump host, because ssh doesn't connect to it directly, for instance
+// when the host is a Kubernetes pod, is connected through Teleport or uses a connect template.
+func (h *Host) JumpDestination() string {
+	if h.IsKubectl() || h.IsTeleport() || strings.TrimSpace(h.ConnectTemplate) != "" {
+		return ""
+	}
+
+	if h.SSHAlias != "" {
+		return h.SSHAlias
+	}
+
+	destination := strings.TrimSpace(h.Address)
+	if destination == "" || strings.Contains(destination, " ") {
+		return ""
+	}
+
+	if login := strings.TrimSpace(h.LoginName); login != "" && !strings.Contains(destination, "@") {
+		destination = login + "@" + destination
+	}
+
+	if port := strings.TrimSpace(h.RemotePort); port != "" {
+		user, hostname, found := strings.Cut(destination, "@")
+		if !found {
+			user, hostname = "", destination
+		}
+
+		destination = net.JoinHostPort(hostname, port)
+		if found {
+			destination = user + "@" + destination
+		}
+	}
+
+	return destination
+}
+
+// WithJumpHost - returns a copy of the host which connects through the jump host. The host is left as is when it
+// defines a jump host itself, because user chose it on purpose. Empty value leaves the host as is as well.
+func (h *Host) WithJumpHost(jumpHost string) Host {
+	withJumpHost := *h
+	if strings.TrimSpace(jumpHost) == "" || h.definesProxyJump() {
+		return withJumpHost
+	}
+
+	withJumpHost.ProxyJumpPool = []string{jumpHost}
+	return withJumpHost
+}
+
+// definesProxyJump - returns true if the host has a pool of jump hosts, ProxyJump or ProxyCommand option either in
+// extra options, in a custom connect string or in ssh config. Hosts which are not connected by ssh directly, for
+// instance Kubernetes pods, are treated the same way, because they can't be connected through a jump host.
+func (h *Host) definesProxyJump() bool {
+	if h.IsKubectl() || h.IsTeleport() || strings.TrimSpace(h.ConnectTemplate) != "" {
+		return true
+	}
+
+	if len(lo.Compact(lo.Map(h.ProxyJumpPool, func(jumpHost string, _ int) string {
+		return strings.TrimSpace(jumpHost)
+	}))) > 0 {
+		return true
+	}
+
+	isProxyOption := func(option string) bool {
+		option = strings.ToLower(option)
+		return strings.Contains(option, "proxyjump") || strings.Contains(option, "proxycommand")
+	}
+
+	if lo.SomeBy(h.ExtraOptions, isProxyOption) {
+		return true
+	}
+
+	if h.IsUserDefinedSSHCommand() && (isProxyOption(h.Address) || strings.Contains(h.Address, "-J")) {
+		return true
+	}
+
+	if h.SSHClientConfig == nil {
+		return false
+	}
+
+	proxyJump := strings.TrimSpace(h.SSHClientConfig.ProxyJump)
+	return proxyJump != "" && !strings.EqualFold(proxyJump, "none")
+}
@@ -0,0 +1,65 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafviktor/goto/internal/constant"
+	"github.com/grafviktor/goto/internal/model/ssh"
+)
+
+func TestJumpDestination(t *testing.T) {
+	tests := []struct {
+		name     string
+		host     Host
+		expected string
+	}{
+		{"Address only", Host{Address: "bastion"}, "bastion"},
+		{"Login and port", Host{Address: "bastion", LoginName: "root", RemotePort: "2222"}, "root@bastion:2222"},
+		{"IPv6 address", Host{Address: "::1", RemotePort: "2222"}, "[::1]:2222"},
+		{"Login in the address", Host{Address: "admin@bastion", LoginName: "root"}, "admin@bastion"},
+		{"SSH alias", Host{Address: "10.0.0.1", SSHAlias: "bastion"}, "bastion"},
+		{"Custom connect string", Host{Address: "-p 2222 bastion"}, ""},
+		{"Kubernetes pod", Host{Protocol: constant.ProtocolKubectl, KubePod: "web-0"}, ""},
+		{"Teleport", Host{Protocol: constant.ProtocolTeleport, Address: "bastion"}, ""},
+		{"Empty address", Host{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.host.JumpDestination())
+		})
+	}
+}
+
+func TestWithJumpHost(t *testing.T) {
+	h := Host{Address: "db", LoginName: "root"}
+	withJumpHost := h.WithJumpHost("admin@bastion")
+	require.Equal(t, "ssh -l root -J admin@bastion db", withJumpHost.CmdSSHConnect())
+	// Original host is not modified
+	require.Nil(t, h.ProxyJumpPool)
+	require.Equal(t, h, h.WithJumpHost(" "))
+
+	// Jump host which is defined by the host itself takes precedence
+	tests := []struct {
+		name string
+		host Host
+	}{
+		{"Pool of jump hosts", Host{Address: "db", ProxyJumpPool: []string{"bastion2"}}},
+		{"Extra option", Host{Address: "db", ExtraOptions: []string{"ProxyCommand=nc -X 5 %h %p"}}},
+		{"Custom connect string", Host{Address: "-J bastion2 db"}},
+		{"SSH config", Host{Address: "db", SSHClientConfig: &ssh.Config{ProxyJump: "bastion2"}}},
+		{"Kubernetes pod", Host{Protocol: constant.ProtocolKubectl, KubePod: "web-0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.host, tt.host.WithJumpHost("admin@bastion"))
+		})
+	}
+
+	// "none" in ssh config means that there is no jump host
+	h.SSHClientConfig = &ssh.Config{ProxyJump: "none"}
+	require.Equal(t, []string{"admin@bastion"}, h.WithJumpHost("admin@bastion").ProxyJumpPool)
+}
@@ -0,0 +1,52 @@
+package host
+
+import (
+	"sort"
+	"strings"
+)
+
+// Profile - is a named set of connection parameters which override the host attributes when user
+// connects using the profile. For instance, a host can be reachable through a different address
+// outside of work hours. Empty values are not overridden.
+type Profile struct {
+	Address          string `yaml:"address,omitempty"`
+	RemotePort       string `yaml:"network_port,omitempty"`
+	LoginName        string `yaml:"username,omitempty"`
+	IdentityFilePath string `yaml:"identity_file_path,omitempty"`
+	GatewayPorts     string `yaml:"gateway_ports,omitempty"`
+}
+
+// ProfileNames - returns sorted names of the connection profiles.
+func (h *Host) ProfileNames() []string {
+	names := make([]string, 0, len(h.Profiles))
+	for name := range h.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// WithProfile - returns a copy of the host where attributes are overridden by the profile.
+// Unknown profile name is ignored and an unchanged copy is returned.
+func (h *Host) WithProfile(profileName string) Host {
+	profiled := *h
+	profile, ok := h.Profiles[profileName]
+	if !ok {
+		return profiled
+	}
+
+	override := func(field *string, value string) {
+		if strings.TrimSpace(value) != "" {
+			*field = value
+		}
+	}
+
+	override(&profiled.Address, profile.Address)
+	override(&profiled.RemotePort, profile.RemotePort)
+	override(&profiled.LoginName, profile.LoginName)
+	override(&profiled.IdentityFilePath, profile.IdentityFilePath)
+	override(&profiled.GatewayPorts, profile.GatewayPorts)
+
+	return profiled
+}
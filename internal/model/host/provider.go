@@ -0,0 +1,27 @@
+package host
+
+import "strings"
+
+// Cloud providers which hosts can be tagged with, see Host.Provider.
+const (
+	ProviderAWS   = "aws"
+	ProviderGCP   = "gcp"
+	ProviderAzure = "azure"
+)
+
+var providerIcons = map[string]string{
+	ProviderAWS:   "🟧",
+	ProviderGCP:   "🟦",
+	ProviderAzure: "🔷",
+}
+
+// NormalizeProvider - returns provider name in lowercase without surrounding spaces, so "AWS" and "aws"
+// are the same provider. Unknown providers are returned as is, they're still used for grouping.
+func NormalizeProvider(provider string) string {
+	return strings.ToLower(strings.TrimSpace(provider))
+}
+
+// ProviderIcon - returns an icon of the cloud provider, or an empty string if the provider is unknown.
+func ProviderIcon(provider string) string {
+	return providerIcons[NormalizeProvider(provider)]
+}
@@ -0,0 +1,22 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderIcon(t *testing.T) {
+	require.Equal(t, "🟧", ProviderIcon(ProviderAWS))
+	require.Equal(t, "🟦", ProviderIcon(ProviderGCP))
+	require.Equal(t, "🔷", ProviderIcon(ProviderAzure))
+	require.Equal(t, "🟧", ProviderIcon(" AWS "))
+	require.Empty(t, ProviderIcon("hetzner"))
+	require.Empty(t, ProviderIcon(""))
+}
+
+func TestNormalizeProvider(t *testing.T) {
+	require.Equal(t, "azure", NormalizeProvider(" Azure"))
+	require.Equal(t, "hetzner", NormalizeProvider("Hetzner"))
+	require.Empty(t, NormalizeProvider("  "))
+}
@@ -0,0 +1,71 @@
+package host
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// ProxyJumpRandom - a random jump host of the pool is used for every connection. It's the default strategy.
+	ProxyJumpRandom = "random"
+	// ProxyJumpRoundRobin - jump hosts of the pool are used one after another.
+	ProxyJumpRoundRobin = "round-robin"
+)
+
+// proxyJumpSelector is shared by all hosts, so hosts which use the same pool rotate over it together.
+var proxyJumpSelector = NewProxyJumpSelector(rand.NewSource(time.Now().UnixNano()))
+
+// ProxyJumpSelector - picks a jump host from a pool according to a selection strategy.
+type ProxyJumpSelector struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+	// next contains index of the next jump host for round-robin strategy, the key is the pool itself.
+	next map[string]int
+}
+
+// NewProxyJumpSelector - creates a selector, source is used by random strategy.
+func NewProxyJumpSelector(source rand.Source) *ProxyJumpSelector {
+	return &ProxyJumpSelector{
+		rnd:  rand.New(source), //nolint:gosec // jump host is not a secret
+		next: map[string]int{},
+	}
+}
+
+// Select - returns a jump host from the pool, empty entries are skipped. Returns empty string if the pool is empty.
+// Unknown strategy is treated as ProxyJumpRandom. Round-robin strategy moves on to the next jump host.
+func (s *ProxyJumpSelector) Select(strategy string, pool []string) string {
+	return s.pick(strategy, pool, true)
+}
+
+// Peek - returns a jump host from the pool the same way as Select, but round-robin strategy stays on the same
+// jump host. It's used when a command is built, but the connection is not launched, for instance when it's copied.
+func (s *ProxyJumpSelector) Peek(strategy string, pool []string) string {
+	return s.pick(strategy, pool, false)
+}
+
+func (s *ProxyJumpSelector) pick(strategy string, pool []string, advance bool) string {
+	pool = lo.Compact(lo.Map(pool, func(jumpHost string, _ int) string { return strings.TrimSpace(jumpHost) }))
+	if len(pool) <= 1 {
+		jumpHost, _ := lo.First(pool)
+		return jumpHost
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.TrimSpace(strings.ToLower(strategy)) == ProxyJumpRoundRobin {
+		key := strings.Join(pool, ",")
+		index := s.next[key] % len(pool)
+		if advance {
+			s.next[key] = index + 1
+		}
+
+		return pool[index]
+	}
+
+	return pool[s.rnd.Intn(len(pool))]
+}
@@ -0,0 +1,85 @@
+package host
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafviktor/goto/internal/model/ssh"
+)
+
+func TestProxyJumpSelector_RoundRobin(t *testing.T) {
+	selector := NewProxyJumpSelector(rand.NewSource(1))
+	pool := []string{"bastion1", " ", "bastion2", "bastion3"}
+
+	selected := []string{}
+	for i := 0; i < 5; i++ {
+		selected = append(selected, selector.Select("round-robin", pool))
+	}
+	require.Equal(t, []string{"bastion1", "bastion2", "bastion3", "bastion1", "bastion2"}, selected)
+
+	// Other pools are rotated independently
+	require.Equal(t, "bastion4", selector.Select(" Round-Robin ", []string{"bastion4", "bastion5"}))
+	require.Equal(t, "bastion3", selector.Select(ProxyJumpRoundRobin, pool))
+
+	// Peek doesn't move on to the next jump host
+	require.Equal(t, "bastion1", selector.Peek(ProxyJumpRoundRobin, pool))
+	require.Equal(t, "bastion1", selector.Peek(ProxyJumpRoundRobin, pool))
+	require.Equal(t, "bastion1", selector.Select(ProxyJumpRoundRobin, pool))
+}
+
+func TestProxyJumpSelector_Random(t *testing.T) {
+	pool := []string{"bastion1", "bastion2", "bastion3"}
+	// Selection is repeatable when the source is seeded with the same value
+	expected := rand.New(rand.NewSource(42))
+	selector := NewProxyJumpSelector(rand.NewSource(42))
+
+	for i := 0; i < 10; i++ {
+		// Unknown strategy is treated as random
+		strategy := []string{"", ProxyJumpRandom, "unknown"}[i%3]
+		require.Equal(t, pool[expected.Intn(len(pool))], selector.Select(strategy, pool))
+	}
+}
+
+func TestProxyJumpSelector_SmallPool(t *testing.T) {
+	selector := NewProxyJumpSelector(rand.NewSource(1))
+	require.Equal(t, "", selector.Select(ProxyJumpRandom, nil))
+	require.Equal(t, "", selector.Select(ProxyJumpRoundRobin, []string{" "}))
+	require.Equal(t, "bastion1", selector.Select(ProxyJumpRoundRobin, []string{" bastion1 "}))
+}
+
+func TestCmdSSHConnect_ProxyJumpPool(t *testing.T) {
+	original := proxyJumpSelector
+	defer func() { proxyJumpSelector = original }()
+	proxyJumpSelector = NewProxyJumpSelector(rand.NewSource(1))
+
+	h := Host{
+		Address:           "db.internal",
+		LoginName:         "root",
+		ProxyJumpPool:     []string{"bastion1", "bastion2"},
+		ProxyJumpStrategy: ProxyJumpRoundRobin,
+		ForwardPresets:    map[string][]string{"db": {"-L 5432:localhost:5432"}},
+	}
+	// Building a command, for instance to copy it, doesn't move on to the next jump host
+	require.Equal(t, ssh.BaseCMD()+" -l root -J bastion1 db.internal", h.CmdSSHConnect())
+	require.Equal(t, ssh.BaseCMD()+" -l root -J bastion1 -L 5432:localhost:5432 db.internal", h.CmdSSHConnectWithForwardPreset("db"))
+	require.Equal(t, ssh.BaseCMD()+" -l root -J bastion1 -F none db.internal", h.CmdSSHFastConnect())
+
+	// Jump host is chosen when connection is launched, all commands of the connection use it
+	connectHost := h.WithProxyJumpSelected()
+	require.Equal(t, []string{"bastion1"}, connectHost.ProxyJumpPool)
+	require.Equal(t, ssh.BaseCMD()+" -l root -J bastion1 db.internal", connectHost.CmdSSHConnect())
+	require.Equal(t, ssh.BaseCMD()+" -l root -J bastion2 db.internal", h.CmdSSHConnect())
+	require.Equal(t, []string{"bastion2"}, h.WithProxyJumpSelected().ProxyJumpPool)
+	require.Equal(t, []string{"bastion1"}, h.WithProxyJumpSelected().ProxyJumpPool)
+
+	// Preview displays the whole pool
+	require.Equal(t, ssh.BaseCMD()+" -l root -J {bastion1|bastion2} db.internal", h.CmdSSHConnectPreview())
+
+	// Host without a pool
+	h.ProxyJumpPool = nil
+	require.Equal(t, ssh.BaseCMD()+" -l root db.internal", h.CmdSSHConnect())
+	require.Equal(t, ssh.BaseCMD()+" -l root db.internal", h.CmdSSHConnectPreview())
+	require.Empty(t, h.WithProxyJumpSelected().ProxyJumpPool)
+}
@@ -0,0 +1,28 @@
+package host
+
+import (
+	"strings"
+)
+
+// WithResolvedAddress - returns a copy of the host, which address is replaced with the one from the resolver map.
+// It's used in networks where short names, for instance "db1", are not resolved by DNS. Names are case-insensitive,
+// the address is left as is when the name is not in the map.
+func (h *Host) WithResolvedAddress(resolver map[string]string) Host {
+	resolved := *h
+	name := strings.TrimSpace(h.Address)
+	address, ok := resolver[name]
+	if !ok {
+		for key, value := range resolver {
+			if strings.EqualFold(key, name) {
+				address, ok = value, true
+				break
+			}
+		}
+	}
+
+	if ok && strings.TrimSpace(address) != "" {
+		resolved.Address = strings.TrimSpace(address)
+	}
+
+	return resolved
+}
@@ -0,0 +1,43 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithResolvedAddress(t *testing.T) {
+	resolver := map[string]string{
+		"db1":   "10.0.0.5",
+		"Web":   " 10.0.0.6 ",
+		"blank": "",
+	}
+
+	tests := []struct {
+		name     string
+		address  string
+		expected string
+	}{
+		{"name is resolved", "db1", "10.0.0.5"},
+		{"name is case-insensitive and trimmed", " WEB ", "10.0.0.6"},
+		{"unknown name is not resolved", "db2", "db2"},
+		{"partial name is not resolved", "db1.example.com", "db1.example.com"},
+		{"empty address in the map is ignored", "blank", "blank"},
+		{"empty host address", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Host{Title: "db", Address: tt.address}
+			resolved := h.WithResolvedAddress(resolver)
+			require.Equal(t, tt.expected, resolved.Address)
+			// Original host keeps the short name
+			require.Equal(t, tt.address, h.Address)
+			require.Equal(t, h.Title, resolved.Title)
+		})
+	}
+
+	// Nil resolver leaves the address as is
+	h := Host{Address: "db1"}
+	require.Equal(t, "db1", h.WithResolvedAddress(nil).Address)
+}
@@ -0,0 +1,48 @@
+package host
+
+import (
+	"errors"
+	"strings"
+	"text/template"
+)
+
+// connectTemplateSample is used to check that a connect template can be rendered before it's saved.
+var connectTemplateSample = Host{
+	Title:            "sample",
+	Address:          "localhost",
+	RemotePort:       "22",
+	LoginName:        "root",
+	IdentityFilePath: "~/.ssh/id_rsa",
+}
+
+// RenderConnectTemplate - renders connect command template over the host attributes,
+// for instance "ssh -p {{.RemotePort}} {{.LoginName}}@{{.Address}}".
+func RenderConnectTemplate(text string, h Host) (string, error) {
+	tmpl, err := template.New("connect").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	sb := strings.Builder{}
+	if err = tmpl.Execute(&sb, h); err != nil {
+		return "", err
+	}
+
+	command := strings.TrimSpace(sb.String())
+	if command == "" {
+		return "", errors.New("connect template renders an empty command")
+	}
+
+	return command, nil
+}
+
+// ValidateConnectTemplate - checks that the template can be parsed and rendered using a sample host.
+// Empty template is valid, it means that the connect command is assembled from the host attributes.
+func ValidateConnectTemplate(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	_, err := RenderConnectTemplate(text, connectTemplateSample)
+	return err
+}
@@ -0,0 +1,63 @@
+package ssh
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// AgentKey - is a key which is loaded into ssh-agent. See 'ssh-add -l'.
+type AgentKey struct {
+	Bits        int
+	Fingerprint string
+	// Comment is usually a path to the private key file which was added to the agent.
+	Comment string
+	Type    string
+}
+
+// String - returns key description which is displayed to the user. Ex: "~/.ssh/id_ed25519 (ED25519)".
+func (k AgentKey) String() string {
+	return fmt.Sprintf("%s (%s)", k.Comment, k.Type)
+}
+
+// IsFile - returns true if the key comment is a path to the private key file. Only such keys can be
+// pinned to a host, because ssh selects agent key by its public key which is read from '<path>.pub' file.
+func (k AgentKey) IsFile() bool {
+	return strings.HasPrefix(k.Comment, "~") || filepath.IsAbs(k.Comment) || strings.HasPrefix(k.Comment, "/")
+}
+
+// ListAgentKeysCommand - builds command which lists fingerprints of all keys loaded into ssh-agent.
+func ListAgentKeysCommand() string {
+	return "ssh-add -l"
+}
+
+// ParseAgentKeys - parses 'ssh-add -l' output. Each line has the following format:
+// "<bits> <fingerprint> <comment> (<type>)", comment may contain spaces. Lines which do not
+// match the format, for instance "The agent has no identities.", are ignored.
+func ParseAgentKeys(output string) []AgentKey {
+	var keys []AgentKey
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+
+		bits, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+
+		key := AgentKey{Bits: bits, Fingerprint: fields[1]}
+		rest := fields[2:]
+		if last := rest[len(rest)-1]; strings.HasPrefix(last, "(") && strings.HasSuffix(last, ")") {
+			key.Type = strings.Trim(last, "()")
+			rest = rest[:len(rest)-1]
+		}
+		key.Comment = strings.Join(rest, " ")
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
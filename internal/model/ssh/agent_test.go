@@ -0,0 +1,38 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAgentKeys(t *testing.T) {
+	output := `256 SHA256:vJ3kQpB8m0Xh9z6 /home/user/.ssh/id_ed25519 (ED25519)
+3072 SHA256:Tq2nR5fK0pLw8cY user@work laptop (RSA)
+256 SHA256:a1B2c3D4e5F6 (ECDSA-SK)
+not a key line
+`
+	require.Equal(t, []AgentKey{
+		{Bits: 256, Fingerprint: "SHA256:vJ3kQpB8m0Xh9z6", Comment: "/home/user/.ssh/id_ed25519", Type: "ED25519"},
+		{Bits: 3072, Fingerprint: "SHA256:Tq2nR5fK0pLw8cY", Comment: "user@work laptop", Type: "RSA"},
+		// Key without a comment
+		{Bits: 256, Fingerprint: "SHA256:a1B2c3D4e5F6", Comment: "", Type: "ECDSA-SK"},
+	}, ParseAgentKeys(output))
+}
+
+func TestParseAgentKeys_NoKeys(t *testing.T) {
+	require.Empty(t, ParseAgentKeys("The agent has no identities."))
+	require.Empty(t, ParseAgentKeys("Could not open a connection to your authentication agent."))
+	require.Empty(t, ParseAgentKeys(""))
+}
+
+func TestAgentKey_IsFile(t *testing.T) {
+	require.True(t, AgentKey{Comment: "/home/user/.ssh/id_ed25519"}.IsFile())
+	require.True(t, AgentKey{Comment: "~/.ssh/id_rsa"}.IsFile())
+	require.False(t, AgentKey{Comment: "user@laptop"}.IsFile())
+	require.False(t, AgentKey{}.IsFile())
+}
+
+func TestAgentKey_String(t *testing.T) {
+	require.Equal(t, "~/.ssh/id_rsa (RSA)", AgentKey{Comment: "~/.ssh/id_rsa", Type: "RSA"}.String())
+}
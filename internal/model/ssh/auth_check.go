@@ -0,0 +1,40 @@
+package ssh
+
+import "strings"
+
+// AuthCheckCommand - is executed on a remote host to check that user can log in, it does nothing.
+const AuthCheckCommand = "true"
+
+// AuthCheckResult - is an outcome of the authentication check, see ParseAuthCheck.
+type AuthCheckResult int
+
+const (
+	// AuthCheckOK - user logged in to the remote host.
+	AuthCheckOK AuthCheckResult = iota
+	// AuthCheckFailed - remote host rejected all authentication methods. BatchMode disables password prompts,
+	// that's why hosts which accept password only fail the check as well.
+	AuthCheckFailed
+	// AuthCheckError - ssh could not reach the remote host, authentication was not attempted.
+	AuthCheckError
+)
+
+// connectionErrorExitCode - ssh exits with this code when an error occurs, otherwise it returns exit code of
+// the remote command.
+const connectionErrorExitCode = 255
+
+// ParseAuthCheck - interprets exit code and error output of 'ssh -o BatchMode=yes <host> true'. Any exit code
+// other than 255 comes from the remote command, so user was authenticated, even if the command failed, for
+// instance because of a restricted shell. Exit code 255 is an authentication failure only if ssh says so.
+func ParseAuthCheck(exitCode int, stdErr string) AuthCheckResult {
+	switch {
+	case exitCode == 0:
+		return AuthCheckOK
+	case exitCode == connectionErrorExitCode && strings.Contains(stdErr, "Permission denied"):
+		return AuthCheckFailed
+	case exitCode == connectionErrorExitCode || exitCode < 0:
+		// Negative exit code means that ssh was not started at all.
+		return AuthCheckError
+	default:
+		return AuthCheckOK
+	}
+}
@@ -0,0 +1,31 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseAuthCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		exitCode int
+		stdErr   string
+		expected AuthCheckResult
+	}{
+		{"Success", 0, "", AuthCheckOK},
+		{"Remote command failed", 1, "", AuthCheckOK},
+		{"Restricted shell", 127, "sh: true: not found", AuthCheckOK},
+		{"Public key rejected", 255, "root@localhost: Permission denied (publickey).", AuthCheckFailed},
+		{"Password required", 255, "root@localhost: Permission denied (password).", AuthCheckFailed},
+		{"Connection refused", 255, "ssh: connect to host localhost port 22: Connection refused", AuthCheckError},
+		{"Host key changed", 255, "Host key verification failed.", AuthCheckError},
+		{"ssh is not installed", -1, "exec: \"ssh\": executable file not found in $PATH", AuthCheckError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, ParseAuthCheck(tt.exitCode, tt.stdErr))
+		})
+	}
+}
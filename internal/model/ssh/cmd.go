@@ -1,7 +1,10 @@
 package ssh
 
 import (
+	"fmt"
 	"strings"
+
+	"github.com/grafviktor/goto/internal/utils"
 )
 
 var baseCmd = BaseCMD()
@@ -29,3 +32,100 @@ func LoadConfigCommand(options ...Option) string {
 
 	return sb.String()
 }
+
+// SSHFSCommand - builds sshfs command to mount root folder of a remote host into mountPoint.
+func SSHFSCommand(mountPoint string, options ...Option) string {
+	hostname, remotePort, privateKey := fileTransferOptions(options)
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("sshfs %s:/ %s", hostname, quoteIfContainsSpace(utils.ExpandTilde(mountPoint))))
+	sb.WriteString(constructKeyValueOption("-p", remotePort))
+	if privateKey != "" {
+		sb.WriteString(fmt.Sprintf(" -o IdentityFile=%s", quoteIfContainsSpace(utils.ExpandTilde(privateKey))))
+	}
+
+	return sb.String()
+}
+
+// SFTPCommand - builds sftp command to open an interactive file transfer session with a remote host.
+// Ex: "sftp -P 2222 -i /tmp/id_rsa root@localhost".
+func SFTPCommand(options ...Option) string {
+	hostname, remotePort, privateKey := fileTransferOptions(options)
+
+	sb := strings.Builder{}
+	sb.WriteString("sftp")
+	// Unlike ssh, sftp uses capital "-P" for the port.
+	sb.WriteString(constructKeyValueOption("-P", remotePort))
+	if privateKey != "" {
+		sb.WriteString(fmt.Sprintf(" -i %s", quoteIfContainsSpace(utils.ExpandTilde(privateKey))))
+	}
+	if hostname != "" {
+		sb.WriteString(fmt.Sprintf(" %s", hostname))
+	}
+
+	return sb.String()
+}
+
+// fileTransferOptions - extracts connection parameters of sshfs and sftp commands from the options, login name
+// is prepended to the hostname. Ex: "root@localhost".
+func fileTransferOptions(options []Option) (hostname, remotePort, privateKey string) {
+	var username string
+	for _, option := range options {
+		switch opt := option.(type) {
+		case OptionAddress:
+			hostname = strings.TrimSpace(opt.Value)
+		case OptionLoginName:
+			username = strings.TrimSpace(opt.Value)
+		case OptionRemotePort:
+			remotePort = strings.TrimSpace(opt.Value)
+		case OptionPrivateKey:
+			privateKey = strings.TrimSpace(opt.Value)
+		}
+	}
+
+	if username != "" {
+		hostname = fmt.Sprintf("%s@%s", username, hostname)
+	}
+
+	return hostname, remotePort, privateKey
+}
+
+// RemoteCommand - returns an argument which is appended to ssh command after the address, so ssh runs the command
+// instead of a login shell. The command is wrapped into double quotes to be passed to ssh as a single argument.
+// Double quotes inside the command cannot be escaped with a backslash, that's why they are closed, the quote
+// character is put into single quotes and then reopened:
+//
+//	tail -f '/var/log/a"b.log'
+//	// becomes:
+//	 "tail -f '/var/log/a"'"'"b.log'"
+func RemoteCommand(command string) string {
+	return fmt.Sprintf(` "%s"`, strings.ReplaceAll(command, `"`, `"'"'"`))
+}
+
+// TailLogCommand - builds remote command which follows the log file. The path is wrapped into single quotes, so
+// it's taken literally by the remote shell, leading "~/" is kept outside of quotes to be expanded by the remote shell.
+// Ex: "tail -f '/var/log/syslog'".
+func TailLogCommand(logPath string) string {
+	logPath = strings.TrimSpace(logPath)
+	home := ""
+	if strings.HasPrefix(logPath, "~/") {
+		home, logPath = "~/", strings.TrimPrefix(logPath, "~/")
+	}
+
+	return fmt.Sprintf("tail -f %s'%s'", home, strings.ReplaceAll(logPath, "'", `'\''`))
+}
+
+// SudoCommand - wraps remote command, so it runs using sudo. Pseudo-terminal is required to enter the password.
+// Ex: "sudo tail -f '/var/log/syslog'".
+func SudoCommand(command string) string {
+	return "sudo " + command
+}
+
+// quoteIfContainsSpace - wraps value into double quotes, so it's not split into several arguments.
+func quoteIfContainsSpace(value string) string {
+	if strings.Contains(value, " ") {
+		return fmt.Sprintf(`"%s"`, value)
+	}
+
+	return value
+}
@@ -0,0 +1,73 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailLogCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		logPath  string
+		expected string
+	}{
+		{"absolute path", "/var/log/syslog", "tail -f '/var/log/syslog'"},
+		{"path with spaces", " /var/log/my app/app.log ", "tail -f '/var/log/my app/app.log'"},
+		{"path with single quote", "/var/log/it's.log", `tail -f '/var/log/it'\''s.log'`},
+		{"path with shell characters", "/var/log/$HOME;rm -rf *.log", "tail -f '/var/log/$HOME;rm -rf *.log'"},
+		{"path in home folder", "~/logs/app.log", "tail -f ~/'logs/app.log'"},
+		{"path with double quote", `/var/log/a"b.log`, `tail -f '/var/log/a"b.log'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, TailLogCommand(tt.logPath))
+		})
+	}
+}
+
+func TestSudoCommand(t *testing.T) {
+	require.Equal(t, "sudo tail -f '/var/log/syslog'", SudoCommand(TailLogCommand("/var/log/syslog")))
+}
+
+func TestRemoteCommand(t *testing.T) {
+	require.Equal(t, ` "tail -f '/var/log/syslog'"`, RemoteCommand("tail -f '/var/log/syslog'"))
+	require.Equal(t, ` "tail -f '/var/log/a"'"'"b.log'"`, RemoteCommand(`tail -f '/var/log/a"b.log'`))
+}
+
+func TestSFTPCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Option
+		expected string
+	}{
+		{"address only", []Option{OptionAddress{Value: "localhost"}}, "sftp localhost"},
+		{
+			name: "all options",
+			options: []Option{
+				OptionAddress{Value: " localhost "},
+				OptionLoginName{Value: "root"},
+				OptionRemotePort{Value: "2222"},
+				OptionPrivateKey{Value: "/tmp/id_rsa"},
+			},
+			expected: "sftp -P 2222 -i /tmp/id_rsa root@localhost",
+		},
+		{
+			name:     "identity file with spaces",
+			options:  []Option{OptionAddress{Value: "localhost"}, OptionPrivateKey{Value: "/tmp/my keys/id_rsa"}},
+			expected: `sftp -i "/tmp/my keys/id_rsa" localhost`,
+		},
+		{
+			name:     "empty options are skipped",
+			options:  []Option{OptionAddress{Value: "localhost"}, OptionLoginName{}, OptionRemotePort{}, OptionPrivateKey{}},
+			expected: "sftp localhost",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, SFTPCommand(tt.options...))
+		})
+	}
+}
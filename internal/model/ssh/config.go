@@ -13,10 +13,15 @@ type Config struct {
 	// 2. 'identityfile'
 	// 3. 'port'
 	// 4. 'user'
+	// 5. 'proxyjump'
 	Hostname     string
 	IdentityFile string
 	Port         string
 	User         string
+	ProxyJump    string
+	// Sources contain places in ssh_config files where the values are defined, keys are lowercase names
+	// of the directives. Ex: "port". Nil means that sources are unknown. See AttachSources.
+	Sources map[string]ConfigSource
 }
 
 // Parse - parses 'ssh -G <hostname> command' output and returns Config struct.
@@ -26,9 +31,23 @@ func Parse(config string) *Config {
 		IdentityFile: getRegexFirstMatchingGroup(sshConfigIdentityFileRe.FindStringSubmatch(config)),
 		Port:         getRegexFirstMatchingGroup(sshConfigPortRe.FindStringSubmatch(config)),
 		User:         getRegexFirstMatchingGroup(sshConfigUserRe.FindStringSubmatch(config)),
+		ProxyJump:    getRegexFirstMatchingGroup(sshConfigProxyJumpRe.FindStringSubmatch(config)),
 	}
 }
 
+// ParseWithSources - parses 'ssh -G <hostname>' output and attaches sources of the values, which are looked up
+// in ssh_config files. Sources are not attached when the output doesn't contain the host which the config was
+// requested for, older ssh versions don't print it, or when the files cannot be read.
+func ParseWithSources(config string, files ...string) *Config {
+	parsed := Parse(config)
+	if hostname := getRegexFirstMatchingGroup(sshConfigHostRe.FindStringSubmatch(config)); hostname != "" {
+		// Sources are optional, they're only displayed to the user.
+		_ = parsed.AttachSources(hostname, files...)
+	}
+
+	return parsed
+}
+
 // StubConfig - returns a stub SSH config. It is used on application startup when build application state and
 // no hosts yet available. Consider to run real ssh process to request a config. See 'message.RunProcessLoadSSHConfig'.
 func StubConfig() *Config {
@@ -45,6 +64,9 @@ var (
 	sshConfigIdentityFileRe = regexp.MustCompile(`(?i)identityfile\s+(.*[^\r\n])`)
 	sshConfigPortRe         = regexp.MustCompile(`(?i)port\s+(.*[^\r\n])`)
 	sshConfigUserRe         = regexp.MustCompile(`(?i)user\s+(.*[^\r\n])`)
+	sshConfigProxyJumpRe    = regexp.MustCompile(`(?i)proxyjump\s+(.*[^\r\n])`)
+	// sshConfigHostRe matches 'host' line only, not 'hostname' or 'hostkeyalgorithms'.
+	sshConfigHostRe = regexp.MustCompile(`(?im)^host\s+(.*[^\r\n])`)
 )
 
 func getRegexFirstMatchingGroup(groups []string) string {
@@ -0,0 +1,221 @@
+package ssh
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/grafviktor/goto/internal/utils"
+)
+
+const (
+	// SystemConfigFile - is a system-wide ssh client configuration file.
+	SystemConfigFile = "/etc/ssh/ssh_config"
+	// UserConfigFile - is a user's ssh client configuration file.
+	UserConfigFile = "~/.ssh/config"
+)
+
+// DefaultConfigFiles - returns ssh_config files in order of precedence: user config overrides system config.
+func DefaultConfigFiles() []string {
+	return []string{UserConfigFile, SystemConfigFile}
+}
+
+// ReadConfig - reads ssh_config files and returns parameters which apply to the hostname. Files should be
+// sorted in order of precedence, same as ssh does it, the first obtained value of a directive is used.
+// Missing files are ignored. Note that 'Match' blocks and 'Include' directives are not supported, consider
+// to use 'ssh -G <hostname>' to get the exact configuration. See LoadConfigCommand.
+func ReadConfig(hostname string, files ...string) (*Config, error) {
+	directives, err := readDirectives(hostname, files...)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{
+		Hostname:     directives["hostname"].Value,
+		IdentityFile: directives["identityfile"].Value,
+		Port:         directives["port"].Value,
+		User:         directives["user"].Value,
+		ProxyJump:    directives["proxyjump"].Value,
+	}
+
+	if config.Hostname == "" {
+		config.Hostname = hostname
+	}
+
+	if config.Port == "" {
+		config.Port = "22"
+	}
+
+	if config.User == "" {
+		config.User = currentUsername()
+	}
+
+	return config, nil
+}
+
+// ConfigSource - is a place in ssh_config file where a value is defined. Host contains patterns of the 'Host'
+// block, it's empty when the value is defined before the first block and applies to all hosts.
+type ConfigSource struct {
+	File string
+	Line int
+	Host string
+}
+
+// String - returns the source in "file:line" format followed by the host block. Ex: "~/.ssh/config:12, Host web-*".
+func (s ConfigSource) String() string {
+	if s.Host == "" {
+		return fmt.Sprintf("%s:%d", s.File, s.Line)
+	}
+
+	return fmt.Sprintf("%s:%d, Host %s", s.File, s.Line, s.Host)
+}
+
+// configDirective - is a value of ssh_config directive and the place where it's defined.
+type configDirective struct {
+	Value  string
+	Source ConfigSource
+}
+
+// AttachSources - finds out where the values of the config are defined, hostname is the host which the config
+// was requested for. Files should be sorted in order of precedence, see ReadConfig. Values which are not found
+// in the files, for instance ssh defaults or values from 'Match' blocks, are left without a source.
+func (c *Config) AttachSources(hostname string, files ...string) error {
+	directives, err := readDirectives(hostname, files...)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{
+		"hostname":     c.Hostname,
+		"identityfile": c.IdentityFile,
+		"port":         c.Port,
+		"user":         c.User,
+		"proxyjump":    c.ProxyJump,
+	}
+
+	c.Sources = map[string]ConfigSource{}
+	for key, value := range values {
+		directive, found := directives[key]
+		// ssh expands "~" in some values, hostnames are case-insensitive.
+		if found && strings.EqualFold(utils.ExpandTilde(directive.Value), utils.ExpandTilde(value)) {
+			c.Sources[key] = directive.Source
+		}
+	}
+
+	return nil
+}
+
+// readDirectives - reads ssh_config files and returns directives which apply to the hostname, the first obtained
+// value of a directive is used. Missing files are ignored.
+func readDirectives(hostname string, files ...string) (map[string]configDirective, error) {
+	directives := map[string]configDirective{}
+	for _, file := range files {
+		fileDirectives, err := readConfigFile(file, hostname)
+		if err != nil {
+			return nil, err
+		}
+
+		mergeDirectives(directives, fileDirectives)
+	}
+
+	return directives, nil
+}
+
+// mergeDirectives - copies directives from src to dst, values which already exist in dst are not overridden.
+func mergeDirectives(dst, src map[string]configDirective) {
+	for key, directive := range src {
+		if _, found := dst[key]; !found {
+			dst[key] = directive
+		}
+	}
+}
+
+// readConfigFile - reads directives which apply to the hostname, file is used as is in the sources of
+// the directives, so "~" is displayed instead of the home folder.
+func readConfigFile(file, hostname string) (map[string]configDirective, error) {
+	f, err := os.Open(utils.ExpandTilde(file))
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]configDirective{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseConfigFile(f, file, hostname)
+}
+
+// parseConfigFile - returns directives from ssh_config file which apply to the hostname. Keys are lowercase,
+// when a directive is defined several times, the first value is used.
+func parseConfigFile(r io.Reader, file, hostname string) (map[string]configDirective, error) {
+	directives := map[string]configDirective{}
+	// Directives which are defined before the first 'Host' block apply to all hosts.
+	applicable := true
+	hostPatterns := ""
+	line := 0
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line++
+		key, value := splitDirective(scanner.Text())
+		switch key {
+		case "":
+			continue
+		case "host":
+			applicable = hostMatches(hostname, strings.Fields(value))
+			hostPatterns = value
+		case "match":
+			applicable = false
+		default:
+			if _, found := directives[key]; applicable && !found {
+				directives[key] = configDirective{
+					Value:  value,
+					Source: ConfigSource{File: file, Line: line, Host: hostPatterns},
+				}
+			}
+		}
+	}
+
+	return directives, scanner.Err()
+}
+
+// splitDirective - splits ssh_config line into lowercase keyword and its value. Keyword and value can be
+// separated with whitespaces or '=' sign. Returns empty keyword for empty lines and comments.
+func splitDirective(line string) (key, value string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", ""
+	}
+
+	separator := strings.IndexAny(line, " \t=")
+	if separator < 0 {
+		return strings.ToLower(line), ""
+	}
+
+	key = line[:separator]
+	value = strings.TrimSpace(line[separator:])
+	value = strings.TrimSpace(strings.TrimPrefix(value, "="))
+	value = strings.Trim(value, "\"")
+
+	return strings.ToLower(key), value
+}
+
+// hostMatches - checks whether the hostname matches 'Host' patterns. A negated pattern (prefixed with '!')
+// excludes the hostname even if it's matched by other patterns.
+func hostMatches(hostname string, patterns []string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		ok, _ := path.Match(strings.TrimPrefix(pattern, "!"), hostname)
+		if ok && negated {
+			return false
+		}
+
+		matched = matched || ok
+	}
+
+	return matched
+}
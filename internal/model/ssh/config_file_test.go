@@ -0,0 +1,183 @@
+package ssh
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mockSystemConfig = `# System-wide defaults
+Host *.example.com
+    Port 22
+    User system_user
+    IdentityFile /etc/ssh/system_key
+
+Host *
+    ServerAliveInterval 60
+    User fallback_user
+`
+	mockUserConfig = `Host db !web.example.com
+    HostName db.example.com
+
+Host *.example.com
+    Port=2222
+    IdentityFile "~/.ssh/user key"
+`
+)
+
+func writeMockConfigs(t *testing.T) (userConfig, systemConfig string) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	userConfig = path.Join(tmpDir, "config")
+	systemConfig = path.Join(tmpDir, "ssh_config")
+	require.NoError(t, os.WriteFile(userConfig, []byte(mockUserConfig), 0o600))
+	require.NoError(t, os.WriteFile(systemConfig, []byte(mockSystemConfig), 0o600))
+
+	return userConfig, systemConfig
+}
+
+func TestReadConfig_UserOverridesSystem(t *testing.T) {
+	userConfig, systemConfig := writeMockConfigs(t)
+
+	// Port and IdentityFile are defined in both files, user values win. User is taken from system config.
+	actual, err := ReadConfig("app.example.com", userConfig, systemConfig)
+	require.NoError(t, err)
+	require.Equal(t, &Config{
+		Hostname:     "app.example.com",
+		IdentityFile: "~/.ssh/user key",
+		Port:         "2222",
+		User:         "system_user",
+	}, actual)
+
+	// When order of files is reversed, system values win.
+	actual, err = ReadConfig("app.example.com", systemConfig, userConfig)
+	require.NoError(t, err)
+	require.Equal(t, "22", actual.Port)
+	require.Equal(t, "/etc/ssh/system_key", actual.IdentityFile)
+}
+
+func TestReadConfig_HostPatterns(t *testing.T) {
+	userConfig, systemConfig := writeMockConfigs(t)
+
+	// Only 'Host *' block of the system config applies
+	actual, err := ReadConfig("db", userConfig, systemConfig)
+	require.NoError(t, err)
+	require.Equal(t, &Config{
+		Hostname:     "db.example.com",
+		IdentityFile: "",
+		Port:         "22",
+		User:         "fallback_user",
+	}, actual)
+
+	// Negated pattern excludes the host
+	actual, err = ReadConfig("web.example.com", userConfig, systemConfig)
+	require.NoError(t, err)
+	require.Equal(t, "web.example.com", actual.Hostname)
+	require.Equal(t, "2222", actual.Port)
+}
+
+func TestReadConfig_MissingFiles(t *testing.T) {
+	userConfig, _ := writeMockConfigs(t)
+
+	actual, err := ReadConfig("app.example.com", userConfig, path.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	require.Equal(t, "2222", actual.Port)
+	require.Equal(t, currentUsername(), actual.User)
+
+	// No config files at all - defaults are used
+	actual, err = ReadConfig("localhost")
+	require.NoError(t, err)
+	require.Equal(t, &Config{Hostname: "localhost", Port: "22", User: currentUsername()}, actual)
+}
+
+func TestParseConfigFile(t *testing.T) {
+	config := `
+# Comment
+User global_user
+Port 2022
+Host target
+	Port	3022
+	Port 4022
+Match host target
+	User match_user
+Host other
+	User other_user
+`
+	// Within a single file the first obtained value is used, global directives go first
+	actual, err := parseConfigFile(strings.NewReader(config), "config", "target")
+	require.NoError(t, err)
+	require.Equal(t, map[string]configDirective{
+		"user": {Value: "global_user", Source: ConfigSource{File: "config", Line: 3}},
+		"port": {Value: "2022", Source: ConfigSource{File: "config", Line: 4}},
+	}, actual)
+
+	// Source contains patterns of the host block
+	config = "Port 2022\nHost web-* !web-01\n  User web_user\n"
+	actual, err = parseConfigFile(strings.NewReader(config), "config", "web-02")
+	require.NoError(t, err)
+	require.Equal(t, ConfigSource{File: "config", Line: 3, Host: "web-* !web-01"}, actual["user"].Source)
+	require.Equal(t, "config:3, Host web-* !web-01", actual["user"].Source.String())
+	require.Equal(t, "config:1", actual["port"].Source.String())
+
+	// 'Match' blocks are not evaluated
+	actual, err = parseConfigFile(strings.NewReader("Match all\nUser match_user\n"), "config", "target")
+	require.NoError(t, err)
+	require.Empty(t, actual)
+}
+
+func TestAttachSources(t *testing.T) {
+	userConfig, systemConfig := writeMockConfigs(t)
+
+	// Values which are the same as in the files get a source, the first file wins
+	config := &Config{Hostname: "app.example.com", Port: "2222", User: "system_user", IdentityFile: "~/.ssh/other"}
+	require.NoError(t, config.AttachSources("app.example.com", userConfig, systemConfig))
+	require.Equal(t, map[string]ConfigSource{
+		"port": {File: userConfig, Line: 5, Host: "*.example.com"},
+		"user": {File: systemConfig, Line: 4, Host: "*.example.com"},
+	}, config.Sources)
+
+	// Hostname is case-insensitive
+	config = &Config{Hostname: "DB.example.com", Port: "22"}
+	require.NoError(t, config.AttachSources("db", userConfig, systemConfig))
+	require.Equal(t, map[string]ConfigSource{
+		"hostname": {File: userConfig, Line: 2, Host: "db !web.example.com"},
+	}, config.Sources)
+
+	// Missing files don't have sources
+	config = &Config{Port: "22"}
+	require.NoError(t, config.AttachSources("app.example.com", path.Join(t.TempDir(), "missing")))
+	require.Empty(t, config.Sources)
+}
+
+func TestSplitDirective(t *testing.T) {
+	tests := []struct {
+		line  string
+		key   string
+		value string
+	}{
+		{"", "", ""},
+		{"  # comment", "", ""},
+		{"HostName example.com", "hostname", "example.com"},
+		{"\tPort\t22", "port", "22"},
+		{"Port=22", "port", "22"},
+		{"Port = 22", "port", "22"},
+		{`IdentityFile "~/.ssh/my key"`, "identityfile", "~/.ssh/my key"},
+		{"Host web-* !web-01", "host", "web-* !web-01"},
+	}
+
+	for _, test := range tests {
+		key, value := splitDirective(test.line)
+		require.Equal(t, test.key, key, "Line: %q", test.line)
+		require.Equal(t, test.value, value, "Line: %q", test.line)
+	}
+}
+
+func TestDefaultConfigFiles(t *testing.T) {
+	// User config must have higher precedence than system config
+	require.Equal(t, []string{UserConfigFile, SystemConfigFile}, DefaultConfigFiles())
+}
@@ -1,6 +1,8 @@
 package ssh
 
 import (
+	"os"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -38,4 +40,26 @@ func TestParseConfig(t *testing.T) {
 
 	actual = Parse(unixMockSSHConfig)
 	require.Equal(t, expected, actual)
+
+	// ProxyJump is read from ssh config
+	require.Equal(t, "bastion", Parse("hostname db\nproxyjump bastion\nport 22").ProxyJump)
+}
+
+func TestParseWithSources(t *testing.T) {
+	configFile := path.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(configFile, []byte("Host db\n  HostName db.example.com\n  User admin\n"), 0o600))
+
+	// Sources are looked up for the host which is printed by ssh
+	output := "host db\nuser admin\nhostname db.example.com\nport 22\nhostbasedauthentication no"
+	actual := ParseWithSources(output, configFile)
+	require.Equal(t, "db.example.com", actual.Hostname)
+	require.Equal(t, map[string]ConfigSource{
+		"hostname": {File: configFile, Line: 2, Host: "db"},
+		"user":     {File: configFile, Line: 3, Host: "db"},
+	}, actual.Sources)
+
+	// Older ssh versions don't print the host, sources are unknown
+	actual = ParseWithSources("user admin\nhostname db.example.com\nport 22", configFile)
+	require.Equal(t, "admin", actual.User)
+	require.Nil(t, actual.Sources)
 }
@@ -0,0 +1,12 @@
+package ssh
+
+import "regexp"
+
+// Example: "root@localhost: Permission denied (publickey,password)."
+var publicKeyDeniedRe = regexp.MustCompile(`Permission denied \(([a-z-]+,)*publickey(,[a-z-]+)*\)`)
+
+// IsPublicKeyDenied - returns true if ssh output says that the remote host rejected
+// public key authentication. Usually it means that the key was not copied to the host.
+func IsPublicKeyDenied(stdErr string) bool {
+	return publicKeyDeniedRe.MatchString(stdErr)
+}
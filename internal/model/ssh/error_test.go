@@ -0,0 +1,30 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IsPublicKeyDenied(t *testing.T) {
+	tests := []struct {
+		name     string
+		stdErr   string
+		expected bool
+	}{
+		{"Public key only", "root@localhost: Permission denied (publickey).", true},
+		{"Several methods", "root@localhost: Permission denied (publickey,gssapi-keyex,password).", true},
+		{"Public key is not the first method", "Permission denied (password,publickey).", true},
+		{"Multiline output", "Warning: Permanently added 'localhost'\nroot@localhost: Permission denied (publickey).", true},
+		{"Password only", "root@localhost: Permission denied (password).", false},
+		{"Keyboard interactive only", "Permission denied (keyboard-interactive).", false},
+		{"Connection refused", "ssh: connect to host localhost port 22: Connection refused", false},
+		{"Empty output", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, IsPublicKeyDenied(tt.stdErr))
+		})
+	}
+}
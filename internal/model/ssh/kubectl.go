@@ -0,0 +1,51 @@
+package ssh
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KubectlShell - is a shell which is started in a container when connecting to a pod.
+const KubectlShell = "/bin/sh"
+
+type (
+	// OptionKubeNamespace - is a Kubernetes namespace of the pod.
+	OptionKubeNamespace struct{ Value string }
+	// OptionKubePod - is a name of the pod to connect to.
+	OptionKubePod struct{ Value string }
+	// OptionKubeContainer - is a container of the pod, kubectl chooses the default one when empty.
+	OptionKubeContainer struct{ Value string }
+	// OptionKubeCommand - is a command which is executed in the container instead of an interactive shell.
+	OptionKubeCommand struct{ Value string }
+)
+
+// KubectlExecCommand - builds kubectl command which starts an interactive shell in a container of the pod.
+// If OptionKubeCommand is set, the command is executed without allocating a terminal.
+func KubectlExecCommand(options ...Option) string {
+	var namespace, pod, container, command string
+	for _, option := range options {
+		switch opt := option.(type) {
+		case OptionKubeNamespace:
+			namespace = strings.TrimSpace(opt.Value)
+		case OptionKubePod:
+			pod = strings.TrimSpace(opt.Value)
+		case OptionKubeContainer:
+			container = strings.TrimSpace(opt.Value)
+		case OptionKubeCommand:
+			command = strings.TrimSpace(opt.Value)
+		}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("kubectl exec")
+	if command == "" {
+		command = KubectlShell
+		sb.WriteString(" -it")
+	}
+	sb.WriteString(constructKeyValueOption("-n", namespace))
+	sb.WriteString(fmt.Sprintf(" %s", pod))
+	sb.WriteString(constructKeyValueOption("-c", container))
+	sb.WriteString(fmt.Sprintf(" -- %s", command))
+
+	return sb.String()
+}
@@ -0,0 +1,62 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubectlExecCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Option
+		expected string
+	}{
+		{
+			name:     "Pod only",
+			options:  []Option{OptionKubePod{Value: "web-0"}},
+			expected: "kubectl exec -it web-0 -- /bin/sh",
+		},
+		{
+			name: "Namespace, pod and container",
+			options: []Option{
+				OptionKubeNamespace{Value: "production"},
+				OptionKubePod{Value: " web-0 "},
+				OptionKubeContainer{Value: "nginx"},
+			},
+			expected: "kubectl exec -it -n production web-0 -c nginx -- /bin/sh",
+		},
+		{
+			name: "Default container",
+			options: []Option{
+				OptionKubeNamespace{Value: "production"},
+				OptionKubePod{Value: "web-0"},
+				OptionKubeContainer{Value: " "},
+			},
+			expected: "kubectl exec -it -n production web-0 -- /bin/sh",
+		},
+		{
+			name: "Ssh options are ignored",
+			options: []Option{
+				OptionLoginName{Value: "root"},
+				OptionRemotePort{Value: "2222"},
+				OptionKubePod{Value: "web-0"},
+			},
+			expected: "kubectl exec -it web-0 -- /bin/sh",
+		},
+		{
+			name: "Command instead of a shell",
+			options: []Option{
+				OptionKubePod{Value: "web-0"},
+				OptionKubeCommand{Value: "uname -s"},
+			},
+			expected: "kubectl exec web-0 -- uname -s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, KubectlExecCommand(tt.options...))
+		})
+	}
+}
@@ -21,6 +21,51 @@ type (
 	OptionAddress struct{ Value string }
 	// OptionReadConfig - is used to read config file from ssh_config.
 	OptionReadConfig struct{ Value string }
+	// OptionGatewayPorts - specifies whether remote hosts are allowed to connect to local forwarded ports.
+	OptionGatewayPorts struct{ Value string }
+	// OptionNoConfig - prevents ssh from reading configuration files, all parameters are taken from command line.
+	OptionNoConfig struct{}
+	// OptionBatchMode - disables password and passphrase prompts, it's used when ssh runs in background.
+	OptionBatchMode struct{}
+	// OptionIdentitiesOnly - makes ssh use only the identity file which is set explicitly, even if ssh-agent offers more keys.
+	OptionIdentitiesOnly struct{ Value bool }
+	// OptionForwardAgent - forwards connection to the local ssh-agent, so keys can be used on the remote host.
+	OptionForwardAgent struct{ Value bool }
+	// OptionCompression - enables compression of all data, it speeds up slow connections.
+	OptionCompression struct{ Value bool }
+	// OptionCompressionLevel - is a compression level from 1 (fast) to 9 (best). Ex: "6".
+	OptionCompressionLevel struct{ Value string }
+	// OptionProxyJump - is a jump host which ssh connects to first. Ex: "bastion.example.com".
+	OptionProxyJump struct{ Value string }
+	// OptionEscapeChar - is the escape character of the session, "none" disables escapes. Ex: "~", "^", "none".
+	OptionEscapeChar struct{ Value string }
+	// OptionBindAddress - is a local address which outgoing connection is bound to. Ex: "192.168.1.10".
+	OptionBindAddress struct{ Value string }
+	// OptionHostKeyAlias - is a name which is used instead of the address to look up the host key. Ex: "db-primary".
+	OptionHostKeyAlias struct{ Value string }
+	// OptionStrictHostKeyChecking - defines how unknown and changed host keys are handled. Ex: "yes", "no", "accept-new".
+	OptionStrictHostKeyChecking struct{ Value string }
+	// OptionConnectTimeout - is a timeout in seconds which is used when connecting to the remote host. Ex: "10".
+	OptionConnectTimeout struct{ Value string }
+	// OptionServerAliveInterval - is a number of seconds after which keepalive message is sent to idle server. Ex: "30".
+	OptionServerAliveInterval struct{ Value string }
+	// OptionServerAliveCountMax - is a number of unanswered keepalive messages after which ssh disconnects. Ex: "3".
+	OptionServerAliveCountMax struct{ Value string }
+	// OptionForceTTY - forces pseudo-terminal allocation, so a remote command can be interrupted with Ctrl+C.
+	OptionForceTTY struct{}
+	// OptionBackground - makes ssh go to background after authentication without a terminal and a remote command,
+	// only port forwarding is done.
+	OptionBackground struct{ Value bool }
+	// OptionPortForward - is a local or remote port forwarding entry. Ex: "-L 8080:localhost:80" or "-R 9000:localhost:9000".
+	OptionPortForward struct{ Value string }
+	// OptionLocalForward - is a list of local port forwarding specs, one '-L' flag per entry. Ex: ["8080:localhost:80"].
+	OptionLocalForward struct{ Value []string }
+	// OptionRemoteForward - is a list of remote port forwarding specs, one '-R' flag per entry. Ex: ["9000:localhost:3000"].
+	OptionRemoteForward struct{ Value []string }
+	// OptionDynamicForward - is a local port of SOCKS proxy which forwards connections through the remote host. Ex: "1080".
+	OptionDynamicForward struct{ Value string }
+	// OptionExtra - is a list of raw ssh_config options, one '-o' flag per entry. Ex: ["Ciphers=aes256-ctr"].
+	OptionExtra struct{ Value []string }
 )
 
 func constructKeyValueOption(optionFlag, optionValue string) string {
@@ -31,6 +76,44 @@ func constructKeyValueOption(optionFlag, optionValue string) string {
 	return ""
 }
 
+// constructConfigOption - builds '-o Name=Value' option which overrides ssh_config parameter.
+func constructConfigOption(optionName, optionValue string) string {
+	optionValue = strings.TrimSpace(optionValue)
+	if optionValue != "" {
+		return fmt.Sprintf(" -o %s=%s", optionName, optionValue)
+	}
+	return ""
+}
+
+// constructExtraOption - builds '-o Key=Value' option from a raw entry. The entry is quoted when it contains
+// spaces, for instance "ProxyCommand=nc -X 5 %h %p", so it's passed to ssh as a single argument.
+func constructExtraOption(optionValue string) string {
+	optionValue = strings.TrimSpace(optionValue)
+	if optionValue == "" {
+		return ""
+	}
+
+	if strings.ContainsAny(optionValue, " \t") {
+		quote := `"`
+		if strings.Contains(optionValue, quote) {
+			quote = "'"
+		}
+		optionValue = quote + optionValue + quote
+	}
+
+	return fmt.Sprintf(" -o %s", optionValue)
+}
+
+// constructPortForwardOption - builds '-L spec' or '-R spec' option, entries with any other flag are ignored.
+func constructPortForwardOption(optionValue string) string {
+	flag, spec, _ := strings.Cut(strings.TrimSpace(optionValue), " ")
+	spec = strings.TrimSpace(spec)
+	if (flag == "-L" || flag == "-R") && spec != "" {
+		return fmt.Sprintf(" %s %s", flag, spec)
+	}
+	return ""
+}
+
 func addOption(sb *strings.Builder, rawParameter Option) {
 	var option string
 	switch p := rawParameter.(type) {
@@ -40,6 +123,64 @@ func addOption(sb *strings.Builder, rawParameter Option) {
 		option = constructKeyValueOption("-p", p.Value)
 	case OptionLoginName:
 		option = constructKeyValueOption("-l", p.Value)
+	case OptionGatewayPorts:
+		option = constructConfigOption("GatewayPorts", p.Value)
+	case OptionNoConfig:
+		option = " -F none"
+	case OptionBatchMode:
+		option = constructConfigOption("BatchMode", "yes")
+	case OptionIdentitiesOnly:
+		if p.Value {
+			option = constructConfigOption("IdentitiesOnly", "yes")
+		}
+	case OptionForwardAgent:
+		if p.Value {
+			option = constructConfigOption("ForwardAgent", "yes")
+		}
+	case OptionCompression:
+		if p.Value {
+			option = " -C"
+		}
+	case OptionCompressionLevel:
+		option = constructConfigOption("CompressionLevel", p.Value)
+	case OptionProxyJump:
+		option = constructKeyValueOption("-J", p.Value)
+	case OptionEscapeChar:
+		option = constructKeyValueOption("-e", p.Value)
+	case OptionBindAddress:
+		option = constructKeyValueOption("-b", p.Value)
+	case OptionHostKeyAlias:
+		option = constructConfigOption("HostKeyAlias", p.Value)
+	case OptionStrictHostKeyChecking:
+		option = constructConfigOption("StrictHostKeyChecking", p.Value)
+	case OptionConnectTimeout:
+		option = constructConfigOption("ConnectTimeout", p.Value)
+	case OptionServerAliveInterval:
+		option = constructConfigOption("ServerAliveInterval", p.Value)
+	case OptionServerAliveCountMax:
+		option = constructConfigOption("ServerAliveCountMax", p.Value)
+	case OptionForceTTY:
+		option = " -t"
+	case OptionBackground:
+		if p.Value {
+			option = " -f -N -T"
+		}
+	case OptionPortForward:
+		option = constructPortForwardOption(p.Value)
+	case OptionLocalForward:
+		for _, forward := range p.Value {
+			option += constructKeyValueOption("-L", forward)
+		}
+	case OptionDynamicForward:
+		option = constructKeyValueOption("-D", p.Value)
+	case OptionExtra:
+		for _, extra := range p.Value {
+			option += constructExtraOption(extra)
+		}
+	case OptionRemoteForward:
+		for _, forward := range p.Value {
+			option += constructKeyValueOption("-R", forward)
+		}
 	case OptionReadConfig:
 		option = constructKeyValueOption("-G", utils.RemoveDuplicateSpaces(p.Value))
 	case OptionAddress:
@@ -45,6 +45,12 @@ func Test_ConstructKeyValueOption(t *testing.T) {
 	}
 }
 
+func Test_ConstructConfigOption(t *testing.T) {
+	require.Equal(t, " -o GatewayPorts=yes", constructConfigOption("GatewayPorts", "yes"))
+	require.Equal(t, " -o GatewayPorts=no", constructConfigOption("GatewayPorts", "  no  "))
+	require.Equal(t, "", constructConfigOption("GatewayPorts", ""))
+}
+
 func Test_AddOption(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -71,6 +77,231 @@ func Test_AddOption(t *testing.T) {
 			rawParameter:   OptionAddress{Value: ""},
 			expectedResult: "",
 		},
+		{
+			name:           "OptionGatewayPorts 'yes'",
+			rawParameter:   OptionGatewayPorts{Value: "yes"},
+			expectedResult: " -o GatewayPorts=yes",
+		},
+		{
+			name:           "OptionGatewayPorts 'no'",
+			rawParameter:   OptionGatewayPorts{Value: "no"},
+			expectedResult: " -o GatewayPorts=no",
+		},
+		{
+			name:           "OptionGatewayPorts 'clientspecified'",
+			rawParameter:   OptionGatewayPorts{Value: "clientspecified"},
+			expectedResult: " -o GatewayPorts=clientspecified",
+		},
+		{
+			name:           "OptionProxyJump with value",
+			rawParameter:   OptionProxyJump{Value: "bastion.example.com"},
+			expectedResult: " -J bastion.example.com",
+		},
+		{
+			name:           "OptionProxyJump with empty value",
+			rawParameter:   OptionProxyJump{Value: " "},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionEscapeChar with character",
+			rawParameter:   OptionEscapeChar{Value: "^"},
+			expectedResult: " -e ^",
+		},
+		{
+			name:           "OptionEscapeChar 'none'",
+			rawParameter:   OptionEscapeChar{Value: "none"},
+			expectedResult: " -e none",
+		},
+		{
+			name:           "OptionEscapeChar with empty value",
+			rawParameter:   OptionEscapeChar{Value: ""},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionGatewayPorts with empty value",
+			rawParameter:   OptionGatewayPorts{Value: ""},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionBindAddress with value",
+			rawParameter:   OptionBindAddress{Value: "192.168.1.10"},
+			expectedResult: " -b 192.168.1.10",
+		},
+		{
+			name:           "OptionBindAddress with empty value",
+			rawParameter:   OptionBindAddress{Value: " "},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionForceTTY",
+			rawParameter:   OptionForceTTY{},
+			expectedResult: " -t",
+		},
+		{
+			name:           "OptionBackground",
+			rawParameter:   OptionBackground{Value: true},
+			expectedResult: " -f -N -T",
+		},
+		{
+			name:           "OptionBackground disabled",
+			rawParameter:   OptionBackground{Value: false},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionNoConfig",
+			rawParameter:   OptionNoConfig{},
+			expectedResult: " -F none",
+		},
+		{
+			name:           "OptionBatchMode",
+			rawParameter:   OptionBatchMode{},
+			expectedResult: " -o BatchMode=yes",
+		},
+		{
+			name:           "OptionIdentitiesOnly",
+			rawParameter:   OptionIdentitiesOnly{Value: true},
+			expectedResult: " -o IdentitiesOnly=yes",
+		},
+		{
+			name:           "OptionIdentitiesOnly disabled",
+			rawParameter:   OptionIdentitiesOnly{Value: false},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionForwardAgent",
+			rawParameter:   OptionForwardAgent{Value: true},
+			expectedResult: " -o ForwardAgent=yes",
+		},
+		{
+			name:           "OptionForwardAgent disabled",
+			rawParameter:   OptionForwardAgent{Value: false},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionCompression",
+			rawParameter:   OptionCompression{Value: true},
+			expectedResult: " -C",
+		},
+		{
+			name:           "OptionCompression disabled",
+			rawParameter:   OptionCompression{Value: false},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionCompressionLevel",
+			rawParameter:   OptionCompressionLevel{Value: " 6 "},
+			expectedResult: " -o CompressionLevel=6",
+		},
+		{
+			name:           "OptionCompressionLevel empty",
+			rawParameter:   OptionCompressionLevel{Value: ""},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionLocalForward",
+			rawParameter:   OptionLocalForward{Value: []string{"8080:localhost:80", " ", " 5432:db:5432 "}},
+			expectedResult: " -L 8080:localhost:80 -L 5432:db:5432",
+		},
+		{
+			name:           "OptionLocalForward empty",
+			rawParameter:   OptionLocalForward{},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionRemoteForward",
+			rawParameter:   OptionRemoteForward{Value: []string{"9000:localhost:3000", " *:8080:localhost:80 "}},
+			expectedResult: " -R 9000:localhost:3000 -R *:8080:localhost:80",
+		},
+		{
+			name:           "OptionDynamicForward",
+			rawParameter:   OptionDynamicForward{Value: " 1080 "},
+			expectedResult: " -D 1080",
+		},
+		{
+			name:           "OptionDynamicForward empty",
+			rawParameter:   OptionDynamicForward{Value: ""},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionExtra",
+			rawParameter:   OptionExtra{Value: []string{"Ciphers=aes256-ctr,aes128-ctr", " RekeyLimit=1G "}},
+			expectedResult: " -o Ciphers=aes256-ctr,aes128-ctr -o RekeyLimit=1G",
+		},
+		{
+			name:           "OptionExtra with spaces",
+			rawParameter:   OptionExtra{Value: []string{"ProxyCommand=nc -X 5 %h %p", `LocalCommand=echo "hi"`}},
+			expectedResult: ` -o "ProxyCommand=nc -X 5 %h %p" -o 'LocalCommand=echo "hi"'`,
+		},
+		{
+			name:           "OptionExtra empty",
+			rawParameter:   OptionExtra{Value: nil},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionHostKeyAlias",
+			rawParameter:   OptionHostKeyAlias{Value: " db-primary "},
+			expectedResult: " -o HostKeyAlias=db-primary",
+		},
+		{
+			name:           "OptionHostKeyAlias empty",
+			rawParameter:   OptionHostKeyAlias{Value: ""},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionStrictHostKeyChecking",
+			rawParameter:   OptionStrictHostKeyChecking{Value: "accept-new"},
+			expectedResult: " -o StrictHostKeyChecking=accept-new",
+		},
+		{
+			name:           "OptionStrictHostKeyChecking empty",
+			rawParameter:   OptionStrictHostKeyChecking{Value: ""},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionConnectTimeout",
+			rawParameter:   OptionConnectTimeout{Value: "10"},
+			expectedResult: " -o ConnectTimeout=10",
+		},
+		{
+			name:           "OptionConnectTimeout empty",
+			rawParameter:   OptionConnectTimeout{Value: " "},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionServerAliveInterval",
+			rawParameter:   OptionServerAliveInterval{Value: "30"},
+			expectedResult: " -o ServerAliveInterval=30",
+		},
+		{
+			name:           "OptionServerAliveCountMax",
+			rawParameter:   OptionServerAliveCountMax{Value: "0"},
+			expectedResult: " -o ServerAliveCountMax=0",
+		},
+		{
+			name:           "OptionServerAliveInterval empty",
+			rawParameter:   OptionServerAliveInterval{Value: ""},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionPortForward local",
+			rawParameter:   OptionPortForward{Value: " -L  8080:localhost:80 "},
+			expectedResult: " -L 8080:localhost:80",
+		},
+		{
+			name:           "OptionPortForward remote",
+			rawParameter:   OptionPortForward{Value: "-R 9000:localhost:9000"},
+			expectedResult: " -R 9000:localhost:9000",
+		},
+		{
+			name:           "OptionPortForward with unsupported flag",
+			rawParameter:   OptionPortForward{Value: "-D 1080"},
+			expectedResult: "",
+		},
+		{
+			name:           "OptionPortForward without spec",
+			rawParameter:   OptionPortForward{Value: "-L"},
+			expectedResult: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,66 @@
+package ssh
+
+import "strings"
+
+// Labels of remote operating systems, see ParseRemoteOS.
+const (
+	RemoteOSLinux   = "linux"
+	RemoteOSMacOS   = "macos"
+	RemoteOSFreeBSD = "freebsd"
+	RemoteOSOpenBSD = "openbsd"
+	RemoteOSNetBSD  = "netbsd"
+	RemoteOSSolaris = "solaris"
+	RemoteOSWindows = "windows"
+	RemoteOSAIX     = "aix"
+)
+
+// RemoteOSProbeCommand - is executed on a remote host to detect its operating system.
+const RemoteOSProbeCommand = "uname -s"
+
+var remoteOSIcons = map[string]string{
+	RemoteOSLinux:   "🐧",
+	RemoteOSMacOS:   "🍎",
+	RemoteOSFreeBSD: "😈",
+	RemoteOSOpenBSD: "🐡",
+	RemoteOSNetBSD:  "🚩",
+	RemoteOSSolaris: "☀️",
+	RemoteOSWindows: "🪟",
+	RemoteOSAIX:     "🖥️",
+}
+
+// ParseRemoteOS - maps 'uname -s' output to an operating system label. Windows hosts report the name of
+// POSIX layer, for instance "CYGWIN_NT-10.0" or "MINGW64_NT-10.0". Unknown systems are returned in lowercase
+// as is, empty string is returned when output is empty.
+func ParseRemoteOS(unameOutput string) string {
+	// Login banners or motd may precede the command output, the last line is used.
+	lines := strings.Split(strings.TrimSpace(unameOutput), "\n")
+	kernel := strings.ToLower(strings.TrimSpace(lines[len(lines)-1]))
+
+	switch {
+	case kernel == "":
+		return ""
+	case kernel == "linux":
+		return RemoteOSLinux
+	case kernel == "darwin":
+		return RemoteOSMacOS
+	case kernel == "freebsd":
+		return RemoteOSFreeBSD
+	case kernel == "openbsd":
+		return RemoteOSOpenBSD
+	case kernel == "netbsd":
+		return RemoteOSNetBSD
+	case kernel == "sunos":
+		return RemoteOSSolaris
+	case kernel == "aix":
+		return RemoteOSAIX
+	case strings.HasPrefix(kernel, "cygwin"), strings.HasPrefix(kernel, "mingw"), strings.HasPrefix(kernel, "msys"):
+		return RemoteOSWindows
+	default:
+		return kernel
+	}
+}
+
+// RemoteOSIcon - returns an icon of the operating system label, or an empty string if the system is unknown.
+func RemoteOSIcon(label string) string {
+	return remoteOSIcons[label]
+}
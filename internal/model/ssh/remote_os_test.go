@@ -0,0 +1,39 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteOS(t *testing.T) {
+	tests := []struct {
+		uname    string
+		expected string
+	}{
+		{"Linux\n", RemoteOSLinux},
+		{"Darwin", RemoteOSMacOS},
+		{"FreeBSD", RemoteOSFreeBSD},
+		{"OpenBSD", RemoteOSOpenBSD},
+		{"NetBSD", RemoteOSNetBSD},
+		{"SunOS", RemoteOSSolaris},
+		{"AIX", RemoteOSAIX},
+		{"CYGWIN_NT-10.0-19045", RemoteOSWindows},
+		{"MINGW64_NT-10.0-19045", RemoteOSWindows},
+		{"MSYS_NT-10.0", RemoteOSWindows},
+		{"Welcome to the server!\nLinux", RemoteOSLinux},
+		{"Haiku", "haiku"},
+		{"  ", ""},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, ParseRemoteOS(tt.uname), "uname: %q", tt.uname)
+	}
+}
+
+func TestRemoteOSIcon(t *testing.T) {
+	require.Equal(t, "🐧", RemoteOSIcon(RemoteOSLinux))
+	require.Equal(t, "🪟", RemoteOSIcon(RemoteOSWindows))
+	require.Empty(t, RemoteOSIcon("haiku"))
+	require.Empty(t, RemoteOSIcon(""))
+}
@@ -0,0 +1,43 @@
+package ssh
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OptionTeleportCluster - is a name of Teleport cluster which the remote host belongs to.
+type OptionTeleportCluster struct{ Value string }
+
+// TeleportConnectCommand - builds tsh command to connect to a remote host through Teleport proxy. Teleport
+// manages keys and certificates by itself, that's why only login name, port and cluster are taken into account.
+func TeleportConnectCommand(options ...Option) string {
+	var hostname, username, remotePort, cluster string
+	for _, option := range options {
+		switch opt := option.(type) {
+		case OptionAddress:
+			hostname = strings.TrimSpace(opt.Value)
+		case OptionLoginName:
+			username = strings.TrimSpace(opt.Value)
+		case OptionRemotePort:
+			remotePort = strings.TrimSpace(opt.Value)
+		case OptionTeleportCluster:
+			cluster = strings.TrimSpace(opt.Value)
+		}
+	}
+
+	// Address may already contain login name. Ex: root@node.
+	if username != "" && !strings.Contains(hostname, "@") {
+		hostname = fmt.Sprintf("%s@%s", username, hostname)
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("tsh ssh")
+	if cluster != "" {
+		sb.WriteString(fmt.Sprintf(" --cluster=%s", cluster))
+	}
+	sb.WriteString(constructKeyValueOption("-p", remotePort))
+	sb.WriteString(" ")
+	sb.WriteString(hostname)
+
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeleportConnectCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Option
+		expected string
+	}{
+		{
+			name:     "Address only",
+			options:  []Option{OptionAddress{Value: "node1"}},
+			expected: "tsh ssh node1",
+		},
+		{
+			name: "Login name and cluster",
+			options: []Option{
+				OptionTeleportCluster{Value: "production"},
+				OptionLoginName{Value: "root"},
+				OptionAddress{Value: "node1"},
+			},
+			expected: "tsh ssh --cluster=production root@node1",
+		},
+		{
+			name: "All options",
+			options: []Option{
+				OptionTeleportCluster{Value: " staging "},
+				OptionRemotePort{Value: "2222"},
+				OptionLoginName{Value: "admin"},
+				OptionAddress{Value: " node2 "},
+			},
+			expected: "tsh ssh --cluster=staging -p 2222 admin@node2",
+		},
+		{
+			name: "Address contains login name",
+			options: []Option{
+				OptionLoginName{Value: "admin"},
+				OptionAddress{Value: "root@node1"},
+			},
+			expected: "tsh ssh root@node1",
+		},
+		{
+			name: "Options which are managed by Teleport are ignored",
+			options: []Option{
+				OptionPrivateKey{Value: "~/.ssh/id_rsa"},
+				OptionGatewayPorts{Value: "yes"},
+				OptionAddress{Value: "node1"},
+			},
+			expected: "tsh ssh node1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, TeleportConnectCommand(tt.options...))
+		})
+	}
+}
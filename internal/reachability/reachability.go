@@ -0,0 +1,111 @@
+// Package reachability checks whether hosts accept network connections.
+package reachability
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/utils"
+)
+
+const (
+	defaultSSHPort     = "22"
+	defaultDialTimeout = time.Second * 3
+)
+
+// ErrUnknownTarget is returned when network address cannot be extracted from host attributes.
+var ErrUnknownTarget = errors.New("cannot determine network address of the host")
+
+// DialFunc - opens a network connection. Has the same signature as net.Dialer.DialContext.
+type DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// Status - is a result of a reachability check of a single host.
+type Status struct {
+	Host      host.Host
+	Reachable bool
+	Latency   time.Duration
+	Err       error
+}
+
+// Scanner checks reachability of hosts one by one.
+type Scanner struct {
+	Dial    DialFunc
+	Timeout time.Duration
+}
+
+// New - creates a scanner which uses TCP connection to check whether ssh port of a host is open.
+func New() *Scanner {
+	dialer := net.Dialer{}
+
+	return &Scanner{
+		Dial:    dialer.DialContext,
+		Timeout: defaultDialTimeout,
+	}
+}
+
+// Target - returns network address of the host in "hostname:port" format. Values which are
+// loaded from ssh config take effect when host attributes are not set.
+func Target(h host.Host) (string, error) {
+	hostname := strings.TrimSpace(h.Address)
+	port := strings.TrimSpace(h.RemotePort)
+
+	if h.SSHClientConfig != nil {
+		hostname = lo.CoalesceOrEmpty(strings.TrimSpace(h.SSHClientConfig.Hostname), hostname)
+		port = lo.CoalesceOrEmpty(port, strings.TrimSpace(h.SSHClientConfig.Port))
+	} else if h.IsUserDefinedSSHCommand() || h.SSHAlias != "" {
+		// Without ssh config it's impossible to say which host is behind the custom connect string.
+		return "", ErrUnknownTarget
+	}
+
+	if utils.StringEmpty(hostname) {
+		return "", ErrUnknownTarget
+	}
+
+	return net.JoinHostPort(hostname, lo.CoalesceOrEmpty(port, defaultSSHPort)), nil
+}
+
+// Scan - checks reachability of the hosts. Hosts with higher priority are checked first,
+// hosts with equal priority preserve their original order. Results are returned in the order of the scan.
+func (s *Scanner) Scan(ctx context.Context, hosts []host.Host) []Status {
+	ordered := slices.Clone(hosts)
+	slices.SortStableFunc(ordered, func(a, b host.Host) int {
+		return b.Priority - a.Priority
+	})
+
+	result := make([]Status, 0, len(ordered))
+	for _, h := range ordered {
+		if ctx.Err() != nil {
+			break
+		}
+
+		result = append(result, s.check(ctx, h))
+	}
+
+	return result
+}
+
+func (s *Scanner) check(ctx context.Context, h host.Host) Status {
+	target, err := Target(h)
+	if err != nil {
+		return Status{Host: h, Err: err}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	conn, err := s.Dial(ctx, "tcp", target)
+	if err != nil {
+		return Status{Host: h, Err: err}
+	}
+	conn.Close()
+
+	return Status{Host: h, Reachable: true, Latency: time.Since(started)}
+}
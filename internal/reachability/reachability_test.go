@@ -0,0 +1,104 @@
+package reachability
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/model/ssh"
+)
+
+// mockDialer records addresses in the order they were dialed.
+type mockDialer struct {
+	dialed      []string
+	unreachable map[string]bool
+}
+
+func (d *mockDialer) Dial(_ context.Context, _, address string) (net.Conn, error) {
+	d.dialed = append(d.dialed, address)
+	if d.unreachable[address] {
+		return nil, errors.New("connection refused")
+	}
+
+	client, server := net.Pipe()
+	server.Close()
+
+	return client, nil
+}
+
+func TestTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		host        host.Host
+		expected    string
+		expectedErr error
+	}{
+		{"Address without port", host.Host{Address: "localhost"}, "localhost:22", nil},
+		{"Address with port", host.Host{Address: "localhost", RemotePort: "2222"}, "localhost:2222", nil},
+		{"IPv6 address", host.Host{Address: "::1"}, "[::1]:22", nil},
+		{
+			"Values from ssh config",
+			host.Host{Address: "web", SSHClientConfig: &ssh.Config{Hostname: "web.example.com", Port: "2200"}},
+			"web.example.com:2200",
+			nil,
+		},
+		{
+			"Host port takes precedence over ssh config",
+			host.Host{Address: "web", RemotePort: "22", SSHClientConfig: &ssh.Config{Hostname: "web.example.com", Port: "2200"}},
+			"web.example.com:22",
+			nil,
+		},
+		{"Custom connect string without ssh config", host.Host{Address: "root@localhost -p 2222"}, "", ErrUnknownTarget},
+		{"Empty address", host.Host{Address: " "}, "", ErrUnknownTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Target(tt.host)
+			require.Equal(t, tt.expected, actual)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestScan_OrderedByPriority(t *testing.T) {
+	hosts := []host.Host{
+		{ID: 1, Address: "low", Priority: -1},
+		{ID: 2, Address: "default-1"},
+		{ID: 3, Address: "critical", Priority: 10},
+		{ID: 4, Address: "default-2"},
+		{ID: 5, Address: "important", Priority: 5},
+	}
+
+	dialer := &mockDialer{unreachable: map[string]bool{"default-1:22": true}}
+	scanner := New()
+	scanner.Dial = dialer.Dial
+
+	result := scanner.Scan(context.TODO(), hosts)
+
+	// Hosts are dialed by descending priority, equal priorities preserve the original order
+	require.Equal(t, []string{"critical:22", "important:22", "default-1:22", "default-2:22", "low:22"}, dialer.dialed)
+	require.Len(t, result, len(hosts))
+	require.Equal(t, 3, result[0].Host.ID)
+	require.True(t, result[0].Reachable)
+	require.False(t, result[2].Reachable)
+	require.Error(t, result[2].Err)
+	// Original collection is not reordered
+	require.Equal(t, 1, hosts[0].ID)
+}
+
+func TestScan_Cancelled(t *testing.T) {
+	dialer := &mockDialer{}
+	scanner := New()
+	scanner.Dial = dialer.Dial
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.Empty(t, scanner.Scan(ctx, []host.Host{{Address: "localhost"}}))
+	require.Empty(t, dialer.dialed)
+}
@@ -6,20 +6,27 @@ import (
 	"path"
 	"sync"
 
+	"github.com/samber/lo"
 	"gopkg.in/yaml.v2"
 
+	"github.com/grafviktor/goto/internal/config"
 	"github.com/grafviktor/goto/internal/constant"
 )
 
-type view int
+// View is a screen which is currently displayed to the user.
+type View int
 
 const (
 	// ViewHostList mode is active when we browse through a list of hostnames.
-	ViewHostList view = iota
+	ViewHostList View = iota
 	// ViewEditItem mode is active when we edit existing or add a new host.
 	ViewEditItem
 	// ViewMessage mode is active when there was an error when attempted to connect to a remote host.
 	ViewMessage
+	// ViewHelp mode is active when user opens a full list of keyboard shortcuts.
+	ViewHelp
+	// ViewDashboard mode is active when user opens a summary of all hosts.
+	ViewDashboard
 )
 
 var (
@@ -28,6 +35,9 @@ var (
 	stateFile = "state.yaml"
 )
 
+// recentHostsLimit - number of recently selected hosts which are remembered, user switches between them.
+const recentHostsLimit = 2
+
 type iLogger interface {
 	Debug(format string, args ...any)
 	Info(format string, args ...any)
@@ -39,10 +49,22 @@ type ApplicationState struct {
 	Selected         int `yaml:"selected"`
 	appStateFilePath string
 	logger           iLogger
-	CurrentView      view                  `yaml:"-"`
+	CurrentView      View                  `yaml:"-"`
 	Width            int                   `yaml:"-"`
 	Height           int                   `yaml:"-"`
 	ScreenLayout     constant.ScreenLayout `yaml:"screenLayout,omitempty"`
+	// HostSortOrder is chosen by user in the host list, empty value means that hosts are grouped.
+	HostSortOrder constant.HostSortOrder `yaml:"hostSortOrder,omitempty"`
+	// RecentHosts contains ids of the most recently selected hosts, the last selected host goes first.
+	RecentHosts []int `yaml:"recentHosts,omitempty"`
+	// ApplicationConfig contains user-definable parameters. It's not persisted, because
+	// these parameters are read from environment variables and command line flags.
+	ApplicationConfig config.User `yaml:"-"`
+	// acknowledgedBanners contains banner texts which user acknowledged during the current session, key is host id.
+	acknowledgedBanners map[int]string
+	// jumpHostID and jumpHost contain the host which connections jump through during the current session.
+	jumpHostID int
+	jumpHost   string
 }
 
 // Get - reads application state from disk.
@@ -98,3 +120,50 @@ func (as *ApplicationState) Persist() error {
 
 	return nil
 }
+
+// IsBannerAcknowledged - returns true if user acknowledged the banner of the host during the current session.
+// If banner text was changed after acknowledgement, it should be acknowledged again.
+func (as *ApplicationState) IsBannerAcknowledged(hostID int, banner string) bool {
+	acknowledged, ok := as.acknowledgedBanners[hostID]
+	return ok && acknowledged == banner
+}
+
+// AcknowledgeBanner - remembers that user acknowledged the banner of the host. The state is never persisted.
+func (as *ApplicationState) AcknowledgeBanner(hostID int, banner string) {
+	if as.acknowledgedBanners == nil {
+		as.acknowledgedBanners = make(map[int]string)
+	}
+
+	as.acknowledgedBanners[hostID] = banner
+}
+
+// SetJumpHost - makes connections to all other hosts jump through the host, see host.WithJumpHost.
+// Empty jumpHost clears the selection. The state is never persisted.
+func (as *ApplicationState) SetJumpHost(hostID int, jumpHost string) {
+	if jumpHost == "" {
+		hostID = 0
+	}
+
+	as.jumpHostID = hostID
+	as.jumpHost = jumpHost
+}
+
+// JumpHost - returns id and destination of the host which connections jump through, destination is empty
+// when there is no such host.
+func (as *ApplicationState) JumpHost() (int, string) {
+	return as.jumpHostID, as.jumpHost
+}
+
+// AddRecentHost - remembers that the host was selected, only the last two hosts are kept.
+func (as *ApplicationState) AddRecentHost(hostID int) {
+	as.RecentHosts = append([]int{hostID}, lo.Without(as.RecentHosts, hostID)...)
+	if len(as.RecentHosts) > recentHostsLimit {
+		as.RecentHosts = as.RecentHosts[:recentHostsLimit]
+	}
+}
+
+// AlternateRecentHost - returns a recently selected host to switch to from the selected one, like alt-tab does.
+// Returns false if there is no other recent host.
+func (as *ApplicationState) AlternateRecentHost(selectedID int) (int, bool) {
+	return lo.Find(as.RecentHosts, func(hostID int) bool { return hostID != selectedID })
+}
@@ -80,3 +80,72 @@ func Test_PersistApplicationState(t *testing.T) {
 	// Ensure that the persisted state matches the modified state
 	assert.Equal(t, appState.Selected, persistedState.Selected)
 }
+
+func Test_AcknowledgeBanner(t *testing.T) {
+	appState := &ApplicationState{}
+	assert.False(t, appState.IsBannerAcknowledged(1, "Authorized use only"))
+
+	appState.AcknowledgeBanner(1, "Authorized use only")
+	assert.True(t, appState.IsBannerAcknowledged(1, "Authorized use only"))
+	// Other hosts are not affected
+	assert.False(t, appState.IsBannerAcknowledged(2, "Authorized use only"))
+	// Changed banner should be acknowledged again
+	assert.False(t, appState.IsBannerAcknowledged(1, "New banner"))
+
+	// Acknowledgements are not persisted
+	result, err := yaml.Marshal(appState)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(result), "Authorized use only")
+}
+
+func Test_JumpHost(t *testing.T) {
+	appState := &ApplicationState{}
+	_, jumpHost := appState.JumpHost()
+	assert.Empty(t, jumpHost)
+
+	appState.SetJumpHost(2, "root@bastion:2222")
+	hostID, jumpHost := appState.JumpHost()
+	assert.Equal(t, 2, hostID)
+	assert.Equal(t, "root@bastion:2222", jumpHost)
+
+	// Jump host is not persisted
+	result, err := yaml.Marshal(appState)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(result), "bastion")
+
+	appState.SetJumpHost(2, "")
+	hostID, jumpHost = appState.JumpHost()
+	assert.Zero(t, hostID)
+	assert.Empty(t, jumpHost)
+}
+
+func Test_AlternateRecentHost(t *testing.T) {
+	appState := ApplicationState{}
+
+	// No hosts were used
+	_, ok := appState.AlternateRecentHost(1)
+	assert.False(t, ok)
+
+	// Only one host was used, it can't be toggled with itself
+	appState.AddRecentHost(1)
+	_, ok = appState.AlternateRecentHost(1)
+	assert.False(t, ok)
+	hostID, ok := appState.AlternateRecentHost(2)
+	assert.True(t, ok)
+	assert.Equal(t, 1, hostID)
+
+	// Selection toggles between two recent hosts
+	appState.AddRecentHost(2)
+	hostID, _ = appState.AlternateRecentHost(2)
+	assert.Equal(t, 1, hostID)
+	hostID, _ = appState.AlternateRecentHost(1)
+	assert.Equal(t, 2, hostID)
+	// When another host is selected, the last used host is chosen
+	hostID, _ = appState.AlternateRecentHost(3)
+	assert.Equal(t, 2, hostID)
+
+	// Only two hosts are kept and a host which is used again becomes the last one
+	appState.AddRecentHost(3)
+	appState.AddRecentHost(2)
+	assert.Equal(t, []int{2, 3}, appState.RecentHosts)
+}
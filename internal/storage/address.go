@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"strings"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/utils"
+)
+
+// FindByAddress - returns hosts which connect to the address using the login name. Addresses are
+// case-insensitive, surrounding and duplicate spaces are ignored. Empty login name matches hosts
+// without a login name only.
+func (s *yamlStorage) FindByAddress(address, login string) ([]model.Host, error) {
+	hosts, err := s.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	normalize := func(address string) string { return utils.RemoveDuplicateSpaces(strings.TrimSpace(address)) }
+	address, login = normalize(address), strings.TrimSpace(login)
+	found := make([]model.Host, 0)
+	for _, h := range hosts {
+		if strings.EqualFold(normalize(h.Address), address) && strings.TrimSpace(h.LoginName) == login {
+			found = append(found, h)
+		}
+	}
+
+	return found, nil
+}
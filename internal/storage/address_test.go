@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/test"
+)
+
+func TestFindByAddress(t *testing.T) {
+	storage, err := NewYAML(context.TODO(), t.TempDir(), &test.MockLogger{})
+	require.NoError(t, err)
+	for _, h := range []model.Host{
+		{Title: "web", Address: "web.example.com", LoginName: "root"},
+		{Title: "web copy", Address: " WEB.example.com", LoginName: "root "},
+		{Title: "web admin", Address: "web.example.com", LoginName: "admin"},
+		{Title: "web default", Address: "web.example.com"},
+		{Title: "db", Address: "db.example.com", LoginName: "root"},
+	} {
+		_, err = storage.Save(h)
+		require.NoError(t, err)
+	}
+
+	titles := func(hosts []model.Host) []string {
+		return lo.Map(hosts, func(h model.Host, _ int) string { return h.Title })
+	}
+
+	hosts, err := storage.FindByAddress("web.example.com ", "root")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"web", "web copy"}, titles(hosts))
+
+	// Empty login name doesn't match hosts which have a login name
+	hosts, err = storage.FindByAddress("web.example.com", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"web default"}, titles(hosts))
+
+	hosts, err = storage.FindByAddress("cache.example.com", "root")
+	require.NoError(t, err)
+	require.Empty(t, hosts)
+}
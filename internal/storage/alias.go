@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/grafviktor/goto/internal/constant"
+	model "github.com/grafviktor/goto/internal/model/host"
+)
+
+// ErrAliasTaken is returned when a host alias is already used by another host.
+var ErrAliasTaken = errors.New("alias is used by another host")
+
+// GetByAlias - returns a host which has the alias. Aliases are case-insensitive.
+func GetByAlias(storage HostStorage, alias string) (model.Host, error) {
+	if strings.TrimSpace(alias) == "" {
+		return model.Host{}, constant.ErrNotFound
+	}
+
+	hosts, err := storage.GetAll()
+	if err != nil {
+		return model.Host{}, err
+	}
+
+	for _, h := range hosts {
+		if sameAlias(h.Alias, alias) {
+			return h, nil
+		}
+	}
+
+	return model.Host{}, constant.ErrNotFound
+}
+
+// CheckAliasUnique - returns ErrAliasTaken if another host already has the same alias as h.
+func CheckAliasUnique(storage HostStorage, h model.Host) error {
+	if strings.TrimSpace(h.Alias) == "" {
+		return nil
+	}
+
+	hosts, err := storage.GetAll()
+	if err != nil {
+		return err
+	}
+
+	return checkAliasUnique(hosts, h)
+}
+
+func checkAliasUnique(hosts []model.Host, h model.Host) error {
+	if strings.TrimSpace(h.Alias) == "" {
+		return nil
+	}
+
+	for _, existing := range hosts {
+		if existing.ID != h.ID && sameAlias(existing.Alias, h.Alias) {
+			return ErrAliasTaken
+		}
+	}
+
+	return nil
+}
+
+func sameAlias(a, b string) bool {
+	return strings.TrimSpace(a) != "" && strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
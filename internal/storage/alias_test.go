@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafviktor/goto/internal/constant"
+	model "github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/test"
+)
+
+func TestGetByAlias(t *testing.T) {
+	storage, err := NewYAML(context.TODO(), t.TempDir(), &test.MockLogger{})
+	require.NoError(t, err)
+	_, err = storage.Save(model.Host{Title: "web", Address: "10.0.0.1"})
+	require.NoError(t, err)
+	_, err = storage.Save(model.Host{Title: "db", Address: "10.0.0.2", Alias: "db1"})
+	require.NoError(t, err)
+
+	h, err := GetByAlias(storage, " DB1 ")
+	require.NoError(t, err)
+	require.Equal(t, "db", h.Title)
+
+	_, err = GetByAlias(storage, "db2")
+	require.ErrorIs(t, err, constant.ErrNotFound)
+
+	// Hosts without alias are never matched
+	_, err = GetByAlias(storage, "")
+	require.ErrorIs(t, err, constant.ErrNotFound)
+}
+
+func TestAliasUniqueness(t *testing.T) {
+	storage, err := NewYAML(context.TODO(), t.TempDir(), &test.MockLogger{})
+	require.NoError(t, err)
+	db, err := storage.Save(model.Host{Title: "db", Address: "10.0.0.2", Alias: "db1"})
+	require.NoError(t, err)
+
+	// Another host cannot take the alias, aliases are case-insensitive
+	duplicate := model.Host{Title: "db replica", Address: "10.0.0.3", Alias: "DB1"}
+	require.ErrorIs(t, CheckAliasUnique(storage, duplicate), ErrAliasTaken)
+	_, err = storage.Save(duplicate)
+	require.ErrorIs(t, err, ErrAliasTaken)
+	hosts, _ := storage.GetAll()
+	require.Len(t, hosts, 1)
+
+	// The host keeps its own alias when it's saved again
+	db.Address = "10.0.0.20"
+	require.NoError(t, CheckAliasUnique(storage, db))
+	_, err = storage.Save(db)
+	require.NoError(t, err)
+
+	// Empty aliases never collide
+	_, err = storage.Save(model.Host{Title: "web", Address: "10.0.0.1"})
+	require.NoError(t, err)
+	_, err = storage.Save(model.Host{Title: "cache", Address: "10.0.0.4"})
+	require.NoError(t, err)
+}
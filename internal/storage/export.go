@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+)
+
+// StatsCSVHeader - columns of the connection stats which are written by ExportStatsCSV.
+var StatsCSVHeader = []string{
+	"title",
+	"group",
+	"address",
+	"connect_count",
+	"last_connected",
+	"last_exit_code",
+	"last_error",
+}
+
+// ExportStatsCSV - writes connection stats of all hosts from the storage as CSV, hosts are sorted by title.
+// Hosts which were never connected to have zero connection count and empty time and result columns.
+func ExportStatsCSV(storage HostStorage, w io.Writer) error {
+	hosts, err := storage.GetAll()
+	if err != nil {
+		return err
+	}
+
+	// Storage may return hosts in any order, sorted output is easier to compare between exports.
+	hosts = sortedByTitle(hosts)
+	writer := csv.NewWriter(w)
+	if err = writer.Write(StatsCSVHeader); err != nil {
+		return err
+	}
+
+	for _, h := range hosts {
+		if err = writer.Write(statsCSVRecord(h)); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// sortedByTitle - returns a sorted copy of the hosts, hosts with the same title are sorted by id.
+func sortedByTitle(hosts []model.Host) []model.Host {
+	hosts = slices.Clone(hosts)
+	slices.SortFunc(hosts, func(a, b model.Host) int {
+		if c := strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)); c != 0 {
+			return c
+		}
+
+		return a.ID - b.ID
+	})
+
+	return hosts
+}
+
+func statsCSVRecord(h model.Host) []string {
+	lastExitCode := ""
+	if !h.LastConnectResult.Time.IsZero() {
+		lastExitCode = strconv.Itoa(h.LastConnectResult.ExitCode)
+	}
+
+	return []string{
+		h.Title,
+		h.Group,
+		h.Address,
+		strconv.Itoa(h.ConnectCount),
+		formatStatsTime(h.LastConnected),
+		lastExitCode,
+		h.LastConnectResult.Error,
+	}
+}
+
+func formatStatsTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v2"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+)
+
+// ExportHostsDir - writes every host from the storage into its own YAML file, which is named after the title
+// of the host, so the folder can be kept in git and changes of a single host are easy to review. Passwords
+// are removed unless withSecrets is set. Connection stats are always removed, because they change on every
+// connection. Returns names of the written files. The files can be imported back using ReadHostsDir.
+func ExportHostsDir(storage HostStorage, dirPath string, withSecrets bool) ([]string, error) {
+	hosts, err := storage.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = os.MkdirAll(dirPath, 0o700); err != nil {
+		return nil, err
+	}
+
+	// Hosts are sorted, so files of the hosts with the same title get the same names on every export.
+	hosts = sortedByTitle(hosts)
+	fileNames := hostFileNames(hosts)
+	perm := lo.Ternary[os.FileMode](withSecrets, 0o600, 0o644)
+	for i, h := range hosts {
+		data, err := yaml.Marshal(exportedHost(h, withSecrets))
+		if err != nil {
+			return nil, err
+		}
+
+		if err = os.WriteFile(filepath.Join(dirPath, fileNames[i]), data, perm); err != nil {
+			return nil, err
+		}
+	}
+
+	return fileNames, nil
+}
+
+// exportedHost - returns a copy of the host without the attributes which should not be shared.
+func exportedHost(h model.Host, withSecrets bool) model.Host {
+	if !withSecrets {
+		h.Password = ""
+	}
+
+	h.LastConnected = time.Time{}
+	h.ConnectCount = 0
+	h.FailedChecks = 0
+	h.LastConnectResult = model.ConnectResult{}
+
+	return h
+}
+
+// hostFileNames - returns unique file names for the hosts, a number is added to the name when several hosts
+// have the same slug. Ex: "web.yaml", "web-2.yaml".
+func hostFileNames(hosts []model.Host) []string {
+	taken := map[string]bool{}
+	fileNames := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		slug := slugify(h.Title)
+		name := slug
+		for n := 2; taken[name]; n++ {
+			name = fmt.Sprintf("%s-%d", slug, n)
+		}
+
+		taken[name] = true
+		fileNames = append(fileNames, name+".yaml")
+	}
+
+	return fileNames
+}
+
+// slugify - converts the title into a lowercase name which is safe to use as a file name. Characters other
+// than letters and digits are replaced with dashes. Ex: "Web Server #1" -> "web-server-1".
+func slugify(title string) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+			dash = false
+		} else if !dash && builder.Len() > 0 {
+			builder.WriteRune('-')
+			dash = true
+		}
+	}
+
+	slug := strings.TrimSuffix(builder.String(), "-")
+	if slug == "" {
+		return "host"
+	}
+
+	return slug
+}
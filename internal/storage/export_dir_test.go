@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/test"
+)
+
+func TestSlugify(t *testing.T) {
+	require.Equal(t, "web-server-1", slugify("Web Server #1"))
+	require.Equal(t, "db-primary", slugify("  db_primary  "))
+	require.Equal(t, "root-10-0-0-1", slugify("root@10.0.0.1"))
+	require.Equal(t, "prod-web", slugify("prod / web --"))
+	// Title which has no letters or digits still gets a name
+	require.Equal(t, "host", slugify(""))
+	require.Equal(t, "host", slugify("日本"))
+}
+
+func TestHostFileNames_Collisions(t *testing.T) {
+	hosts := []model.Host{
+		{Title: "Web"},
+		{Title: "web"},
+		{Title: "web!"},
+		{Title: "db"},
+	}
+
+	require.Equal(t, []string{"web.yaml", "web-2.yaml", "web-3.yaml", "db.yaml"}, hostFileNames(hosts))
+}
+
+func TestExportHostsDir(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	storage.Hosts = []model.Host{
+		{ID: 2, Title: "web", Address: "10.0.0.2", Password: "secret", PasswordCommand: "pass show web"},
+		{ID: 1, Title: "Web", Address: "10.0.0.1", ConnectCount: 5, LastConnected: time.Now()},
+		{ID: 3, Title: "Database", Address: "10.0.0.3"},
+	}
+
+	dir := filepath.Join(t.TempDir(), "hosts")
+	fileNames, err := ExportHostsDir(storage, dir, false)
+	require.NoError(t, err)
+	// Hosts with the same title are sorted by id
+	require.Equal(t, []string{"database.yaml", "web.yaml", "web-2.yaml"}, fileNames)
+
+	data, err := os.ReadFile(filepath.Join(dir, "web.yaml"))
+	require.NoError(t, err)
+	// Connection stats are not exported
+	require.Equal(t, "title: Web\naddress: 10.0.0.1\n", string(data))
+
+	// Password is removed, but password command is kept, because it doesn't contain the password itself
+	data, err = os.ReadFile(filepath.Join(dir, "web-2.yaml"))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secret")
+	require.Contains(t, string(data), "password_command: pass show web")
+	// Storage is not modified
+	require.Equal(t, "secret", storage.Hosts[0].Password)
+
+	_, err = ExportHostsDir(storage, dir, true)
+	require.NoError(t, err)
+	data, err = os.ReadFile(filepath.Join(dir, "web-2.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "password: secret")
+
+	// Exported folder can be imported back
+	hosts, err := ReadHostsDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Database", "web", "Web"}, []string{hosts[0].Title, hosts[1].Title, hosts[2].Title})
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/test"
+)
+
+func TestExportStatsCSV(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	connected := time.Date(2024, time.March, 1, 10, 30, 0, 0, time.UTC)
+	storage.Hosts = []model.Host{
+		{ID: 1, Title: "web", Group: "prod", Address: "web.example.com"},
+		{
+			ID:                3,
+			Title:             "Database",
+			Address:           "db.example.com",
+			ConnectCount:      12,
+			LastConnected:     connected,
+			LastConnectResult: model.ConnectResult{Time: connected.Add(time.Hour), ExitCode: 255, Error: "Connection refused, \"port 22\""},
+		},
+		{ID: 2, Title: "app", Address: "app.example.com", ConnectCount: 1, LastConnected: connected,
+			LastConnectResult: model.ConnectResult{Time: connected}},
+	}
+
+	buffer := bytes.Buffer{}
+	require.NoError(t, ExportStatsCSV(storage, &buffer))
+
+	// Hosts are sorted by title, hosts without stats have zero count and empty time and result columns
+	expected := "title,group,address,connect_count,last_connected,last_exit_code,last_error\n" +
+		"app,,app.example.com,1,2024-03-01T10:30:00Z,0,\n" +
+		"Database,,db.example.com,12,2024-03-01T10:30:00Z,255,\"Connection refused, \"\"port 22\"\"\"\n" +
+		"web,prod,web.example.com,0,,,\n"
+	require.Equal(t, expected, buffer.String())
+}
+
+func TestExportStatsCSV_EmptyStorage(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	storage.Hosts = nil
+
+	buffer := bytes.Buffer{}
+	require.NoError(t, ExportStatsCSV(storage, &buffer))
+	require.Equal(t, "title,group,address,connect_count,last_connected,last_exit_code,last_error\n", buffer.String())
+}
+
+func TestExportStatsCSV_StorageError(t *testing.T) {
+	buffer := bytes.Buffer{}
+	require.Error(t, ExportStatsCSV(test.NewMockStorage(true), &buffer))
+	require.Empty(t, buffer.String())
+}
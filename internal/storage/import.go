@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+)
+
+// ConflictPolicy defines what happens when an imported host has the same title as an existing one.
+type ConflictPolicy string
+
+const (
+	// ConflictOverwrite - existing host is replaced with the imported one.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	// ConflictSkip - imported host is ignored, existing host is kept as is.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictRename - imported host is saved under a new title, ex: "web (2)".
+	ConflictRename ConflictPolicy = "rename"
+)
+
+// ParseConflictPolicy - converts a policy name, for instance a command line parameter, into a policy.
+// Names are case-insensitive.
+func ParseConflictPolicy(name string) (ConflictPolicy, error) {
+	policy := ConflictPolicy(strings.ToLower(strings.TrimSpace(name)))
+	switch policy {
+	case ConflictOverwrite, ConflictSkip, ConflictRename:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown conflict policy: %q", name)
+	}
+}
+
+// ConflictResolver - returns a policy for a single colliding host, so a user can choose it per item.
+type ConflictResolver func(existing, imported model.Host) ConflictPolicy
+
+// ApplyToAll - returns a resolver which applies the same policy to all colliding hosts.
+func ApplyToAll(policy ConflictPolicy) ConflictResolver {
+	return func(_, _ model.Host) ConflictPolicy {
+		return policy
+	}
+}
+
+// ImportResult - contains number of imported hosts grouped by the way they were saved.
+type ImportResult struct {
+	Created     int
+	Overwritten int
+	Skipped     int
+	Renamed     int
+}
+
+// Import - saves hosts which are read by an importer into the storage. Titles are compared case-insensitively,
+// when a title collides with an existing host, or with a host imported earlier, resolve decides what to do.
+func Import(storage HostStorage, hosts []model.Host, resolve ConflictResolver) (ImportResult, error) {
+	result := ImportResult{}
+	existingHosts, err := storage.GetAll()
+	if err != nil {
+		return result, err
+	}
+
+	byTitle := make(map[string]model.Host, len(existingHosts))
+	for _, h := range existingHosts {
+		byTitle[titleKey(h.Title)] = h
+	}
+
+	for _, h := range hosts {
+		// Imported hosts never carry ids of the storage
+		h.ID = idEmpty
+		existing, collides := byTitle[titleKey(h.Title)]
+		if collides {
+			switch policy := resolve(existing, h); policy {
+			case ConflictSkip:
+				result.Skipped++
+				continue
+			case ConflictOverwrite:
+				h.ID = existing.ID
+				result.Overwritten++
+			case ConflictRename:
+				h.Title = uniqueTitle(h.Title, byTitle)
+				result.Renamed++
+			default:
+				return result, fmt.Errorf("unknown conflict policy: %q", policy)
+			}
+		} else {
+			result.Created++
+		}
+
+		saved, err := storage.Save(h)
+		if err != nil {
+			return result, err
+		}
+		byTitle[titleKey(saved.Title)] = saved
+	}
+
+	return result, nil
+}
+
+func titleKey(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+// uniqueTitle - appends the smallest number to the title, which makes it unique. Ex: "web" -> "web (2)".
+func uniqueTitle(title string, byTitle map[string]model.Host) string {
+	title = strings.TrimSpace(title)
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s (%d)", title, n)
+		if _, taken := byTitle[titleKey(candidate)]; !taken {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+)
+
+// ReadHostsDir - reads hosts from a folder where every .yaml or .yml file contains attributes of a single host.
+// File name without extension is used as a title when the host has no title. Malformed files are skipped,
+// they are returned as a joined error along with the hosts which were read. Sub-folders are ignored.
+func ReadHostsDir(dirPath string) ([]model.Host, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := []model.Host{}
+	var errs []error
+	for _, entry := range entries {
+		extension := filepath.Ext(entry.Name())
+		if entry.IsDir() || (extension != ".yaml" && extension != ".yml") {
+			continue
+		}
+
+		h, err := readHostFile(filepath.Join(dirPath, entry.Name()))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry.Name(), err))
+			continue
+		}
+
+		if strings.TrimSpace(h.Title) == "" {
+			h.Title = strings.TrimSuffix(entry.Name(), extension)
+		}
+		hosts = append(hosts, h)
+	}
+
+	return hosts, errors.Join(errs...)
+}
+
+// readHostFile - parses a single host. Unknown attributes are rejected, because they are most likely misspelled.
+func readHostFile(filePath string) (model.Host, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return model.Host{}, err
+	}
+
+	var h model.Host
+	if err = yaml.UnmarshalStrict(data, &h); err != nil {
+		return model.Host{}, err
+	}
+
+	if !h.HasDestination() {
+		return model.Host{}, errors.New("host address is required")
+	}
+
+	return h, nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadHostsDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"web-01.yaml":    "address: 10.0.0.1\nnetwork_port: \"2222\"\n",
+		"db.yml":         "title: Database\naddress: 10.0.0.2\n",
+		"broken.yaml":    "address: [10.0.0.3\n",
+		"misspelled.yml": "adress: 10.0.0.4\n",
+		"empty.yaml":     "",
+		"README.md":      "# Hosts\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "archive.yaml"), 0o700))
+
+	hosts, err := ReadHostsDir(dir)
+	require.Len(t, hosts, 2)
+	// Files are read in the order of their names
+	require.Equal(t, "Database", hosts[0].Title)
+	require.Equal(t, "10.0.0.2", hosts[0].Address)
+	// File name is used when title is not set
+	require.Equal(t, "web-01", hosts[1].Title)
+	require.Equal(t, "2222", hosts[1].RemotePort)
+
+	// Malformed files are reported, but they don't prevent other hosts from being read
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "broken.yaml")
+	require.Contains(t, err.Error(), "misspelled.yml")
+	require.Contains(t, err.Error(), "empty.yaml: host address is required")
+	require.NotContains(t, err.Error(), "README.md")
+
+	_, err = ReadHostsDir(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func TestReadHostsDir_Import(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "web.yaml"), []byte("address: 192.168.0.1\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cache.yaml"), []byte("address: 192.168.0.2\n"), 0o600))
+
+	hosts, err := ReadHostsDir(dir)
+	require.NoError(t, err)
+
+	storage := newImportTestStorage(t)
+	result, err := Import(storage, hosts, ApplyToAll(ConflictSkip))
+	require.NoError(t, err)
+	require.Equal(t, ImportResult{Created: 1, Skipped: 1}, result)
+	require.Equal(t, "192.168.0.2", hostsByTitle(t, storage)["cache"].Address)
+	require.Equal(t, "10.0.0.1", hostsByTitle(t, storage)["web"].Address)
+}
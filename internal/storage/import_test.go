@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/test"
+)
+
+func newImportTestStorage(t *testing.T) *yamlStorage {
+	t.Helper()
+
+	storage, err := NewYAML(context.TODO(), t.TempDir(), &test.MockLogger{})
+	require.NoError(t, err)
+	_, err = storage.Save(model.Host{Title: "web", Address: "10.0.0.1"})
+	require.NoError(t, err)
+	_, err = storage.Save(model.Host{Title: "web (2)", Address: "10.0.0.2"})
+	require.NoError(t, err)
+	_, err = storage.Save(model.Host{Title: "db", Address: "10.0.0.3"})
+	require.NoError(t, err)
+
+	return storage
+}
+
+// importTestHosts - "Web" and "db" collide with existing hosts, "cache" is a new one.
+var importTestHosts = []model.Host{
+	{ID: 7, Title: "Web", Address: "192.168.0.1"},
+	{Title: "cache", Address: "192.168.0.2"},
+	{Title: " db ", Address: "192.168.0.3"},
+}
+
+func hostsByTitle(t *testing.T, storage HostStorage) map[string]model.Host {
+	t.Helper()
+
+	hosts, err := storage.GetAll()
+	require.NoError(t, err)
+	result := make(map[string]model.Host, len(hosts))
+	for _, h := range hosts {
+		result[h.Title] = h
+	}
+
+	return result
+}
+
+func TestImport_Overwrite(t *testing.T) {
+	storage := newImportTestStorage(t)
+	result, err := Import(storage, importTestHosts, ApplyToAll(ConflictOverwrite))
+	require.NoError(t, err)
+	require.Equal(t, ImportResult{Created: 1, Overwritten: 2}, result)
+
+	hosts := hostsByTitle(t, storage)
+	require.Len(t, hosts, 4)
+	require.Equal(t, "192.168.0.1", hosts["Web"].Address)
+	require.Equal(t, "10.0.0.2", hosts["web (2)"].Address)
+	require.Equal(t, "192.168.0.3", hosts[" db "].Address)
+	require.Equal(t, "192.168.0.2", hosts["cache"].Address)
+}
+
+func TestImport_Skip(t *testing.T) {
+	storage := newImportTestStorage(t)
+	result, err := Import(storage, importTestHosts, ApplyToAll(ConflictSkip))
+	require.NoError(t, err)
+	require.Equal(t, ImportResult{Created: 1, Skipped: 2}, result)
+
+	hosts := hostsByTitle(t, storage)
+	require.Len(t, hosts, 4)
+	require.Equal(t, "10.0.0.1", hosts["web"].Address)
+	require.Equal(t, "10.0.0.3", hosts["db"].Address)
+	require.Equal(t, "192.168.0.2", hosts["cache"].Address)
+}
+
+func TestImport_Rename(t *testing.T) {
+	storage := newImportTestStorage(t)
+	result, err := Import(storage, importTestHosts, ApplyToAll(ConflictRename))
+	require.NoError(t, err)
+	require.Equal(t, ImportResult{Created: 1, Renamed: 2}, result)
+
+	hosts := hostsByTitle(t, storage)
+	require.Len(t, hosts, 6)
+	require.Equal(t, "10.0.0.1", hosts["web"].Address)
+	// "web (2)" is taken already
+	require.Equal(t, "192.168.0.1", hosts["Web (3)"].Address)
+	require.Equal(t, "192.168.0.3", hosts["db (2)"].Address)
+}
+
+func TestImport_PerItemPolicy(t *testing.T) {
+	storage := newImportTestStorage(t)
+	var asked []string
+	resolve := func(existing, imported model.Host) ConflictPolicy {
+		asked = append(asked, existing.Title)
+		if existing.Title == "web" {
+			return ConflictOverwrite
+		}
+
+		return ConflictSkip
+	}
+
+	result, err := Import(storage, importTestHosts, resolve)
+	require.NoError(t, err)
+	require.Equal(t, []string{"web", "db"}, asked)
+	require.Equal(t, ImportResult{Created: 1, Overwritten: 1, Skipped: 1}, result)
+
+	hosts := hostsByTitle(t, storage)
+	require.Equal(t, "192.168.0.1", hosts["Web"].Address)
+	require.Equal(t, "10.0.0.3", hosts["db"].Address)
+}
+
+func TestImport_CollisionWithinImportedHosts(t *testing.T) {
+	storage := newImportTestStorage(t)
+	imported := []model.Host{
+		{Title: "proxy", Address: "192.168.0.1"},
+		{Title: "proxy", Address: "192.168.0.2"},
+	}
+
+	result, err := Import(storage, imported, ApplyToAll(ConflictRename))
+	require.NoError(t, err)
+	require.Equal(t, ImportResult{Created: 1, Renamed: 1}, result)
+	require.Equal(t, "192.168.0.2", hostsByTitle(t, storage)["proxy (2)"].Address)
+}
+
+func TestImport_UnknownPolicy(t *testing.T) {
+	storage := newImportTestStorage(t)
+	_, err := Import(storage, importTestHosts, ApplyToAll("merge"))
+	require.ErrorContains(t, err, "unknown conflict policy")
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	policy, err := ParseConflictPolicy(" Overwrite ")
+	require.NoError(t, err)
+	require.Equal(t, ConflictOverwrite, policy)
+
+	_, err = ParseConflictPolicy("merge")
+	require.ErrorContains(t, err, "unknown conflict policy")
+}
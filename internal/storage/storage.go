@@ -14,6 +14,17 @@ type HostStorage interface {
 	Get(hostID int) (model.Host, error)
 	Save(model.Host) (model.Host, error)
 	Delete(id int) error
+	FindByAddress(address, login string) ([]model.Host, error)
+}
+
+// FileStorage is implemented by storages which keep hosts in a file which can be edited manually.
+type FileStorage interface {
+	FilePath() string
+}
+
+// WatchableStorage is implemented by storages which notify about changes made by other applications.
+type WatchableStorage interface {
+	Watch(ctx context.Context) (<-chan struct{}, error)
 }
 
 // Get returns new data service.
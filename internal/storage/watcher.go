@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounceTime is a quiet period after the last file event. Text editors usually
+// produce several events when saving a file, only a single notification is sent for them.
+var watchDebounceTime = time.Millisecond * 500
+
+// Watch - watches hosts file and sends a notification to the returned channel, when the file
+// is changed by another application. The channel is closed when the context is cancelled.
+func (s *yamlStorage) Watch(ctx context.Context) (<-chan struct{}, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	// Watch the folder instead of the file, because many text editors
+	// replace the original file with a new one instead of updating it.
+	if err = watcher.Add(filepath.Dir(s.fsDataPath)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	s.logger.Debug("[STORAGE] Watch file changes: %s", s.fsDataPath)
+	fileEvents := make(chan struct{})
+	changes := make(chan struct{})
+
+	go func() {
+		defer watcher.Close()
+		defer close(fileEvents)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(event.Name) != filepath.Clean(s.fsDataPath) || event.Has(fsnotify.Chmod) {
+					continue
+				}
+
+				select {
+				case fileEvents <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				s.logger.Error("[STORAGE] File watcher error. %v", err)
+			}
+		}
+	}()
+
+	go func() {
+		defer close(changes)
+
+		for range debounce(ctx, fileEvents, watchDebounceTime) {
+			if !s.isExternalChange() {
+				s.logger.Debug("[STORAGE] Ignore hosts file change made by the application")
+				continue
+			}
+
+			s.logger.Info("[STORAGE] Hosts file was changed by another application")
+			select {
+			case changes <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// debounce - sends a single value to the output channel when there are no new values
+// in the input channel during the delay period. Output channel is closed when input channel is closed
+// or the context is cancelled.
+func debounce(ctx context.Context, in <-chan struct{}, delay time.Duration) <-chan struct{} {
+	out := make(chan struct{})
+
+	go func() {
+		defer close(out)
+
+		var timer <-chan time.Time
+		for {
+			select {
+			case _, ok := <-in:
+				if !ok {
+					return
+				}
+
+				timer = time.After(delay)
+			case <-timer:
+				timer = nil
+				select {
+				case out <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
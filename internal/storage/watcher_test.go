@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/test"
+)
+
+func Test_debounce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	in := make(chan struct{})
+	out := debounce(ctx, in, time.Millisecond*50)
+
+	// A burst of events should produce a single notification
+	for i := 0; i < 5; i++ {
+		in <- struct{}{}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	select {
+	case <-out:
+	case <-time.After(time.Second):
+		t.Fatal("debounced notification was not sent")
+	}
+
+	select {
+	case <-out:
+		t.Fatal("only one notification should be sent for a burst of events")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	// Output channel is closed when input channel is closed
+	close(in)
+	_, ok := <-out
+	require.False(t, ok)
+}
+
+func Test_isExternalChange(t *testing.T) {
+	storage, err := NewYAML(context.TODO(), t.TempDir(), &test.MockLogger{})
+	require.NoError(t, err)
+
+	// File doesn't exist
+	require.False(t, storage.isExternalChange())
+
+	// Changes which are made by the application are ignored
+	_, err = storage.Save(model.Host{Title: "test", Address: "localhost"})
+	require.NoError(t, err)
+	require.False(t, storage.isExternalChange())
+
+	// Changes which are made by other applications are detected
+	require.NoError(t, os.WriteFile(storage.FilePath(), []byte("- host:\n    title: changed\n"), 0o600))
+	require.True(t, storage.isExternalChange())
+
+	// Once the file is re-read, its content is not considered as a change anymore
+	_, err = storage.GetAll()
+	require.NoError(t, err)
+	require.False(t, storage.isExternalChange())
+}
+
+func Test_Watch(t *testing.T) {
+	defaultDebounceTime := watchDebounceTime
+	defer func() { watchDebounceTime = defaultDebounceTime }()
+	watchDebounceTime = time.Millisecond * 50
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	appFolder := t.TempDir()
+	storage, err := NewYAML(ctx, appFolder, &test.MockLogger{})
+	require.NoError(t, err)
+	changes, err := storage.Watch(ctx)
+	require.NoError(t, err)
+
+	// Self-write is not reported
+	_, err = storage.Save(model.Host{Title: "test", Address: "localhost"})
+	require.NoError(t, err)
+	select {
+	case <-changes:
+		t.Fatal("changes made by the application should not be reported")
+	case <-time.After(time.Millisecond * 200):
+	}
+
+	// Other files in the same folder are ignored
+	require.NoError(t, os.WriteFile(path.Join(appFolder, "state.yaml"), []byte("selected: 1"), 0o600))
+	// External change is reported
+	require.NoError(t, os.WriteFile(storage.FilePath(), []byte("- host:\n    title: changed\n"), 0o600))
+	select {
+	case <-changes:
+	case <-time.After(time.Second * 2):
+		t.Fatal("external change was not reported")
+	}
+
+	// Channel is closed when context is cancelled
+	cancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-changes
+		return !ok
+	}, time.Second, time.Millisecond*10)
+}
@@ -1,12 +1,12 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
 	"path"
-
-	"golang.org/x/exp/slices"
+	"sync"
 
 	"github.com/samber/lo"
 	"gopkg.in/yaml.v2"
@@ -45,31 +45,43 @@ func NewYAML(ctx context.Context, appFolder string, logger iLogger) (*yamlStorag
 
 type yamlStorage struct {
 	innerStorage map[int]yamlHostWrapper
-	nextID       int
-	fsDataPath   string
-	logger       iLogger
+	// order contains host IDs in the same order as hosts are stored in the file, so that the order
+	// which is chosen by the user is not changed when the file is written.
+	order      []int
+	nextID     int
+	fsDataPath string
+	logger     iLogger
+	// lastWritten is the file content which was written or read by the application. It's used
+	// to distinguish changes made by the application from changes made by other programs.
+	lastWritten   []byte
+	lastWrittenMu sync.Mutex
 }
 
+// yamlHostWrapper - ID is stored next to the host, instead of deriving it from the position of the host
+// in the file. Thereby, IDs which are referenced by the application state do not change when hosts are
+// reordered or removed from the file by the user.
 type yamlHostWrapper struct {
+	ID   int        `yaml:"id,omitempty"`
 	Host model.Host `yaml:"host"`
 }
 
 func (s *yamlStorage) flushToDisk() error {
-	// map contains values in shuffled order
-	mapValues := lo.Values(s.innerStorage)
-	// sorting slice by index
-	slices.SortFunc(mapValues, func(a, b yamlHostWrapper) int {
-		if a.Host.ID < b.Host.ID {
-			return -1
-		}
-		return 1
+	values := lo.FilterMap(s.order, func(id int, _ int) (yamlHostWrapper, bool) {
+		wrapped, ok := s.innerStorage[id]
+		wrapped.ID = id
+
+		return wrapped, ok
 	})
 
-	result, err := yaml.Marshal(mapValues)
+	result, err := yaml.Marshal(values)
 	if err != nil {
 		return err
 	}
 
+	s.lastWrittenMu.Lock()
+	s.lastWritten = result
+	s.lastWrittenMu.Unlock()
+
 	err = os.WriteFile(s.fsDataPath, result, 0o600)
 	if err != nil {
 		panic(err)
@@ -78,7 +90,20 @@ func (s *yamlStorage) flushToDisk() error {
 	return nil
 }
 
+// FilePath returns path to the hosts file.
+func (s *yamlStorage) FilePath() string {
+	return s.fsDataPath
+}
+
 func (s *yamlStorage) Save(host model.Host) (model.Host, error) {
+	existingHosts := lo.MapToSlice(s.innerStorage, func(_ int, value yamlHostWrapper) model.Host {
+		return value.Host
+	})
+	if err := checkAliasUnique(existingHosts, host); err != nil {
+		s.logger.Error("[STORAGE] Cannot save host with title: %s. Alias '%s' is taken", host.Title, host.Alias)
+		return host, err
+	}
+
 	if host.ID == idEmpty {
 		s.logger.Debug("[STORAGE] Generate new id for new host with title: %s", host.Title)
 		s.nextID++
@@ -86,7 +111,10 @@ func (s *yamlStorage) Save(host model.Host) (model.Host, error) {
 	}
 
 	s.logger.Info("[STORAGE] Save host with id: %d, title: %s", host.ID, host.Title)
-	s.innerStorage[host.ID] = yamlHostWrapper{host}
+	if _, ok := s.innerStorage[host.ID]; !ok {
+		s.order = append(s.order, host.ID)
+	}
+	s.innerStorage[host.ID] = yamlHostWrapper{ID: host.ID, Host: host}
 
 	err := s.flushToDisk()
 	if err != nil {
@@ -99,6 +127,7 @@ func (s *yamlStorage) Save(host model.Host) (model.Host, error) {
 func (s *yamlStorage) Delete(hostID int) error {
 	s.logger.Info("[STORAGE] Delete host with id: %d", hostID)
 	delete(s.innerStorage, hostID)
+	s.order = lo.Without(s.order, hostID)
 
 	err := s.flushToDisk()
 	if err != nil {
@@ -108,14 +137,14 @@ func (s *yamlStorage) Delete(hostID int) error {
 }
 
 func (s *yamlStorage) GetAll() ([]model.Host, error) {
-	// re-create innerStorage before reading file data
-	s.innerStorage = make(map[int]yamlHostWrapper)
 	s.logger.Debug("[STORAGE] Read hosts from file: %s\n", s.fsDataPath)
 	fileData, err := os.ReadFile(s.fsDataPath)
 	if err != nil {
 		var pathErr *os.PathError
 		if errors.As(err, &pathErr) {
 			s.logger.Info("[STORAGE] Path no found: %s. Assuming it's not created yet", s.fsDataPath)
+			s.innerStorage = make(map[int]yamlHostWrapper)
+			s.order = nil
 
 			return make([]model.Host, 0), nil
 		}
@@ -128,17 +157,42 @@ func (s *yamlStorage) GetAll() ([]model.Host, error) {
 	s.logger.Debug("[STORAGE] Unmarshal hosts data from yaml storage")
 	err = yaml.Unmarshal(fileData, &yamlHosts)
 	if err != nil {
+		// Hosts file may be broken after manual editing. Keep previously loaded hosts intact.
 		s.logger.Error("[STORAGE] Could not unmarshal hosts data. %v", err)
 		return nil, err
 	}
 
-	s.nextID = idEmpty
+	s.lastWrittenMu.Lock()
+	s.lastWritten = fileData
+	s.lastWrittenMu.Unlock()
+
+	// re-create innerStorage only when file data is read successfully
+	s.innerStorage = make(map[int]yamlHostWrapper)
+	s.order = make([]int, 0, len(yamlHosts))
+	s.nextID = lo.Max(lo.Map(yamlHosts, func(wrapped yamlHostWrapper, _ int) int { return wrapped.ID }))
+	idsAssigned := false
 	for _, wrapped := range yamlHosts {
-		s.nextID++
-		wrapped.Host.ID = s.nextID
+		_, duplicate := s.innerStorage[wrapped.ID]
+		if wrapped.ID <= idEmpty || duplicate {
+			// Hosts which were added before IDs were persisted, or copied by the user along with the ID.
+			s.nextID++
+			s.logger.Debug("[STORAGE] Assign id %d to host with title: %s", s.nextID, wrapped.Host.Title)
+			wrapped.ID = s.nextID
+			idsAssigned = true
+		}
+		wrapped.Host.ID = wrapped.ID
 
 		// Maintain an internal map which is keyed by int
-		s.innerStorage[s.nextID] = wrapped
+		s.innerStorage[wrapped.ID] = wrapped
+		s.order = append(s.order, wrapped.ID)
+	}
+
+	// Persist assigned IDs immediately, otherwise they would change if user reorders the file before
+	// any host is saved.
+	if idsAssigned {
+		if err = s.flushToDisk(); err != nil {
+			s.logger.Error("[STORAGE] Cannot persist host ids. %v", err)
+		}
 	}
 
 	hosts := lo.MapToSlice(s.innerStorage, func(key int, value yamlHostWrapper) model.Host {
@@ -149,6 +203,20 @@ func (s *yamlStorage) GetAll() ([]model.Host, error) {
 	return hosts, nil
 }
 
+// isExternalChange - returns true if hosts file content differs from the content which was written by the application.
+func (s *yamlStorage) isExternalChange() bool {
+	fileData, err := os.ReadFile(s.fsDataPath)
+	if err != nil {
+		// File was removed or renamed. There is nothing to reload.
+		return false
+	}
+
+	s.lastWrittenMu.Lock()
+	defer s.lastWrittenMu.Unlock()
+
+	return !bytes.Equal(fileData, s.lastWritten)
+}
+
 func (s *yamlStorage) Get(hostID int) (model.Host, error) {
 	s.logger.Debug("[STORAGE] Read host with id %d from the database", hostID)
 	found, ok := s.innerStorage[hostID]
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	model "github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/test"
+)
+
+func hostIDsByTitle(t *testing.T, storage *yamlStorage) map[string]int {
+	t.Helper()
+
+	hosts, err := storage.GetAll()
+	require.NoError(t, err)
+
+	return lo.SliceToMap(hosts, func(h model.Host) (string, int) { return h.Title, h.ID })
+}
+
+func TestYAMLStorage_ReorderDoesNotChangeIDs(t *testing.T) {
+	appFolder := t.TempDir()
+	storage, err := NewYAML(context.TODO(), appFolder, &test.MockLogger{})
+	require.NoError(t, err)
+
+	for _, title := range []string{"first", "second", "third"} {
+		_, err = storage.Save(model.Host{Title: title, Address: "localhost"})
+		require.NoError(t, err)
+	}
+
+	// Remove the middle host, so that IDs do not match positions anymore
+	ids := hostIDsByTitle(t, storage)
+	require.NoError(t, storage.Delete(ids["second"]))
+	ids = hostIDsByTitle(t, storage)
+	require.Equal(t, map[string]int{"first": 1, "third": 3}, ids)
+
+	// Reorder hosts in the file as a user would do it in a text editor
+	fileData, err := os.ReadFile(storage.FilePath())
+	require.NoError(t, err)
+	var wrapped []yamlHostWrapper
+	require.NoError(t, yaml.Unmarshal(fileData, &wrapped))
+	fileData, err = yaml.Marshal(lo.Reverse(wrapped))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(storage.FilePath(), fileData, 0o600))
+
+	// Neither a new instance of the storage, nor the running one change IDs
+	reopened, err := NewYAML(context.TODO(), appFolder, &test.MockLogger{})
+	require.NoError(t, err)
+	require.Equal(t, ids, hostIDsByTitle(t, reopened))
+	require.Equal(t, ids, hostIDsByTitle(t, storage))
+
+	// New host doesn't reuse an ID of the removed host and the order chosen by the user is preserved
+	saved, err := reopened.Save(model.Host{Title: "fourth", Address: "localhost"})
+	require.NoError(t, err)
+	require.Equal(t, 4, saved.ID)
+
+	fileData, err = os.ReadFile(reopened.FilePath())
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(fileData, &wrapped))
+	require.Equal(t, []int{3, 1, 4}, lo.Map(wrapped, func(w yamlHostWrapper, _ int) int { return w.ID }))
+}
+
+func TestYAMLStorage_AssignsMissingAndDuplicateIDs(t *testing.T) {
+	appFolder := t.TempDir()
+	storage, err := NewYAML(context.TODO(), appFolder, &test.MockLogger{})
+	require.NoError(t, err)
+
+	// A file which was created before IDs were persisted, one of the hosts was copied along with its ID
+	fileData := []byte(`- host:
+    title: legacy
+    address: localhost
+- id: 5
+  host:
+    title: original
+    address: localhost
+- id: 5
+  host:
+    title: copy
+    address: localhost
+`)
+	require.NoError(t, os.WriteFile(storage.FilePath(), fileData, 0o600))
+
+	ids := hostIDsByTitle(t, storage)
+	require.Equal(t, map[string]int{"legacy": 6, "original": 5, "copy": 7}, ids)
+
+	// Assigned IDs are persisted, so they survive reordering of the file
+	var wrapped []yamlHostWrapper
+	fileData, err = os.ReadFile(storage.FilePath())
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(fileData, &wrapped))
+	fileData, err = yaml.Marshal(lo.Reverse(wrapped))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(storage.FilePath(), fileData, 0o600))
+
+	require.Equal(t, ids, hostIDsByTitle(t, storage))
+}
@@ -2,6 +2,7 @@ package test
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/samber/lo"
 
@@ -29,6 +30,13 @@ func NewMockStorage(shouldFail bool) *mockStorage {
 type mockStorage struct {
 	shouldFail bool
 	Hosts      []host.Host
+	// StorageFile is returned by FilePath method.
+	StorageFile string
+}
+
+// FilePath implements storage.FileStorage.
+func (ms *mockStorage) FilePath() string {
+	return ms.StorageFile
 }
 
 // Delete implements storage.HostStorage.
@@ -50,6 +58,18 @@ func (ms *mockStorage) Delete(id int) error {
 	return nil
 }
 
+// FindByAddress implements storage.HostStorage.
+func (ms *mockStorage) FindByAddress(address, login string) ([]host.Host, error) {
+	if ms.shouldFail {
+		return nil, errors.New("mock error")
+	}
+
+	return lo.Filter(ms.Hosts, func(h host.Host, _ int) bool {
+		return strings.EqualFold(strings.TrimSpace(h.Address), strings.TrimSpace(address)) &&
+			strings.TrimSpace(h.LoginName) == strings.TrimSpace(login)
+	}), nil
+}
+
 // Get implements storage.HostStorage.
 func (ms *mockStorage) Get(hostID int) (host.Host, error) {
 	if ms.shouldFail {
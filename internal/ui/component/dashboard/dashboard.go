@@ -0,0 +1,374 @@
+// Package dashboard implements a screen which displays a summary of all hosts.
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+
+	"github.com/grafviktor/goto/internal/i18n"
+	"github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/reachability"
+	"github.com/grafviktor/goto/internal/storage"
+	"github.com/grafviktor/goto/internal/ui/theme"
+)
+
+const (
+	recentlyUsedLimit     = 5
+	failedConnectionLimit = 20
+)
+
+var (
+	docStyle    = lipgloss.NewStyle().Margin(1, 2)
+	headerStyle = lipgloss.NewStyle().Bold(true)
+	// Styles below use colors of the theme, see setStyles.
+	titleStyle lipgloss.Style
+	hintStyle  lipgloss.Style
+)
+
+// setStyles - builds styles which use colors of the palette. Theme is selected after the package is
+// initialized, that's why styles are built when the dashboard is created.
+func setStyles(palette theme.Palette) {
+	titleStyle = lipgloss.NewStyle().
+		Background(palette.TitleBackground).
+		Foreground(palette.TitleForeground).
+		Padding(0, 1)
+	hintStyle = lipgloss.NewStyle().Foreground(palette.Muted)
+}
+
+type iLogger interface {
+	Debug(format string, args ...any)
+	Info(format string, args ...any)
+	Error(format string, args ...any)
+}
+
+type iScanner interface {
+	Scan(ctx context.Context, hosts []host.Host) []reachability.Status
+}
+
+type (
+	// CloseDashboard fires when user closes the dashboard.
+	CloseDashboard struct{}
+	// HostsPruned fires when dead hosts were deleted or archived, host list should be reloaded.
+	HostsPruned struct{}
+	// FailedChecksChanged fires when reachability scan changed counters of failed checks, host list should be
+	// reloaded, otherwise the counters are overwritten when a host is saved from the list.
+	FailedChecksChanged struct{}
+	msgScanCompleted    struct{ result []reachability.Status }
+)
+
+type keyMap struct {
+	scan         key.Binding
+	toggleFailed key.Binding
+	prune        key.Binding
+	pruneDelete  key.Binding
+	pruneArchive key.Binding
+	close        key.Binding
+}
+
+type dashboardModel struct {
+	appContext context.Context
+	repo       storage.HostStorage
+	scanner    iScanner
+	logger     iLogger
+	keyMap     keyMap
+	summary    Summary
+	hosts      []host.Host
+	failed     []host.Host
+	// showFailed switches the dashboard to the list of recently failed connections.
+	showFailed bool
+	err        error
+	// lastScan is preserved between openings of the dashboard.
+	lastScan     []reachability.Status
+	lastScanTime time.Time
+	scanning     bool
+	// pruneThreshold is a number of consecutive failed checks after which a host is offered for pruning.
+	pruneThreshold int
+	// pruning is set when user is asked to delete or archive pruneCandidates.
+	pruning         bool
+	pruneCandidates []host.Host
+}
+
+// New - creates dashboard model.
+// ctx - application context, cancels reachability scan when the application exits.
+// storage - is the data layer.
+// pruneThreshold - number of consecutive failed checks after which a host is considered dead.
+// log - application logger.
+func New(ctx context.Context, storage storage.HostStorage, pruneThreshold int, log iLogger) *dashboardModel {
+	setStyles(theme.Current())
+	if pruneThreshold < 1 {
+		pruneThreshold = defaultPruneThreshold
+	}
+
+	return &dashboardModel{
+		appContext:     ctx,
+		repo:           storage,
+		scanner:        reachability.New(),
+		logger:         log,
+		pruneThreshold: pruneThreshold,
+		keyMap: keyMap{
+			scan: key.NewBinding(
+				key.WithKeys("r"),
+				key.WithHelp("r", i18n.T("check reachability")),
+			),
+			toggleFailed: key.NewBinding(
+				key.WithKeys("f"),
+				key.WithHelp("f", i18n.T("failed connections")),
+			),
+			prune: key.NewBinding(
+				key.WithKeys("p"),
+				key.WithHelp("p", i18n.T("prune dead hosts")),
+			),
+			pruneDelete: key.NewBinding(
+				key.WithKeys("d"),
+				key.WithHelp("d", i18n.T("delete")),
+			),
+			pruneArchive: key.NewBinding(
+				key.WithKeys("a"),
+				key.WithHelp("a", i18n.T("archive")),
+			),
+			close: key.NewBinding(
+				key.WithKeys("esc", "q"),
+				key.WithHelp("esc", i18n.T("close")),
+			),
+		},
+	}
+}
+
+// Init - re-reads hosts from the storage and rebuilds the summary. Called every time the dashboard is opened.
+func (m *dashboardModel) Init() tea.Cmd {
+	m.logger.Debug("[UI] Build hosts summary")
+	m.refreshSummary()
+
+	return nil
+}
+
+func (m *dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.pruning {
+			return m, m.handleKeyEventWhenPruning(msg)
+		}
+
+		switch {
+		case key.Matches(msg, m.keyMap.close):
+			m.logger.Debug("[UI] Close dashboard")
+			return m, func() tea.Msg { return CloseDashboard{} }
+		case key.Matches(msg, m.keyMap.scan):
+			return m, m.scan()
+		case key.Matches(msg, m.keyMap.toggleFailed):
+			m.showFailed = !m.showFailed
+			m.logger.Debug("[UI] Display failed connections: %v", m.showFailed)
+		case key.Matches(msg, m.keyMap.prune):
+			m.pruning = true
+			m.pruneCandidates = PruneCandidates(m.hosts, m.pruneThreshold)
+			m.logger.Debug("[UI] Found %d hosts to prune", len(m.pruneCandidates))
+		}
+	case msgScanCompleted:
+		m.logger.Debug("[UI] Reachability scan completed. Checked %d hosts", len(msg.result))
+		m.scanning = false
+		m.lastScan = msg.result
+		m.lastScanTime = time.Now()
+		changed := m.trackFailedChecks(msg.result)
+		m.refreshSummary()
+		if changed {
+			return m, func() tea.Msg { return FailedChecksChanged{} }
+		}
+	}
+
+	return m, nil
+}
+
+func (m *dashboardModel) handleKeyEventWhenPruning(msg tea.KeyMsg) tea.Cmd {
+	var pruned bool
+	switch {
+	case key.Matches(msg, m.keyMap.close):
+		m.logger.Debug("[UI] Cancel pruning")
+	case key.Matches(msg, m.keyMap.pruneDelete), key.Matches(msg, m.keyMap.pruneArchive):
+		pruned = len(m.pruneCandidates) > 0
+		m.prune(key.Matches(msg, m.keyMap.pruneArchive))
+	default:
+		return nil
+	}
+
+	m.pruning = false
+	m.pruneCandidates = nil
+	if !pruned {
+		return nil
+	}
+
+	m.refreshSummary()
+	return func() tea.Msg { return HostsPruned{} }
+}
+
+func (m *dashboardModel) refreshSummary() {
+	m.hosts, m.err = m.repo.GetAll()
+	if m.err != nil {
+		m.logger.Error("[UI] Cannot read database. %v", m.err)
+	}
+
+	m.summary = Aggregate(m.hosts, m.lastScan, recentlyUsedLimit)
+	m.failed = FailedConnections(m.hosts, failedConnectionLimit)
+}
+
+func (m *dashboardModel) scan() tea.Cmd {
+	if m.scanning {
+		return nil
+	}
+
+	hosts, err := m.repo.GetAll()
+	if err != nil {
+		m.logger.Error("[UI] Cannot read database. %v", err)
+		m.err = err
+		return nil
+	}
+
+	m.logger.Info("[UI] Check reachability of %d hosts", len(hosts))
+	m.scanning = true
+	return func() tea.Msg {
+		return msgScanCompleted{result: m.scanner.Scan(m.appContext, hosts)}
+	}
+}
+
+func (m *dashboardModel) View() string {
+	if m.pruning {
+		return m.pruneView()
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(titleStyle.Render(lo.Ternary(m.showFailed, i18n.T("failed connections"), i18n.T("summary"))))
+	sb.WriteString("\n\n")
+
+	if m.err != nil {
+		sb.WriteString(fmt.Sprintf("%s\n\n", m.err.Error()))
+	}
+
+	if m.showFailed {
+		sb.WriteString(m.failedView())
+	} else {
+		sb.WriteString(m.summaryView())
+	}
+
+	sb.WriteString(hintStyle.Render(fmt.Sprintf("%s %s • %s %s • %s %s • %s %s",
+		m.keyMap.scan.Help().Key, m.keyMap.scan.Help().Desc,
+		m.keyMap.toggleFailed.Help().Key, m.keyMap.toggleFailed.Help().Desc,
+		m.keyMap.prune.Help().Key, m.keyMap.prune.Help().Desc,
+		m.keyMap.close.Help().Key, m.keyMap.close.Help().Desc,
+	)))
+
+	return docStyle.Render(sb.String())
+}
+
+// pruneView - lists hosts which failed too many consecutive reachability checks.
+func (m *dashboardModel) pruneView() string {
+	sb := strings.Builder{}
+	sb.WriteString(titleStyle.Render(i18n.T("prune dead hosts")))
+	sb.WriteString("\n\n")
+
+	if len(m.pruneCandidates) == 0 {
+		sb.WriteString("  " + i18n.Tf("none of the hosts failed %d checks in a row", m.pruneThreshold) + "\n\n")
+		sb.WriteString(hintStyle.Render(fmt.Sprintf("%s %s", m.keyMap.close.Help().Key, m.keyMap.close.Help().Desc)))
+
+		return docStyle.Render(sb.String())
+	}
+
+	for _, h := range m.pruneCandidates {
+		sb.WriteString(fmt.Sprintf("  %-24s %s\n", h.Title, i18n.Tf("failed %d checks in a row", h.FailedChecks)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(hintStyle.Render(fmt.Sprintf("%s %s • %s %s • %s %s",
+		m.keyMap.pruneDelete.Help().Key, m.keyMap.pruneDelete.Help().Desc,
+		m.keyMap.pruneArchive.Help().Key, i18n.Tf("archive to \"%s\" group", archivedGroup),
+		m.keyMap.close.Help().Key, i18n.T("cancel"),
+	)))
+
+	return docStyle.Render(sb.String())
+}
+
+// failedView - lists hosts which last connection failed with exit code and error text.
+func (m *dashboardModel) failedView() string {
+	sb := strings.Builder{}
+	if len(m.failed) == 0 {
+		sb.WriteString("  " + i18n.T("none") + "\n")
+	}
+
+	for _, h := range m.failed {
+		result := h.LastConnectResult
+		sb.WriteString(fmt.Sprintf("  %-24s %-14s %s\n",
+			h.Title,
+			i18n.Tf("exit code %d", result.ExitCode),
+			humanizeSince(result.Time, time.Now()),
+		))
+		// Error output may consist of several lines, the last one usually describes the reason.
+		if reason := lastLine(result.Error); reason != "" {
+			sb.WriteString(fmt.Sprintf("    %s\n", hintStyle.Render(reason)))
+		}
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+func (m *dashboardModel) summaryView() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%s %d\n\n", headerStyle.Render(i18n.T("Hosts:")), m.summary.Total))
+
+	sb.WriteString(headerStyle.Render(i18n.T("Groups:")))
+	sb.WriteString("\n")
+	for _, group := range m.summary.Groups {
+		// Hosts without a group are counted under a fixed title, which is translated only when displayed.
+		name := lo.Ternary(group.Name == ungroupedTitle, i18n.T(ungroupedTitle), group.Name)
+		sb.WriteString(fmt.Sprintf("  %-24s %d\n", name, group.Count))
+	}
+	sb.WriteString("\n")
+
+	// Most users don't tag hosts with cloud providers, the section is displayed only when there are any.
+	if len(m.summary.Providers) > 0 {
+		sb.WriteString(headerStyle.Render(i18n.T("Providers:")))
+		sb.WriteString("\n")
+		for _, provider := range m.summary.Providers {
+			name := strings.TrimSpace(host.ProviderIcon(provider.Name) + " " + provider.Name)
+			sb.WriteString(fmt.Sprintf("  %-24s %d\n", name, provider.Count))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(headerStyle.Render(i18n.T("Reachable:") + " "))
+	switch {
+	case m.scanning:
+		sb.WriteString(i18n.T("checking..."))
+	case m.lastScanTime.IsZero():
+		sb.WriteString(i18n.T("not checked yet"))
+	default:
+		sb.WriteString(i18n.Tf("%d of %d (checked %s)",
+			m.summary.Reachable,
+			m.summary.Scanned,
+			humanizeSince(m.lastScanTime, time.Now()),
+		))
+	}
+	sb.WriteString("\n\n")
+
+	sb.WriteString(headerStyle.Render(i18n.T("Recently used:")))
+	sb.WriteString("\n")
+	if len(m.summary.RecentlyUsed) == 0 {
+		sb.WriteString("  " + i18n.T("none") + "\n")
+	}
+	for _, h := range m.summary.RecentlyUsed {
+		sb.WriteString(fmt.Sprintf("  %-24s %s\n", h.Title, humanizeSince(h.LastConnected, time.Now())))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
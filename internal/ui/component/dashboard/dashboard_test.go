@@ -0,0 +1,124 @@
+package dashboard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafviktor/goto/internal/i18n"
+	"github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/reachability"
+	"github.com/grafviktor/goto/internal/test"
+)
+
+type mockScanner struct {
+	calls int
+}
+
+func (s *mockScanner) Scan(_ context.Context, hosts []host.Host) []reachability.Status {
+	s.calls++
+	return []reachability.Status{{Host: hosts[0], Reachable: true}, {Host: hosts[1]}}
+}
+
+func TestDashboard_Init(t *testing.T) {
+	model := New(context.TODO(), test.NewMockStorage(false), 0, &test.MockLogger{})
+	require.Nil(t, model.Init())
+	require.Equal(t, 3, model.summary.Total)
+	require.NoError(t, model.err)
+	require.Contains(t, model.View(), "not checked yet")
+
+	// Storage error is displayed
+	model = New(context.TODO(), test.NewMockStorage(true), 0, &test.MockLogger{})
+	model.Init()
+	require.Error(t, model.err)
+	require.Contains(t, model.View(), "mock error")
+}
+
+func TestDashboard_Scan(t *testing.T) {
+	scanner := &mockScanner{}
+	model := New(context.TODO(), test.NewMockStorage(false), 0, &test.MockLogger{})
+	model.scanner = scanner
+	model.Init()
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	require.True(t, model.scanning)
+	require.Contains(t, model.View(), "checking...")
+
+	// Second scan is not started while the first one is in progress
+	_, secondCmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	require.Nil(t, secondCmd)
+
+	model.Update(cmd())
+	require.Equal(t, 1, scanner.calls)
+	require.False(t, model.scanning)
+	require.Equal(t, 2, model.summary.Scanned)
+	require.Equal(t, 1, model.summary.Reachable)
+	require.Contains(t, model.View(), "1 of 2")
+}
+
+func TestDashboard_ToggleFailedConnections(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	storage.Hosts[1].LastConnectResult = host.ConnectResult{
+		Time:     time.Now(),
+		ExitCode: 255,
+		Error:    "Command: ssh localhost\nError:   ssh: connect to host localhost port 2222: Connection refused",
+	}
+	model := New(context.TODO(), storage, 0, &test.MockLogger{})
+	model.Init()
+	require.NotContains(t, model.View(), "Mock Host 2")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	require.True(t, model.showFailed)
+	view := model.View()
+	require.Contains(t, view, "failed connections")
+	require.Contains(t, view, "Mock Host 2")
+	require.Contains(t, view, "exit code 255")
+	require.Contains(t, view, "Connection refused")
+	require.NotContains(t, view, "Mock Host 1")
+
+	// Press 'f' again to go back to the summary
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	require.False(t, model.showFailed)
+	require.Contains(t, model.View(), "Recently used:")
+}
+
+func TestDashboard_Providers(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	model := New(context.TODO(), storage, 0, &test.MockLogger{})
+	model.Init()
+	require.NotContains(t, model.View(), "Providers:")
+
+	storage.Hosts[0].Provider = host.ProviderAWS
+	storage.Hosts[1].Provider = host.ProviderAWS
+	storage.Hosts[2].Provider = "hetzner"
+	model.Init()
+	view := model.View()
+	require.Contains(t, view, "Providers:")
+	require.Regexp(t, `🟧 aws\s+2`, view)
+	require.Regexp(t, `  hetzner\s+1`, view)
+}
+
+func TestDashboard_Close(t *testing.T) {
+	model := New(context.TODO(), test.NewMockStorage(false), 0, &test.MockLogger{})
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Equal(t, CloseDashboard{}, cmd())
+}
+
+func TestDashboard_Translated(t *testing.T) {
+	i18n.SetLanguage("de")
+	t.Cleanup(func() { i18n.SetLanguage(i18n.DefaultLanguage) })
+
+	model := New(context.TODO(), test.NewMockStorage(false), 0, &test.MockLogger{})
+	model.Init()
+	view := model.View()
+	require.Contains(t, view, "Übersicht")
+	require.Contains(t, view, "noch nicht geprüft")
+	require.Contains(t, view, "ohne Gruppe")
+	require.Contains(t, view, "Erreichbarkeit prüfen")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	require.Contains(t, model.View(), "keiner der Hosts ist 3 Prüfungen in Folge fehlgeschlagen")
+}
@@ -0,0 +1,103 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/reachability"
+)
+
+const (
+	// defaultPruneThreshold is used when the threshold is not set in the user config.
+	defaultPruneThreshold = 3
+	// archivedGroup - pruned hosts are moved to this group instead of being deleted if user chooses so.
+	archivedGroup = "archived"
+)
+
+// NextFailedChecks - returns number of consecutive failed checks of the host after the scan. A successful check
+// resets the counter. A check which couldn't be performed, for instance because the scan was canceled or
+// the address is unknown, doesn't say anything about the host, in that case the counter is not changed.
+func NextFailedChecks(current int, status reachability.Status) int {
+	switch {
+	case status.Reachable:
+		return 0
+	case errors.Is(status.Err, reachability.ErrUnknownTarget), errors.Is(status.Err, context.Canceled):
+		return current
+	default:
+		return current + 1
+	}
+}
+
+// PruneCandidates - returns hosts which failed at least threshold consecutive checks, hosts with
+// more failures go first. Archived hosts are not offered again.
+func PruneCandidates(hosts []host.Host, threshold int) []host.Host {
+	candidates := lo.Filter(hosts, func(h host.Host, _ int) bool {
+		return h.FailedChecks >= threshold && !strings.EqualFold(strings.TrimSpace(h.Group), archivedGroup)
+	})
+	slices.SortStableFunc(candidates, func(a, b host.Host) int {
+		return b.FailedChecks - a.FailedChecks
+	})
+
+	return candidates
+}
+
+// trackFailedChecks - updates counters of consecutive failed checks of the scanned hosts. Returns true if any
+// counter was changed.
+func (m *dashboardModel) trackFailedChecks(scanResult []reachability.Status) bool {
+	// Scan result contains copies of the hosts, which could be changed or deleted during the scan.
+	hosts, err := m.repo.GetAll()
+	if err != nil {
+		m.logger.Error("[UI] Cannot read database. %v", err)
+		return false
+	}
+
+	var changed bool
+
+	hostsByID := lo.KeyBy(hosts, func(h host.Host) int { return h.ID })
+	for _, status := range scanResult {
+		h, ok := hostsByID[status.Host.ID]
+		if !ok {
+			continue
+		}
+
+		failedChecks := NextFailedChecks(h.FailedChecks, status)
+		if failedChecks == h.FailedChecks {
+			continue
+		}
+
+		h.FailedChecks = failedChecks
+		if _, err = m.repo.Save(h); err != nil {
+			m.logger.Error("[UI] Cannot save failed checks of host id: %d. %v", h.ID, err)
+			continue
+		}
+
+		changed = true
+	}
+
+	return changed
+}
+
+// prune - deletes or archives prune candidates.
+func (m *dashboardModel) prune(archive bool) {
+	for _, h := range m.pruneCandidates {
+		var err error
+		if archive {
+			m.logger.Info("[UI] Archive host id: %d, title: %s", h.ID, h.Title)
+			h.Group = archivedGroup
+			_, err = m.repo.Save(h)
+		} else {
+			m.logger.Info("[UI] Delete host id: %d, title: %s", h.ID, h.Title)
+			err = m.repo.Delete(h.ID)
+		}
+
+		if err != nil {
+			m.logger.Error("[UI] Cannot prune host id: %d. %v", h.ID, err)
+			m.err = err
+		}
+	}
+}
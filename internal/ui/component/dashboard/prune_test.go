@@ -0,0 +1,181 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/reachability"
+	"github.com/grafviktor/goto/internal/storage"
+	"github.com/grafviktor/goto/internal/test"
+)
+
+func TestNextFailedChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  int
+		status   reachability.Status
+		expected int
+	}{
+		{"Reachable host resets the counter", 5, reachability.Status{Reachable: true}, 0},
+		{"Unreachable host increments the counter", 2, reachability.Status{Err: errors.New("connection refused")}, 3},
+		{"First failure", 0, reachability.Status{Err: errors.New("i/o timeout")}, 1},
+		{"Unknown address is not a failure", 2, reachability.Status{Err: reachability.ErrUnknownTarget}, 2},
+		{"Canceled scan is not a failure", 2, reachability.Status{Err: context.Canceled}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, NextFailedChecks(tt.current, tt.status))
+		})
+	}
+}
+
+func TestPruneCandidates(t *testing.T) {
+	hosts := []host.Host{
+		{Title: "alive", FailedChecks: 0},
+		{Title: "flaky", FailedChecks: 2},
+		{Title: "dead", FailedChecks: 3},
+		{Title: "long dead", FailedChecks: 10},
+		{Title: "archived", Group: " Archived ", FailedChecks: 10},
+	}
+
+	candidates := PruneCandidates(hosts, 3)
+	require.Equal(t, []string{"long dead", "dead"}, []string{candidates[0].Title, candidates[1].Title})
+	require.Len(t, candidates, 2)
+	require.Empty(t, PruneCandidates(hosts, 11))
+}
+
+// unreachableScanner - reports hosts which title is "dead" as unreachable.
+type unreachableScanner struct{}
+
+func (s unreachableScanner) Scan(_ context.Context, hosts []host.Host) []reachability.Status {
+	result := make([]reachability.Status, 0, len(hosts))
+	for _, h := range hosts {
+		if h.Title == "dead" {
+			result = append(result, reachability.Status{Host: h, Err: errors.New("connection refused")})
+		} else {
+			result = append(result, reachability.Status{Host: h, Reachable: true})
+		}
+	}
+
+	return result
+}
+
+// canceledScanner - reports that none of the hosts were checked.
+type canceledScanner struct{}
+
+func (s canceledScanner) Scan(_ context.Context, hosts []host.Host) []reachability.Status {
+	return lo.Map(hosts, func(h host.Host, _ int) reachability.Status {
+		return reachability.Status{Host: h, Err: context.Canceled}
+	})
+}
+
+func newPruneTestModel(t *testing.T) (*dashboardModel, storage.HostStorage) {
+	t.Helper()
+
+	repo, err := storage.NewYAML(context.TODO(), t.TempDir(), &test.MockLogger{})
+	require.NoError(t, err)
+	_, err = repo.Save(host.Host{Title: "alive", Address: "10.0.0.1", FailedChecks: 1})
+	require.NoError(t, err)
+	_, err = repo.Save(host.Host{Title: "dead", Address: "10.0.0.2", FailedChecks: 1})
+	require.NoError(t, err)
+
+	model := New(context.TODO(), repo, 2, &test.MockLogger{})
+	model.scanner = unreachableScanner{}
+	model.Init()
+
+	return model, repo
+}
+
+func scanAndWait(model *dashboardModel) tea.Cmd {
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	_, cmd = model.Update(cmd())
+
+	return cmd
+}
+
+func TestDashboard_TrackFailedChecks(t *testing.T) {
+	model, repo := newPruneTestModel(t)
+	// Host list is asked to reload hosts, because the counters are changed
+	cmd := scanAndWait(model)
+	require.Equal(t, FailedChecksChanged{}, cmd())
+
+	hosts, err := repo.GetAll()
+	require.NoError(t, err)
+	failedChecks := map[string]int{}
+	for _, h := range hosts {
+		failedChecks[h.Title] = h.FailedChecks
+	}
+	require.Equal(t, map[string]int{"alive": 0, "dead": 2}, failedChecks)
+}
+
+func TestDashboard_PruneDelete(t *testing.T) {
+	model, repo := newPruneTestModel(t)
+
+	// No hosts reached the threshold yet
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	require.True(t, model.pruning)
+	require.Contains(t, model.View(), "none of the hosts failed 2 checks in a row")
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	require.Nil(t, cmd)
+	require.False(t, model.pruning)
+
+	scanAndWait(model)
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	require.Contains(t, model.View(), "dead")
+	require.NotContains(t, model.View(), "alive")
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	require.Equal(t, HostsPruned{}, cmd())
+	hosts, _ := repo.GetAll()
+	require.Len(t, hosts, 1)
+	require.Equal(t, "alive", hosts[0].Title)
+}
+
+func TestDashboard_PruneArchive(t *testing.T) {
+	model, repo := newPruneTestModel(t)
+	scanAndWait(model)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	require.Equal(t, HostsPruned{}, cmd())
+
+	hosts, _ := repo.GetAll()
+	require.Len(t, hosts, 2)
+	for _, h := range hosts {
+		if h.Title == "dead" {
+			require.Equal(t, archivedGroup, h.Group)
+		}
+	}
+
+	// Archived hosts are not offered again
+	scanAndWait(model)
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	require.Empty(t, model.pruneCandidates)
+}
+
+func TestDashboard_PruneCancel(t *testing.T) {
+	model, repo := newPruneTestModel(t)
+	scanAndWait(model)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Nil(t, cmd)
+	require.False(t, model.pruning)
+	hosts, _ := repo.GetAll()
+	require.Len(t, hosts, 2)
+}
+
+func TestDashboard_TrackFailedChecks_Unchanged(t *testing.T) {
+	model, _ := newPruneTestModel(t)
+	model.scanner = canceledScanner{}
+
+	// Canceled scan doesn't change the counters, there's nothing to reload
+	require.Nil(t, scanAndWait(model))
+}
@@ -0,0 +1,124 @@
+package dashboard
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/grafviktor/goto/internal/i18n"
+	"github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/reachability"
+)
+
+const ungroupedTitle = "ungrouped"
+
+// GroupCount - is a number of hosts which belong to a group.
+type GroupCount struct {
+	Name  string
+	Count int
+}
+
+// Summary - contains aggregated information about all hosts.
+type Summary struct {
+	Total  int
+	Groups []GroupCount
+	// Providers contains number of hosts per cloud provider, hosts without provider are not counted.
+	Providers []GroupCount
+	// Scanned is a number of hosts which were checked during the last scan and still exist in the storage.
+	Scanned   int
+	Reachable int
+	// RecentlyUsed contains hosts which were connected to, the most recent one goes first.
+	RecentlyUsed []host.Host
+}
+
+// Aggregate - builds summary of the hosts. scanResult is a result of the last reachability scan,
+// it can be empty if hosts were never scanned. recentLimit restricts number of recently used hosts.
+func Aggregate(hosts []host.Host, scanResult []reachability.Status, recentLimit int) Summary {
+	summary := Summary{Total: len(hosts)}
+
+	summary.Groups = countBy(hosts, func(h host.Host) string {
+		return lo.CoalesceOrEmpty(strings.TrimSpace(h.Group), ungroupedTitle)
+	})
+	summary.Providers = countBy(lo.Filter(hosts, func(h host.Host, _ int) bool {
+		return host.NormalizeProvider(h.Provider) != ""
+	}), func(h host.Host) string {
+		return host.NormalizeProvider(h.Provider)
+	})
+
+	// Hosts which were deleted after the scan are not taken into account.
+	hostIDs := lo.SliceToMap(hosts, func(h host.Host) (int, struct{}) {
+		return h.ID, struct{}{}
+	})
+	for _, status := range scanResult {
+		if _, ok := hostIDs[status.Host.ID]; !ok {
+			continue
+		}
+
+		summary.Scanned++
+		if status.Reachable {
+			summary.Reachable++
+		}
+	}
+
+	summary.RecentlyUsed = lo.Filter(hosts, func(h host.Host, _ int) bool {
+		return !h.LastConnected.IsZero()
+	})
+	slices.SortStableFunc(summary.RecentlyUsed, func(a, b host.Host) int {
+		return b.LastConnected.Compare(a.LastConnected)
+	})
+	if len(summary.RecentlyUsed) > recentLimit {
+		summary.RecentlyUsed = summary.RecentlyUsed[:recentLimit]
+	}
+
+	return summary
+}
+
+// countBy - returns number of hosts per key. Largest groups go first, groups of the same size are sorted by name.
+func countBy(hosts []host.Host, key func(h host.Host) string) []GroupCount {
+	counts := []GroupCount{}
+	for name, count := range lo.CountValuesBy(hosts, key) {
+		counts = append(counts, GroupCount{Name: name, Count: count})
+	}
+
+	slices.SortFunc(counts, func(a, b GroupCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return counts
+}
+
+// FailedConnections - returns hosts which last ssh session ended with an error, the most recent failure goes first.
+// limit restricts number of returned hosts.
+func FailedConnections(hosts []host.Host, limit int) []host.Host {
+	failed := lo.Filter(hosts, func(h host.Host, _ int) bool {
+		return h.LastConnectResult.Failed()
+	})
+	slices.SortStableFunc(failed, func(a, b host.Host) int {
+		return b.LastConnectResult.Time.Compare(a.LastConnectResult.Time)
+	})
+	if len(failed) > limit {
+		failed = failed[:limit]
+	}
+
+	return failed
+}
+
+// humanizeSince - returns a short description of time elapsed since t. Ex: "5m ago".
+func humanizeSince(t, now time.Time) string {
+	elapsed := now.Sub(t)
+	switch {
+	case elapsed < time.Minute:
+		return i18n.T("just now")
+	case elapsed < time.Hour:
+		return i18n.Tf("%dm ago", elapsed/time.Minute)
+	case elapsed < time.Hour*24:
+		return i18n.Tf("%dh ago", elapsed/time.Hour)
+	default:
+		return t.Format(time.DateOnly)
+	}
+}
@@ -0,0 +1,120 @@
+package dashboard
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/reachability"
+)
+
+func TestAggregate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	hosts := []host.Host{
+		{ID: 1, Title: "web-01", Group: "prod", LastConnected: now.Add(-time.Hour)},
+		{ID: 2, Title: "web-02", Group: "prod"},
+		{ID: 3, Title: "db-01", Group: " prod ", LastConnected: now.Add(-time.Minute)},
+		{ID: 4, Title: "staging", Group: "staging", LastConnected: now.Add(-time.Hour * 48)},
+		{ID: 5, Title: "laptop"},
+		{ID: 6, Title: "router", Group: " "},
+	}
+	scanResult := []reachability.Status{
+		{Host: hosts[0], Reachable: true},
+		{Host: hosts[1], Err: errors.New("connection refused")},
+		{Host: hosts[3], Reachable: true},
+		// Host was deleted after the scan
+		{Host: host.Host{ID: 100}, Reachable: true},
+	}
+
+	summary := Aggregate(hosts, scanResult, 2)
+
+	require.Equal(t, 6, summary.Total)
+	require.Equal(t, []GroupCount{
+		{Name: "prod", Count: 3},
+		{Name: "ungrouped", Count: 2},
+		{Name: "staging", Count: 1},
+	}, summary.Groups)
+	require.Equal(t, 3, summary.Scanned)
+	require.Equal(t, 2, summary.Reachable)
+	// Most recent first, limited to 2 hosts
+	require.Len(t, summary.RecentlyUsed, 2)
+	require.Equal(t, "db-01", summary.RecentlyUsed[0].Title)
+	require.Equal(t, "web-01", summary.RecentlyUsed[1].Title)
+}
+
+func TestAggregate_Providers(t *testing.T) {
+	hosts := []host.Host{
+		{ID: 1, Title: "web-01", Provider: host.ProviderAWS},
+		{ID: 2, Title: "web-02", Provider: " AWS "},
+		{ID: 3, Title: "db-01", Provider: host.ProviderGCP},
+		{ID: 4, Title: "cache", Provider: "hetzner"},
+		{ID: 5, Title: "laptop"},
+	}
+
+	summary := Aggregate(hosts, nil, 0)
+
+	// Hosts without provider are not counted, provider names are case-insensitive
+	require.Equal(t, []GroupCount{
+		{Name: "aws", Count: 2},
+		{Name: "gcp", Count: 1},
+		{Name: "hetzner", Count: 1},
+	}, summary.Providers)
+	require.Empty(t, Aggregate(hosts[4:], nil, 0).Providers)
+}
+
+func TestAggregate_Empty(t *testing.T) {
+	summary := Aggregate(nil, nil, 5)
+
+	require.Equal(t, 0, summary.Total)
+	require.Empty(t, summary.Groups)
+	require.Equal(t, 0, summary.Scanned)
+	require.Equal(t, 0, summary.Reachable)
+	require.Empty(t, summary.RecentlyUsed)
+}
+
+func TestAggregate_NeverScanned(t *testing.T) {
+	hosts := []host.Host{{ID: 1, Title: "web-01"}, {ID: 2, Title: "web-02"}}
+	summary := Aggregate(hosts, nil, 5)
+
+	require.Equal(t, 2, summary.Total)
+	require.Equal(t, []GroupCount{{Name: "ungrouped", Count: 2}}, summary.Groups)
+	require.Equal(t, 0, summary.Scanned)
+	require.Empty(t, summary.RecentlyUsed)
+}
+
+func TestFailedConnections(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	hosts := []host.Host{
+		{ID: 1, Title: "ok", LastConnectResult: host.ConnectResult{Time: now}},
+		{ID: 2, Title: "refused", LastConnectResult: host.ConnectResult{
+			Time: now.Add(-time.Hour), ExitCode: 255, Error: "ssh: connect to host 10.0.0.1 port 22: Connection refused",
+		}},
+		{ID: 3, Title: "never connected"},
+		{ID: 4, Title: "remote command failed", LastConnectResult: host.ConnectResult{Time: now, ExitCode: 1}},
+		{ID: 5, Title: "not started", LastConnectResult: host.ConnectResult{
+			Time: now.Add(-time.Hour * 2), ExitCode: -1, Error: "exec: \"ssh\": executable file not found in $PATH",
+		}},
+	}
+
+	// Only failures are returned, the most recent first
+	failed := FailedConnections(hosts, 10)
+	require.Equal(t, []int{4, 2, 5}, lo.Map(failed, func(h host.Host, _ int) int { return h.ID }))
+	require.Equal(t, 255, failed[1].LastConnectResult.ExitCode)
+
+	require.Len(t, FailedConnections(hosts, 2), 2)
+	require.Empty(t, FailedConnections(hosts[:1], 10))
+	require.Empty(t, FailedConnections(nil, 10))
+}
+
+func TestHumanizeSince(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(t, "just now", humanizeSince(now.Add(-time.Second*30), now))
+	require.Equal(t, "5m ago", humanizeSince(now.Add(-time.Minute*5-time.Second*10), now))
+	require.Equal(t, "3h ago", humanizeSince(now.Add(-time.Hour*3-time.Minute*20), now))
+	require.Equal(t, "2024-04-28", humanizeSince(now.Add(-time.Hour*72), now))
+}
@@ -0,0 +1,122 @@
+package hostedit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v2"
+
+	hostModel "github.com/grafviktor/goto/internal/model/host"
+)
+
+// draftsFolder is located in the application home folder and contains one draft file per host.
+const draftsFolder = "drafts"
+
+// draftFilePath - returns path of the draft file of the host, new hosts share the draft with id 0.
+func draftFilePath(appHome string, hostID int) string {
+	return path.Join(appHome, draftsFolder, fmt.Sprintf("%d.yaml", hostID))
+}
+
+// writeDraft - stores in-progress host attributes, so they can be restored if the application exits unexpectedly.
+// The draft may contain a password, that's why it's readable by the owner only.
+func writeDraft(appHome string, h hostModel.Host) error {
+	data, err := yaml.Marshal(h)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(path.Join(appHome, draftsFolder), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(draftFilePath(appHome, h.ID), data, 0o600)
+}
+
+// readDraft - reads the draft of the host. Returns false if there is no draft.
+func readDraft(appHome string, hostID int) (hostModel.Host, bool, error) {
+	data, err := os.ReadFile(draftFilePath(appHome, hostID))
+	if errors.Is(err, os.ErrNotExist) {
+		return hostModel.Host{}, false, nil
+	} else if err != nil {
+		return hostModel.Host{}, false, err
+	}
+
+	var h hostModel.Host
+	if err = yaml.Unmarshal(data, &h); err != nil {
+		return hostModel.Host{}, false, err
+	}
+
+	return h, true, nil
+}
+
+// clearDraft - removes the draft of the host. Missing draft is not an error.
+func clearDraft(appHome string, hostID int) error {
+	err := os.Remove(draftFilePath(appHome, hostID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// restoreDraft - replaces host attributes with the draft, which is left when the application exits before
+// the changes are saved or discarded. Returns false if there is no draft or drafts are disabled.
+func (m *editModel) restoreDraft() bool {
+	if !m.draftEnabled() {
+		return false
+	}
+
+	draft, ok, err := readDraft(m.appState.ApplicationConfig.AppHome, m.host.ID)
+	if err != nil {
+		m.logger.Info("[UI] Cannot read draft of host id: %v. %v", m.host.ID, err)
+		return false
+	} else if !ok {
+		return false
+	}
+
+	m.logger.Info("[UI] Restore draft of host id: %v", m.host.ID)
+	// Identifier and ssh config are not a part of the draft.
+	draft.ID = m.host.ID
+	draft.SSHClientConfig = m.host.SSHClientConfig
+	*m.host.Host = draft
+
+	return true
+}
+
+// updateDraft - writes the draft if host attributes changed since the last write.
+func (m *editModel) updateDraft() {
+	if !m.draftEnabled() {
+		return
+	}
+
+	draft, err := marshalHost(m.host.unwrap())
+	if err != nil || draft == m.draft {
+		return
+	}
+
+	if err = writeDraft(m.appState.ApplicationConfig.AppHome, m.host.unwrap()); err != nil {
+		m.logger.Info("[UI] Cannot write draft of host id: %v. %v", m.host.ID, err)
+		return
+	}
+
+	m.logger.Debug("[UI] Write draft of host id: %v", m.host.ID)
+	m.draft = draft
+}
+
+// removeDraft - removes the draft when changes are saved or discarded.
+func (m *editModel) removeDraft() {
+	if !m.draftEnabled() {
+		return
+	}
+
+	if err := clearDraft(m.appState.ApplicationConfig.AppHome, m.host.ID); err != nil {
+		m.logger.Info("[UI] Cannot remove draft of host id: %v. %v", m.host.ID, err)
+	}
+}
+
+// draftEnabled - returns true if user enabled drafts. Scratch host is never written to disk, so it has no draft.
+func (m *editModel) draftEnabled() bool {
+	return m.appState.ApplicationConfig.EditDraft && !m.host.IsScratch()
+}
@@ -1,6 +1,10 @@
 package hostedit
 
 import (
+	"strings"
+
+	"github.com/samber/lo"
+
 	model "github.com/grafviktor/goto/internal/model/host"
 )
 
@@ -18,14 +22,78 @@ func (m *hostModelWrapper) getHostAttributeValueByIndex(inputType int) string {
 		return m.Address
 	case inputDescription:
 		return m.Description
+	case inputGroup:
+		return m.Group
+	case inputTags:
+		return strings.Join(m.Tags, ", ")
+	case inputAlias:
+		return m.Alias
+	case inputWebURL:
+		return m.WebURL
+	case inputBanner:
+		return m.Banner
+	case inputNotes:
+		return m.Notes
+	case inputProtocol:
+		return m.Protocol
+	case inputTeleportCluster:
+		return m.TeleportCluster
+	case inputKubeNamespace:
+		return m.KubeNamespace
+	case inputKubePod:
+		return m.KubePod
+	case inputKubeContainer:
+		return m.KubeContainer
 	case inputLogin:
 		return m.LoginName
 	case inputNetworkPort:
 		return m.RemotePort
 	case inputIdentityFile:
 		return m.IdentityFilePath
+	case inputIdentitiesOnly:
+		return lo.Ternary(m.IdentitiesOnly, "yes", "no")
+	case inputForwardAgent:
+		return lo.Ternary(m.ForwardAgent, "yes", "no")
+	case inputCompression:
+		return lo.Ternary(m.Compression, "yes", "no")
+	case inputCompressionLevel:
+		return m.CompressionLevel
 	case inputPassword:
 		return m.Password
+	case inputPasswordCommand:
+		return m.PasswordCommand
+	case inputGatewayPorts:
+		return m.GatewayPorts
+	case inputEscapeChar:
+		return m.EscapeChar
+	case inputBindAddress:
+		return m.BindAddress
+	case inputHostKeyAlias:
+		return m.HostKeyAlias
+	case inputStrictHostKeyChecking:
+		return m.StrictHostKeyChecking
+	case inputConnectTimeout:
+		return m.ConnectTimeout
+	case inputKeepAliveInterval:
+		return m.KeepAliveInterval
+	case inputKeepAliveCountMax:
+		return m.KeepAliveCountMax
+	case inputLocalForwards:
+		return strings.Join(m.LocalForwards, ", ")
+	case inputRemoteForwards:
+		return strings.Join(m.RemoteForwards, ", ")
+	case inputDynamicForward:
+		return m.DynamicForward
+	case inputBackgroundTunnel:
+		return lo.Ternary(m.BackgroundTunnel, "yes", "no")
+	case inputAutoConnect:
+		return lo.Ternary(m.AutoConnect, "yes", "no")
+	case inputExtraOptions:
+		return strings.Join(m.ExtraOptions, ", ")
+	case inputTermType:
+		return m.TermType
+	case inputConnectTemplate:
+		return m.ConnectTemplate
 	default:
 		return ""
 	}
@@ -39,14 +107,78 @@ func (m *hostModelWrapper) setHostAttributeByIndex(inputType int, value string)
 		m.Address = value
 	case inputDescription:
 		m.Description = value
+	case inputGroup:
+		m.Group = value
+	case inputTags:
+		m.Tags = splitTags(value)
+	case inputAlias:
+		m.Alias = value
+	case inputWebURL:
+		m.WebURL = value
+	case inputBanner:
+		m.Banner = value
+	case inputNotes:
+		m.Notes = value
+	case inputProtocol:
+		m.Protocol = value
+	case inputTeleportCluster:
+		m.TeleportCluster = value
+	case inputKubeNamespace:
+		m.KubeNamespace = value
+	case inputKubePod:
+		m.KubePod = value
+	case inputKubeContainer:
+		m.KubeContainer = value
 	case inputLogin:
 		m.LoginName = value
 	case inputNetworkPort:
 		m.RemotePort = value
 	case inputIdentityFile:
 		m.IdentityFilePath = value
+	case inputIdentitiesOnly:
+		m.IdentitiesOnly = value == "yes"
+	case inputForwardAgent:
+		m.ForwardAgent = value == "yes"
+	case inputCompression:
+		m.Compression = value == "yes"
+	case inputCompressionLevel:
+		m.CompressionLevel = value
 	case inputPassword:
 		m.Password = value
+	case inputPasswordCommand:
+		m.PasswordCommand = value
+	case inputGatewayPorts:
+		m.GatewayPorts = value
+	case inputEscapeChar:
+		m.EscapeChar = value
+	case inputBindAddress:
+		m.BindAddress = value
+	case inputHostKeyAlias:
+		m.HostKeyAlias = value
+	case inputStrictHostKeyChecking:
+		m.StrictHostKeyChecking = value
+	case inputConnectTimeout:
+		m.ConnectTimeout = value
+	case inputKeepAliveInterval:
+		m.KeepAliveInterval = value
+	case inputKeepAliveCountMax:
+		m.KeepAliveCountMax = value
+	case inputLocalForwards:
+		m.LocalForwards = splitForwards(value)
+	case inputRemoteForwards:
+		m.RemoteForwards = splitForwards(value)
+	case inputDynamicForward:
+		m.DynamicForward = value
+	case inputBackgroundTunnel:
+		m.BackgroundTunnel = value == "yes"
+	case inputAutoConnect:
+		m.AutoConnect = value == "yes"
+	case inputExtraOptions:
+		m.ExtraOptions = splitExtraOptions(value)
+	case inputTermType:
+		m.TermType = value
+	case inputConnectTemplate:
+		m.ConnectTemplate = value
 	}
 }
 
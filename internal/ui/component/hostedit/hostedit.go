@@ -3,24 +3,36 @@ package hostedit
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/samber/lo"
 
+	"github.com/grafviktor/goto/internal/constant"
+	"github.com/grafviktor/goto/internal/i18n"
 	hostModel "github.com/grafviktor/goto/internal/model/host"
 	"github.com/grafviktor/goto/internal/model/ssh"
 	"github.com/grafviktor/goto/internal/state"
 	"github.com/grafviktor/goto/internal/storage"
 	"github.com/grafviktor/goto/internal/ui/component/input"
 	"github.com/grafviktor/goto/internal/ui/message"
+	"github.com/grafviktor/goto/internal/ui/theme"
 	"github.com/grafviktor/goto/internal/utils"
 )
 
@@ -47,20 +59,63 @@ const (
 	inputTitle int = iota
 	inputAddress
 	inputDescription
+	inputGroup
+	inputTags
+	inputAlias
+	inputWebURL
+	inputBanner
+	inputNotes
+	inputProtocol
+	inputTeleportCluster
+	inputKubeNamespace
+	inputKubePod
+	inputKubeContainer
 	inputLogin
 	inputNetworkPort
 	inputIdentityFile
+	inputRemoteForwards
+	inputIdentitiesOnly
+	inputForwardAgent
+	inputCompression
+	inputCompressionLevel
 	inputPassword
-	inputEchoPassword
+	inputPasswordCommand
+	inputGatewayPorts
+	inputEscapeChar
+	inputBindAddress
+	inputHostKeyAlias
+	inputStrictHostKeyChecking
+	inputConnectTimeout
+	inputKeepAliveInterval
+	inputKeepAliveCountMax
+	inputLocalForwards
+	inputDynamicForward
+	inputBackgroundTunnel
+	inputAutoConnect
+	inputExtraOptions
+	inputTermType
+	inputConnectTemplate
+	// inputsCount should always be the last one. It's used to allocate the collection of inputs.
+	inputsCount
 )
 
-type itemID struct{}
+type (
+	itemID      struct{}
+	scratchHost struct{}
+)
+
+// clipboardWriteAll is a variable in order to be replaced in unit tests.
+var clipboardWriteAll = clipboard.WriteAll
 
 var (
 	// ItemID is a key to extract item id from application context.
-	ItemID       = itemID{}
-	defaultTitle = "host details"
-	debounceTime = time.Millisecond * 300
+	ItemID = itemID{}
+	// ScratchHost is a key to extract the scratch host from application context, it's never stored.
+	ScratchHost = scratchHost{}
+	// sshKeysFolder is scanned for private keys when user cycles through identity files.
+	sshKeysFolder = "~/.ssh"
+	defaultTitle  = "host details"
+	debounceTime  = time.Millisecond * 300
 )
 
 type iLogger interface {
@@ -70,7 +125,7 @@ type iLogger interface {
 
 func notEmptyValidator(s string) error {
 	if utils.StringEmpty(s) {
-		return fmt.Errorf("value is required")
+		return errors.New(i18n.T("value is required"))
 	}
 
 	return nil
@@ -82,21 +137,394 @@ func networkPortValidator(s string) error {
 	}
 
 	auto := 0 // 0 is used to autodetect base, see strconv.ParseUint
+	if !isPositiveNumber(s, auto) {
+		return errors.New(i18n.T("network port must be a number which is less than 65,535"))
+	}
+
+	return nil
+}
+
+func connectTimeoutValidator(s string) error {
+	if utils.StringEmpty(s) {
+		return nil
+	}
+
+	decimal := 10
+	if !isPositiveNumber(strings.TrimSpace(s), decimal) {
+		return errors.New(i18n.T("connect timeout must be a positive number of seconds"))
+	}
+
+	return nil
+}
+
+// nonNegativeNumberValidator - checks that the value is a decimal number which is greater than or equal to zero.
+func nonNegativeNumberValidator(s string) error {
+	if utils.StringEmpty(s) {
+		return nil
+	}
+
+	decimal := 10
+	if s = strings.TrimSpace(s); s != "0" && !isPositiveNumber(s, decimal) {
+		return errors.New(i18n.T("value must be a non-negative number"))
+	}
+
+	return nil
+}
+
+// isPositiveNumber - returns true if s is a number from 1 to 65,535 in the given base.
+func isPositiveNumber(s string, base int) bool {
 	maxLengthBit := 16
-	if num, err := strconv.ParseUint(s, auto, maxLengthBit); err != nil || num < 1 {
-		return fmt.Errorf("network port must be a number which is less than 65,535")
+	num, err := strconv.ParseUint(s, base, maxLengthBit)
+	return err == nil && num >= 1
+}
+
+func aliasValidator(s string) error {
+	if strings.ContainsFunc(strings.TrimSpace(s), unicode.IsSpace) {
+		return errors.New(i18n.T("alias must not contain spaces"))
+	}
+
+	return nil
+}
+
+func webURLValidator(s string) error {
+	if utils.StringEmpty(s) {
+		return nil
+	}
+
+	// Address placeholder is substituted when url is opened, use any valid hostname to validate the rest of the url.
+	value := strings.ReplaceAll(strings.TrimSpace(s), hostModel.WebURLAddressPlaceholder, "localhost")
+	parsed, err := url.ParseRequestURI(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New(i18n.T("web url must be a valid http or https url"))
+	}
+
+	return nil
+}
+
+func compressionLevelValidator(s string) error {
+	if utils.StringEmpty(s) {
+		return nil
+	}
+
+	if level, err := strconv.Atoi(strings.TrimSpace(s)); err != nil || level < 1 || level > 9 {
+		return errors.New(i18n.T("compression level must be a number from 1 to 9"))
+	}
+
+	return nil
+}
+
+func protocolValidator(s string) error {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", constant.ProtocolSSH, constant.ProtocolTeleport, constant.ProtocolKubectl:
+		return nil
+	default:
+		return errors.New(i18n.Tf(
+			"protocol must be one of: %s, %s, %s",
+			constant.ProtocolSSH,
+			constant.ProtocolTeleport,
+			constant.ProtocolKubectl,
+		))
+	}
+}
+
+// addressValidator - address is not used when connecting to a Kubernetes pod, otherwise it's required and
+// must be a hostname or an IP address. User-defined connect strings, which contain spaces or a login, are
+// not validated here, see hostModel.Host.IsUserDefinedSSHCommand.
+func (m *editModel) addressValidator(s string) error {
+	if m.host.IsKubectl() {
+		return nil
+	}
+
+	if err := notEmptyValidator(s); err != nil {
+		return err
+	}
+
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, " @") || isIPAddress(s) || isHostname(s) {
+		return nil
+	}
+
+	return errors.New(i18n.T("address must be a hostname or an IP address"))
+}
+
+// isIPAddress - returns true if s is an IPv4 or IPv6 address, IPv6 address can be enclosed in square brackets.
+func isIPAddress(s string) bool {
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		s = s[1 : len(s)-1]
+	}
+
+	return net.ParseIP(s) != nil
+}
+
+// isHostname - returns true if s is a valid hostname as defined in RFC 1123. A trailing dot is allowed.
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(s, ".") {
+		if !isHostnameLabel(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isHostnameLabel - returns true if the label consists of 1 to 63 letters, digits, hyphens and underscores and
+// doesn't start or end with a hyphen. Underscores are not allowed by RFC 1123, but they're common in internal
+// DNS zones and ssh resolves such names.
+func isHostnameLabel(label string) bool {
+	if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+
+	for _, r := range label {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// kubectlValidator - is used by inputs which are required when connecting to a Kubernetes pod.
+func (m *editModel) kubectlValidator(s string) error {
+	if !m.host.IsKubectl() {
+		return nil
+	}
+
+	return notEmptyValidator(s)
+}
+
+func connectTemplateValidator(s string) error {
+	if err := hostModel.ValidateConnectTemplate(s); err != nil {
+		return errors.New(i18n.Tf("connect template is not valid, %v", err))
+	}
+
+	return nil
+}
+
+func gatewayPortsValidator(s string) error {
+	switch strings.TrimSpace(s) {
+	case "", "yes", "no", "clientspecified":
+		return nil
+	default:
+		return errors.New(i18n.T("gateway ports must be one of: yes, no, clientspecified"))
+	}
+}
+
+func escapeCharValidator(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "none" || utf8.RuneCountInString(s) == 1 {
+		return nil
+	}
+
+	return errors.New(i18n.T("escape character must be a single character or none"))
+}
+
+func bindAddressValidator(s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" || net.ParseIP(s) != nil {
+		return nil
+	}
+
+	return errors.New(i18n.T("bind address must be an IP address"))
+}
+
+func hostKeyAliasValidator(s string) error {
+	if strings.ContainsAny(strings.TrimSpace(s), " \t") {
+		return errors.New(i18n.T("host key alias must not contain spaces"))
+	}
+
+	return nil
+}
+
+func strictHostKeyCheckingValidator(s string) error {
+	switch strings.TrimSpace(s) {
+	case "", "yes", "no", "accept-new":
+		return nil
+	default:
+		return errors.New(i18n.T("strict host key checking must be one of: yes, no, accept-new"))
+	}
+}
+
+// termTypeValidator - any terminal type is accepted, because terminfo databases differ, but the value must be
+// a single word which can be stored in TERM variable.
+func termTypeValidator(s string) error {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, " \t=") {
+		return errors.New(i18n.T("terminal type must be a single word, ex: xterm-256color"))
+	}
+
+	return nil
+}
+
+// localForwardsValidator - checks that every entry of comma-separated list is 'port:host:port' and that local
+// ports are unique.
+func localForwardsValidator(s string) error {
+	if forward, ok := invalidForward(s, false); ok {
+		return errors.New(i18n.Tf("local forward '%s' must be port:host:port", forward))
+	}
+
+	if port, ok := hostModel.DuplicateLocalPort(splitForwards(s)); ok {
+		return errors.New(i18n.Tf("local port %s is forwarded more than once", port))
+	}
+
+	return nil
+}
+
+// remoteForwardsValidator - checks that every entry of comma-separated list is '[bind_address:]port:host:port'.
+func remoteForwardsValidator(s string) error {
+	if forward, ok := invalidForward(s, true); ok {
+		return errors.New(i18n.Tf("remote forward '%s' must be [bind_address:]port:host:port", forward))
+	}
+
+	return nil
+}
+
+// invalidForward - returns the first entry of comma-separated list of forwards which is not 'port:host:port'.
+// When bindAddressAllowed is set, the entry may start with a bind address.
+func invalidForward(s string, bindAddressAllowed bool) (string, bool) {
+	for _, forward := range splitForwards(s) {
+		parts := strings.Split(forward, ":")
+		valid := len(parts) == 3 || bindAddressAllowed && len(parts) == 4
+		if valid {
+			ports := parts[len(parts)-3:]
+			valid = !lo.SomeBy(parts, utils.StringEmpty) &&
+				networkPortValidator(ports[0]) == nil && networkPortValidator(ports[2]) == nil
+		}
+
+		if !valid {
+			return forward, true
+		}
+	}
+
+	return "", false
+}
+
+// splitForwards - splits comma-separated list of port forwards, empty entries are dropped.
+func splitForwards(s string) []string {
+	forwards := lo.Compact(lo.Map(strings.Split(s, ","), func(forward string, _ int) string {
+		return strings.TrimSpace(forward)
+	}))
+
+	return lo.Ternary(len(forwards) == 0, nil, forwards)
+}
+
+// tagsValidator - checks that tags don't contain spaces, so that a tag can be typed into the filter prompt as is.
+func tagsValidator(s string) error {
+	for _, tag := range splitTags(s) {
+		if strings.ContainsAny(tag, " \t") {
+			return errors.New(i18n.Tf("tag '%s' must not contain spaces", tag))
+		}
 	}
 
 	return nil
 }
 
-func getKeyMap(focusedInput int) keyMap {
+// splitTags - splits comma-separated list of tags, empty and duplicate entries are dropped.
+func splitTags(s string) []string {
+	tags := lo.Uniq(lo.Compact(lo.Map(strings.Split(s, ","), func(tag string, _ int) string {
+		return strings.TrimSpace(tag)
+	})))
+
+	return lo.Ternary(len(tags) == 0, nil, tags)
+}
+
+// extraOptionsValidator - checks that every entry of the list is 'Key=Value'.
+func extraOptionsValidator(s string) error {
+	for _, option := range splitExtraOptions(s) {
+		key, _, _ := strings.Cut(option, "=")
+		if strings.Count(option, "=") != 1 || utils.StringEmpty(key) || strings.ContainsAny(key, " \t") {
+			return errors.New(i18n.Tf("extra option '%s' must be Key=Value", option))
+		}
+	}
+
+	return nil
+}
+
+// splitExtraOptions - splits newline or comma-separated list of ssh options, empty entries are dropped.
+// Values of some options are comma-separated lists themselves, for instance "Ciphers=aes256-ctr,aes128-ctr",
+// that's why a comma starts a new entry only when the text after it contains '='.
+func splitExtraOptions(s string) []string {
+	options := []string{}
+	for _, line := range strings.Split(s, "\n") {
+		lineOptions := []string{}
+		for _, part := range strings.Split(line, ",") {
+			if len(lineOptions) > 0 && !strings.Contains(part, "=") {
+				lineOptions[len(lineOptions)-1] += "," + part
+			} else {
+				lineOptions = append(lineOptions, part)
+			}
+		}
+
+		options = append(options, lineOptions...)
+	}
+
+	options = lo.Compact(lo.Map(options, func(option string, _ int) string {
+		return strings.Trim(strings.TrimSpace(option), ",")
+	}))
+
+	return lo.Ternary(len(options) == 0, nil, options)
+}
+
+// identityFileWarning - warns when private key is accessible by group or others, because ssh refuses such keys.
+// Missing or unreadable files are not reported, ssh will print a descriptive error itself. File permissions are
+// not checked on Windows.
+func identityFileWarning(s string) error {
+	if utils.StringEmpty(s) {
+		return nil
+	}
+
+	var permissionsErr *utils.KeyPermissionsError
+	if err := utils.CheckPrivateKey(s); errors.As(err, &permissionsErr) {
+		return err
+	}
+
+	return nil
+}
+
+// deriveTitle - generates a title of a new host from its address. When derivation mode is 'short',
+// login name and domain part are stripped from the address, for instance "root@web-01.example.com"
+// becomes "web-01". IP addresses are never shortened.
+func deriveTitle(address string, mode constant.TitleDerivation) string {
+	address = strings.TrimSpace(address)
+	if mode != constant.TitleDerivationShort || address == "" {
+		return address
+	}
+
+	// Custom connect string may contain ssh parameters, they are skipped together
+	// with their arguments and the destination is taken as a hostname.
+	hostname := hostModel.Destination(address)
+	if _, afterAt, found := strings.Cut(hostname, "@"); found {
+		hostname = afterAt
+	}
+
+	if net.ParseIP(hostname) != nil {
+		return hostname
+	}
+
+	shortName, _, _ := strings.Cut(hostname, ".")
+	return shortName
+}
+
+func getKeyMap(focusedInput int, isSelector bool) keyMap {
+	keys := newKeyMap()
+	if isSelector {
+		// Selector does not accept text, so question mark can open help as well.
+		keys.Help.SetKeys("f1", "?")
+		keys.Help.SetHelp("f1/?", keys.Help.Help().Desc)
+	}
 	if focusedInput == inputTitle || focusedInput == inputAddress {
 		keys.CopyInputValue.SetEnabled(true)
 	} else {
 		keys.CopyInputValue.SetEnabled(false)
 	}
 
+	keys.NextKey.SetEnabled(focusedInput == inputIdentityFile)
+
 	return keys
 }
 
@@ -114,15 +542,43 @@ type editModel struct {
 	title        string
 	viewport     viewport.Model
 	debounceTag  int
+	// invalidInputsFirst is toggled by user. When set, invalid inputs are displayed
+	// on top of the form after a failed save.
+	invalidInputsFirst bool
+	saveFailed         bool
+	// titleDerived is true when title of a new host was generated from its address
+	// and wasn't changed by the user since then.
+	titleDerived bool
+	// rawMode is set when user edits the host as a yaml document in rawEditor instead of the form.
+	rawMode   bool
+	rawEditor textarea.Model
+	// identityFiles are private keys found in sshKeysFolder, nil until user cycles through identity files first time.
+	identityFiles []string
+	// draft is the last host yaml which was written to the draft file. See updateDraft.
+	draft string
+	// notesPanel is toggled by user, it displays savedDescription and savedNotes next to the inputs.
+	notesPanel       bool
+	savedDescription string
+	savedNotes       string
+	// duplicateWarned is set when user was warned that another host has the same connection target,
+	// the host is saved if user presses save key once again. Any other key resets it.
+	duplicateWarned bool
 }
 
 // New - returns new edit host form.
 func New(ctx context.Context, storage storage.HostStorage, state *state.ApplicationState, log iLogger) *editModel {
+	palette := theme.Current()
+	setStyles(palette)
+	input.SetStyles(palette)
 	initialFocusedInput := inputTitle
 
 	// If we can't cast host id to int, that means we're adding a new host. Ignore the error
 	hostID, _ := ctx.Value(ItemID).(int)
-	host, hostNotFoundErr := storage.Get(hostID)
+	host, isScratch := ctx.Value(ScratchHost).(hostModel.Host)
+	var hostNotFoundErr error
+	if !isScratch {
+		host, hostNotFoundErr = storage.Get(hostID)
+	}
 	if hostNotFoundErr != nil {
 		// Logger should notify that this is a new host
 		host = hostModel.Host{}
@@ -130,17 +586,25 @@ func New(ctx context.Context, storage storage.HostStorage, state *state.Applicat
 	host.SSHClientConfig = ssh.StubConfig()
 
 	m := editModel{
-		inputs:       make([]input.Input, 7), // Update the size to 7
+		inputs:       make([]input.Input, inputsCount),
 		hostStorage:  storage,
 		host:         wrap(&host),
 		help:         help.New(),
-		keyMap:       getKeyMap(initialFocusedInput),
+		keyMap:       getKeyMap(initialFocusedInput, false),
 		appState:     state,
 		logger:       log,
 		focusedInput: initialFocusedInput,
-		title:        defaultTitle,
+		title:        i18n.T(defaultTitle),
 		isNewHost:    hostNotFoundErr != nil,
+		// Draft is not saved yet, so values are taken before it's restored.
+		savedDescription: host.Description,
+		savedNotes:       host.Notes,
+	}
+
+	if m.restoreDraft() {
+		m.title = i18n.T("unsaved changes are restored from draft")
 	}
+	m.draft, _ = marshalHost(host)
 
 	var t input.Input
 	for i := range m.inputs {
@@ -149,36 +613,199 @@ func New(ctx context.Context, storage storage.HostStorage, state *state.Applicat
 
 		switch i {
 		case inputTitle:
-			t.SetLabel("Title")
+			t.SetLabel(i18n.T("Title"))
 			t.SetValue(host.Title)
 			t.Validate = notEmptyValidator
 		case inputAddress:
-			t.SetLabel("Host")
+			t.SetLabel(i18n.T("Host"))
 			t.CharLimit = 128
 			t.SetValue(host.Address)
-			t.Validate = notEmptyValidator
+			t.Validate = m.addressValidator
 			t.Tooltip = "ssh"
 		case inputDescription:
-			t.SetLabel("Description")
+			t.SetLabel(i18n.T("Description"))
 			t.CharLimit = 512
 			t.SetValue(host.Description)
+		case inputGroup:
+			t.SetLabel(i18n.T("Group"))
+			t.CharLimit = 128
+			t.SetValue(host.Group)
+		case inputTags:
+			t.SetLabel(i18n.T("Tags"))
+			t.CharLimit = 256
+			t.SetValue(m.host.getHostAttributeValueByIndex(inputTags))
+			t.Validate = tagsValidator
+		case inputAlias:
+			t.SetLabel(i18n.T("Alias"))
+			t.CharLimit = 32
+			t.SetValue(host.Alias)
+			t.Validate = aliasValidator
+		case inputWebURL:
+			t.SetLabel(i18n.T("Web URL"))
+			t.CharLimit = 512
+			t.SetValue(host.WebURL)
+			t.Validate = webURLValidator
+		case inputBanner:
+			t.SetLabel(i18n.T("Banner"))
+			t.CharLimit = 1024
+			t.SetValue(host.Banner)
+		case inputNotes:
+			t.SetLabel(i18n.T("Notes"))
+			t.CharLimit = 1024
+			t.SetValue(host.Notes)
+		case inputProtocol:
+			t.SetLabel(i18n.T("Protocol"))
+			t.CharLimit = 8
+			t.SetValue(host.Protocol)
+			t.Validate = protocolValidator
+		case inputTeleportCluster:
+			t.SetLabel(i18n.T("Teleport Cluster"))
+			t.CharLimit = 128
+			t.SetValue(host.TeleportCluster)
+		case inputKubeNamespace:
+			t.SetLabel(i18n.T("Namespace"))
+			t.CharLimit = 63
+			t.SetValue(host.KubeNamespace)
+			t.Validate = m.kubectlValidator
+		case inputKubePod:
+			t.SetLabel(i18n.T("Pod"))
+			t.CharLimit = 253
+			t.SetValue(host.KubePod)
+			t.Validate = m.kubectlValidator
+		case inputKubeContainer:
+			t.SetLabel(i18n.T("Container"))
+			t.CharLimit = 63
+			t.SetValue(host.KubeContainer)
 		case inputLogin:
-			t.SetLabel("Login")
+			t.SetLabel(i18n.T("Login"))
 			t.CharLimit = 128
 			t.SetValue(host.LoginName)
 		case inputNetworkPort:
-			t.SetLabel("Network Port")
+			t.SetLabel(i18n.T("Network Port"))
 			t.CharLimit = 5
 			t.SetValue(host.RemotePort)
 			t.Validate = networkPortValidator
 		case inputIdentityFile:
-			t.SetLabel("Identity File")
+			t.SetLabel(i18n.T("Identity File"))
 			t.CharLimit = 512
 			t.SetValue(host.IdentityFilePath)
+			t.Warn = identityFileWarning
+			t.CheckWarning()
+		case inputRemoteForwards:
+			t.SetLabel(i18n.T("Remote Forwards"))
+			t.CharLimit = 512
+			t.SetValue(m.host.getHostAttributeValueByIndex(inputRemoteForwards))
+			t.Validate = remoteForwardsValidator
+		case inputIdentitiesOnly:
+			t.SetLabel(i18n.T("Identities Only"))
+			t.SetValue(m.host.getHostAttributeValueByIndex(inputIdentitiesOnly))
+			t.SetOptions("no", "yes")
+		case inputForwardAgent:
+			t.SetLabel(i18n.T("Forward Agent"))
+			t.SetValue(m.host.getHostAttributeValueByIndex(inputForwardAgent))
+			t.SetOptions("no", "yes")
+		case inputCompression:
+			t.SetLabel(i18n.T("Compression"))
+			t.SetValue(m.host.getHostAttributeValueByIndex(inputCompression))
+			t.SetOptions("no", "yes")
+		case inputCompressionLevel:
+			t.SetLabel(i18n.T("Compression Level"))
+			t.CharLimit = 1
+			t.SetValue(host.CompressionLevel)
+			t.Validate = compressionLevelValidator
+			t.Warn = func(s string) error {
+				// Host is updated after the input, so the level is taken from the input value.
+				if !m.host.Compression && !utils.StringEmpty(s) {
+					return errors.New(i18n.T("ignored, compression is off"))
+				}
+
+				return nil
+			}
 		case inputPassword:
-			t.SetLabel("Password")
+			t.SetLabel(i18n.T("Password"))
 			t.CharLimit = 128
 			t.SetValue(host.Password)
+			// Password is masked, so it's not exposed when the screen is shared. See togglePasswordVisibility.
+			t.EchoMode = textinput.EchoPassword
+			t.EchoCharacter = '•'
+		case inputPasswordCommand:
+			t.SetLabel(i18n.T("Password Command"))
+			t.CharLimit = 512
+			t.SetValue(host.PasswordCommand)
+		case inputGatewayPorts:
+			t.SetLabel(i18n.T("Gateway Ports"))
+			t.CharLimit = 15
+			t.SetValue(host.GatewayPorts)
+			t.Validate = gatewayPortsValidator
+		case inputEscapeChar:
+			t.SetLabel(i18n.T("Escape Character"))
+			t.CharLimit = 4
+			t.SetValue(host.EscapeChar)
+			t.Validate = escapeCharValidator
+		case inputBindAddress:
+			t.SetLabel(i18n.T("Bind Address"))
+			t.CharLimit = 45
+			t.SetValue(host.BindAddress)
+			t.Validate = bindAddressValidator
+		case inputHostKeyAlias:
+			t.SetLabel(i18n.T("Host Key Alias"))
+			t.CharLimit = 128
+			t.SetValue(host.HostKeyAlias)
+			t.Validate = hostKeyAliasValidator
+		case inputStrictHostKeyChecking:
+			t.SetLabel(i18n.T("Strict Host Key Checking"))
+			t.SetValue(host.StrictHostKeyChecking)
+			t.Validate = strictHostKeyCheckingValidator
+			// Empty option leaves the decision to ssh, so the placeholder is displayed.
+			t.SetOptions("", "yes", "no", "accept-new")
+		case inputConnectTimeout:
+			t.SetLabel(i18n.T("Connect Timeout"))
+			t.CharLimit = 5
+			t.SetValue(host.ConnectTimeout)
+			t.Validate = connectTimeoutValidator
+		case inputKeepAliveInterval:
+			t.SetLabel(i18n.T("Keepalive Interval"))
+			t.CharLimit = 5
+			t.SetValue(host.KeepAliveInterval)
+			t.Validate = nonNegativeNumberValidator
+		case inputKeepAliveCountMax:
+			t.SetLabel(i18n.T("Keepalive Count Max"))
+			t.CharLimit = 5
+			t.SetValue(host.KeepAliveCountMax)
+			t.Validate = nonNegativeNumberValidator
+		case inputLocalForwards:
+			t.SetLabel(i18n.T("Local Forwards"))
+			t.CharLimit = 512
+			t.SetValue(m.host.getHostAttributeValueByIndex(inputLocalForwards))
+			t.Validate = localForwardsValidator
+		case inputDynamicForward:
+			t.SetLabel(i18n.T("Dynamic Forward"))
+			t.CharLimit = 5
+			t.SetValue(host.DynamicForward)
+			t.Validate = networkPortValidator
+		case inputBackgroundTunnel:
+			t.SetLabel(i18n.T("Background Tunnel"))
+			t.SetValue(m.host.getHostAttributeValueByIndex(inputBackgroundTunnel))
+			t.SetOptions("no", "yes")
+		case inputAutoConnect:
+			t.SetLabel(i18n.T("Auto Connect"))
+			t.SetValue(m.host.getHostAttributeValueByIndex(inputAutoConnect))
+			t.SetOptions("no", "yes")
+		case inputExtraOptions:
+			t.SetLabel(i18n.T("Extra Options"))
+			t.CharLimit = 1024
+			t.SetValue(m.host.getHostAttributeValueByIndex(inputExtraOptions))
+			t.Validate = extraOptionsValidator
+		case inputTermType:
+			t.SetLabel(i18n.T("Terminal Type"))
+			t.CharLimit = 64
+			t.SetValue(host.TermType)
+			t.Validate = termTypeValidator
+		case inputConnectTemplate:
+			t.SetLabel(i18n.T("Connect Template"))
+			t.CharLimit = 512
+			t.SetValue(host.ConnectTemplate)
+			t.Validate = connectTemplateValidator
 		}
 
 		m.inputs[i] = t
@@ -201,6 +828,10 @@ func (m *editModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateViewPort(msg)
 	case tea.KeyMsg:
 		cmd = m.handleKeyboardEvent(msg)
+		// Saved and discarded changes remove the draft, it must not be written again.
+		if !key.Matches(msg, m.keyMap.Save, m.keyMap.Discard) {
+			m.updateDraft()
+		}
 		m.viewport.SetContent(m.inputsView())
 	case debouncedMessage:
 		cmd = m.handleDebouncedMessage(msg)
@@ -220,14 +851,25 @@ func (m *editModel) View() string {
 		m.updateViewPort(nil)
 	}
 
-	viewPortContent := m.viewport.View()
+	if m.rawMode {
+		return m.rawView()
+	}
+
+	viewPortContent := m.withNotesPanel(m.viewport.View())
 	return fmt.Sprintf("%s\n%s\n%s", m.headerView(), viewPortContent, m.helpView())
 }
 
 func (m *editModel) handleKeyboardEvent(msg tea.KeyMsg) tea.Cmd {
 	// If title displays an error, due to an incorrect title for instance
 	// once user presses any button, we should reset it to default value
-	m.title = defaultTitle
+	m.title = i18n.T(defaultTitle)
+	if !key.Matches(msg, m.keyMap.Save) {
+		m.duplicateWarned = false
+	}
+
+	if m.rawMode {
+		return m.handleKeyEventInRawMode(msg)
+	}
 
 	switch {
 	case key.Matches(msg, m.keyMap.Save):
@@ -240,17 +882,46 @@ func (m *editModel) handleKeyboardEvent(msg tea.KeyMsg) tea.Cmd {
 		return m.inputFocusChange(msg)
 	case key.Matches(msg, m.keyMap.Discard):
 		m.logger.Info("[UI] Discard changes for host id: %v", m.host.ID)
+		m.removeDraft()
 		return message.TeaCmd(CloseEditForm{})
+	case key.Matches(msg, m.keyMap.CopyValue):
+		m.copyFocusedInputValue()
+		return nil
+	case key.Matches(msg, m.keyMap.CopyCommand):
+		m.copyConnectCommand()
+		return nil
+	case key.Matches(msg, m.keyMap.NotesPanel):
+		m.toggleNotesPanel()
+		return nil
+	case key.Matches(msg, m.keyMap.ShowPassword):
+		m.togglePasswordVisibility()
+		return nil
+	case key.Matches(msg, m.keyMap.RawYAML):
+		return m.enterRawMode()
+	case key.Matches(msg, m.keyMap.NextKey):
+		m.cycleIdentityFile()
+		return nil
+	case key.Matches(msg, m.keyMap.ErrorsFirst):
+		m.invalidInputsFirst = !m.invalidInputsFirst
+		m.logger.Debug("[UI] Display invalid inputs first: %v", m.invalidInputsFirst)
+		return nil
+	case key.Matches(msg, m.keyMap.Help):
+		return message.TeaCmd(message.OpenHelpOverlay{KeyBindings: m.keyMap.FullHelp()})
 	default:
 		// Handle all other key events
 		cmd := m.focusedInputProcessKeyEvent(msg)
-		if m.focusedInput == inputAddress || m.focusedInput == inputTitle {
+		if m.focusedInput == inputAddress || m.focusedInput == inputTitle || m.focusedInput == inputProtocol {
 			// This statement is required as user may want to copy title to address,
-			// if Host field contains a custom command, ssh options inputs
-			// should be disabled.
+			// if Host field contains a custom command or protocol is changed to
+			// Teleport, ssh options inputs should be disabled.
 			m.updateInputFields()
 		}
 
+		if m.focusedInput == inputCompression {
+			// Compression level is ignored when compression is off, the warning should be updated.
+			m.inputs[inputCompressionLevel].CheckWarning()
+		}
+
 		return cmd
 	}
 }
@@ -274,6 +945,9 @@ func (m *editModel) handleDebouncedMessage(msg debouncedMessage) tea.Cmd {
 }
 
 func (m *editModel) save(_ tea.Msg) tea.Cmd {
+	m.saveFailed = false
+	m.trimHostAttributes()
+
 	for i := range m.inputs {
 		if m.inputs[i].Validate != nil {
 			if err := m.inputs[i].Validate(m.inputs[i].Value()); err != nil {
@@ -284,13 +958,91 @@ func (m *editModel) save(_ tea.Msg) tea.Cmd {
 					err.Error(),
 				)
 				m.inputs[i].Err = err
-				m.title = fmt.Sprintf("%s is not valid", m.inputs[i].Label())
-
-				return nil
+				// Keep validating, so all invalid inputs are highlighted, but report the first one.
+				if !m.saveFailed {
+					m.title = i18n.Tf("%s is not valid", m.inputs[i].Label())
+				}
+				m.saveFailed = true
 			}
 		}
 	}
 
+	if m.saveFailed {
+		return nil
+	}
+
+	// Validators check fields one by one, but connect command is built from several fields.
+	connectTarget := hostModel.Host{
+		Address:  m.inputs[inputAddress].Value(),
+		Protocol: m.inputs[inputProtocol].Value(),
+		KubePod:  m.inputs[inputKubePod].Value(),
+	}
+	if !connectTarget.HasDestination() {
+		m.logger.Info("[UI] Cannot save host with id %v. Reason: connect command has no destination", m.host.ID)
+		m.inputs[inputAddress].Err = errors.New(i18n.T("host address is required to build connect command"))
+		m.title = i18n.T("cannot save host, connect command is empty")
+		m.saveFailed = true
+
+		return nil
+	}
+
+	// Unlike connection target, alias must be unique, because it identifies the host in quick connect box.
+	if err := storage.CheckAliasUnique(m.hostStorage, m.host.unwrap()); errors.Is(err, storage.ErrAliasTaken) {
+		m.logger.Info("[UI] Cannot save host with id %v. Reason: %v", m.host.ID, err)
+		m.inputs[inputAlias].Err = errors.New(i18n.T("alias is used by another host"))
+		m.title = i18n.Tf("%s is not valid", m.inputs[inputAlias].Label())
+		m.saveFailed = true
+
+		return nil
+	}
+
+	// Forward presets are edited in the hosts file, but ssh fails to connect if a preset binds a local port twice.
+	for _, name := range m.host.ForwardPresetNames() {
+		if port, ok := hostModel.DuplicateLocalPort(m.host.ForwardPresets[name]); ok {
+			m.logger.Info("[UI] Cannot save host with id %v. Reason: forward preset '%s' binds port %s twice",
+				m.host.ID, name, port)
+			m.title = i18n.Tf("forward preset '%s' binds local port %s more than once", name, port)
+			m.saveFailed = true
+
+			return nil
+		}
+
+		// Local forwards of the host are applied together with the preset.
+		forwards := append(slices.Clone(m.host.LocalForwards), m.host.ForwardPresets[name]...)
+		if port, ok := hostModel.DuplicateLocalPort(forwards); ok {
+			m.logger.Info("[UI] Cannot save host with id %v. Reason: forward preset '%s' and local forwards bind port %s",
+				m.host.ID, name, port)
+			m.inputs[inputLocalForwards].Err = errors.New(i18n.Tf("local port %s is forwarded more than once", port))
+			m.title = i18n.Tf("preset '%s' and local forwards bind port %s", name, port)
+			m.saveFailed = true
+
+			return nil
+		}
+	}
+
+	// Scratch host lives in memory only, host list keeps it until the application exits.
+	if m.host.IsScratch() {
+		m.logger.Info("[UI] Update scratch host, it's not stored")
+		return tea.Sequence(
+			message.TeaCmd(CloseEditForm{}),
+			message.TeaCmd(message.HostUpdated{Host: m.host.unwrap()}),
+		)
+	}
+
+	// Duplicates are most likely accidental, but that's not a reason to reject the changes. User must confirm them.
+	if duplicate, found := m.findDuplicateTarget(); found && !m.duplicateWarned {
+		m.logger.Info("[UI] Host id: %v has the same connection target as host id: %v. Ask for confirmation",
+			m.host.ID, duplicate.ID)
+		m.title = i18n.Tf("\"%s\" has the same address and login, press %s again to save",
+			duplicate.Title, m.keyMap.Save.Help().Key)
+		m.duplicateWarned = true
+
+		return nil
+	}
+	m.duplicateWarned = false
+
+	// Draft of a new host is stored with id 0, so it must be removed before the host gets its id.
+	m.removeDraft()
 	host, _ := m.hostStorage.Save(m.host.unwrap())
 	// Need to check storage error and update application status:
 	// if err != nil { return message.TeaCmd(message.Error{StdErr: err}) }
@@ -301,7 +1053,7 @@ func (m *editModel) save(_ tea.Msg) tea.Cmd {
 		message.TeaCmd(message.HostCreated{Host: host}),
 		message.TeaCmd(message.HostUpdated{Host: host}))
 
-	return tea.Sequence(
+	cmds := []tea.Cmd{
 		message.TeaCmd(CloseEditForm{}),
 		// Order matters here! That's why we use tea.Sequence instead of tea.Batch.
 		// 'HostListSelectItem' message should be dispatched
@@ -310,12 +1062,141 @@ func (m *editModel) save(_ tea.Msg) tea.Cmd {
 		message.TeaCmd(message.HostListSelectItem{HostID: host.ID}),
 		// message.TeaCmd(hostlist.MsgRefreshRepo{}),
 		cmd,
-	)
+	}
+
+	return tea.Sequence(cmds...)
+}
+
+// connectCommand - returns shell-escaped connect command which is built from the current, possibly unsaved,
+// input values. Password is redacted, because the command is supposed to be shared or pasted into a terminal.
+func (m *editModel) connectCommand() string {
+	host := m.host.unwrap()
+	command := hostModel.RedactPassword(host.CmdSSHConnect(), host.Password)
+	// Replace Windows ssh prefix "cmd /c ssh" with "ssh"
+	command = strings.Replace(command, "cmd /c ", "", 1)
+
+	return utils.ShellEscape(command)
+}
+
+func (m *editModel) copyConnectCommand() {
+	command := m.connectCommand()
+	if err := clipboardWriteAll(command); err != nil {
+		m.logger.Info("[UI] Cannot copy connect command to clipboard. %v", err)
+		m.title = i18n.T("cannot copy command to clipboard")
+		return
+	}
+
+	m.logger.Debug("[UI] Copy connect command to clipboard: %s", command)
+	m.title = i18n.T("command copied to clipboard")
+}
+
+// copyFocusedInputValue - copies value of the focused input to clipboard, for instance a long identity file path.
+func (m *editModel) copyFocusedInputValue() {
+	value := m.inputs[m.focusedInput].Value()
+	if value == "" {
+		m.title = i18n.T("value is empty")
+		return
+	}
+
+	if err := clipboardWriteAll(value); err != nil {
+		m.logger.Info("[UI] Cannot copy value of input '%s' to clipboard. %v", m.inputs[m.focusedInput].Label(), err)
+		m.title = i18n.T("cannot copy value to clipboard")
+		return
+	}
+
+	m.logger.Debug("[UI] Copy value of input '%s' to clipboard", m.inputs[m.focusedInput].Label())
+	m.title = i18n.T("value copied to clipboard")
+}
+
+// togglePasswordVisibility - shows or hides characters of the password input.
+func (m *editModel) togglePasswordVisibility() {
+	password := &m.inputs[inputPassword]
+	password.EchoMode = lo.Ternary(password.EchoMode == textinput.EchoPassword, textinput.EchoNormal, textinput.EchoPassword)
+	m.logger.Debug("[UI] Display password: %v", password.EchoMode == textinput.EchoNormal)
+}
+
+// trimHostAttributes - removes leading and trailing whitespaces from host attributes, because
+// trailing spaces in addresses and paths cause connection failures which are hard to spot.
+// Passwords and custom connect strings are preserved as is.
+func (m *editModel) trimHostAttributes() {
+	for i := range m.inputs {
+		if i == inputPassword || (i == inputAddress && m.host.IsUserDefinedSSHCommand()) {
+			continue
+		}
+
+		value := m.host.getHostAttributeValueByIndex(i)
+		if trimmed := strings.TrimSpace(value); trimmed != value {
+			m.logger.Debug("[UI] Trim whitespaces of '%s' value", m.inputs[i].Label())
+			m.host.setHostAttributeByIndex(i, trimmed)
+			if m.inputs[i].Enabled() {
+				m.inputs[i].SetValue(trimmed)
+			}
+		}
+	}
+}
+
+// findDuplicateTarget - looks for another host which connects to the same target as the edited one. Hosts with
+// the same address and login name are found in the storage, then their ports are compared, see sameConnectionTarget.
+func (m *editModel) findDuplicateTarget() (hostModel.Host, bool) {
+	edited := m.host.unwrap()
+	hosts, err := m.hostStorage.FindByAddress(edited.Address, edited.LoginName)
+	if err != nil {
+		m.logger.Debug("[UI] Cannot check for duplicate hosts. %v", err)
+		return hostModel.Host{}, false
+	}
+
+	return lo.Find(hosts, func(h hostModel.Host) bool {
+		// Edited host is already in the storage, it's not a duplicate of itself.
+		if !m.isNewHost && h.ID == edited.ID {
+			return false
+		}
+
+		return sameConnectionTarget(h, edited)
+	})
+}
+
+// sameConnectionTarget - returns true if both hosts connect to the same address and port using the same login name.
+func sameConnectionTarget(a, b hostModel.Host) bool {
+	if a.IsKubectl() || b.IsKubectl() {
+		return a.IsKubectl() == b.IsKubectl() &&
+			strings.TrimSpace(a.KubeNamespace) == strings.TrimSpace(b.KubeNamespace) &&
+			strings.TrimSpace(a.KubePod) == strings.TrimSpace(b.KubePod) &&
+			strings.TrimSpace(a.KubeContainer) == strings.TrimSpace(b.KubeContainer)
+	}
+
+	if a.SSHAlias != "" || b.SSHAlias != "" {
+		return strings.TrimSpace(a.SSHAlias) == strings.TrimSpace(b.SSHAlias)
+	}
+
+	addressA := utils.RemoveDuplicateSpaces(strings.TrimSpace(a.Address))
+	addressB := utils.RemoveDuplicateSpaces(strings.TrimSpace(b.Address))
+	if a.IsUserDefinedSSHCommand() || b.IsUserDefinedSSHCommand() {
+		// Custom connect strings are compared as is, as other parameters are ignored.
+		return addressA == addressB
+	}
+
+	// Empty port means that ssh uses the default one.
+	portA := lo.Ternary(utils.StringEmpty(a.RemotePort), "22", strings.TrimSpace(a.RemotePort))
+	portB := lo.Ternary(utils.StringEmpty(b.RemotePort), "22", strings.TrimSpace(b.RemotePort))
+
+	return strings.EqualFold(addressA, addressB) &&
+		portA == portB &&
+		strings.TrimSpace(a.LoginName) == strings.TrimSpace(b.LoginName)
 }
 
 func (m *editModel) copyInputValueFromTo(sourceInput, destinationInput int) {
 	newValue := m.inputs[sourceInput].Value()
+	m.setInputValue(destinationInput, newValue)
+	m.logger.Debug(
+		"[UI] Copy '%s' value to '%s', new value = %s",
+		m.inputs[sourceInput].Label(),
+		m.inputs[destinationInput].Label(),
+		newValue,
+	)
+}
 
+// setInputValue - sets value of the input which is not focused and updates the model.
+func (m *editModel) setInputValue(destinationInput int, newValue string) {
 	// Temporary remove input validator.
 	// It's necessary, because input.SetValue(...) invokes Validate function,
 	// if the input contains invalid value, Validate function returns error and
@@ -328,13 +1209,9 @@ func (m *editModel) copyInputValueFromTo(sourceInput, destinationInput int) {
 	m.inputs[destinationInput].SetValue(newValue)
 	m.inputs[destinationInput].SetCursor(len(newValue))
 	m.inputs[destinationInput].Validate = validator
-	m.inputs[destinationInput].Err = m.inputs[destinationInput].Validate(newValue)
-	m.logger.Debug(
-		"[UI] Copy '%s' value to '%s', new value = %s",
-		m.inputs[sourceInput].Label(),
-		m.inputs[destinationInput].Label(),
-		newValue,
-	)
+	if validator != nil {
+		m.inputs[destinationInput].Err = validator(newValue)
+	}
 
 	// Update the model as well
 	m.host.setHostAttributeByIndex(destinationInput, newValue)
@@ -366,6 +1243,11 @@ func (m *editModel) focusedInputProcessKeyEvent(msg tea.Msg) tea.Cmd {
 		m.copyInputValueFromTo(inputTitle, inputAddress)
 	}
 
+	// When user changes the title manually, we should not overwrite it anymore.
+	if m.focusedInput == inputTitle && previousValue != m.inputs[inputTitle].Value() {
+		m.titleDerived = false
+	}
+
 	// When change UI field, update the model as well
 	m.host.setHostAttributeByIndex(m.focusedInput, m.inputs[m.focusedInput].Value())
 
@@ -375,6 +1257,8 @@ func (m *editModel) focusedInputProcessKeyEvent(msg tea.Msg) tea.Cmd {
 
 		// And value changed
 		if previousValue != currentValue {
+			m.deriveTitleFromAddress()
+
 			// Load SSH config for the specified hostname
 			cmd = message.TeaCmd(debouncedMessage{
 				wrappedMsg:  message.RunProcessSSHLoadConfig{Host: *m.host.Host},
@@ -386,17 +1270,65 @@ func (m *editModel) focusedInputProcessKeyEvent(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
+// cycleIdentityFile - replaces identity file with the next private key found in ~/.ssh folder.
+func (m *editModel) cycleIdentityFile() {
+	if m.identityFiles == nil {
+		keysFolder := utils.ExpandTilde(sshKeysFolder)
+		files, err := utils.FindPrivateKeys(keysFolder)
+		if err != nil {
+			m.logger.Info("[UI] Cannot read private keys from '%s'. %v", keysFolder, err)
+			m.title = i18n.Tf("cannot read private keys from %s", sshKeysFolder)
+			return
+		}
+
+		m.logger.Debug("[UI] Found %d private keys in '%s'", len(files), keysFolder)
+		m.identityFiles = files
+	}
+
+	if len(m.identityFiles) == 0 {
+		m.title = i18n.Tf("no private keys found in %s", sshKeysFolder)
+		return
+	}
+
+	next := 0
+	current := utils.ExpandTilde(strings.TrimSpace(m.inputs[inputIdentityFile].Value()))
+	if index := lo.IndexOf(m.identityFiles, current); index >= 0 {
+		next = (index + 1) % len(m.identityFiles)
+	}
+
+	m.setInputValue(inputIdentityFile, m.identityFiles[next])
+	m.inputs[inputIdentityFile].CheckWarning()
+}
+
+// deriveTitleFromAddress - generates title of a new host from its address unless user set the title manually.
+func (m *editModel) deriveTitleFromAddress() {
+	titleIsEmpty := utils.StringEmpty(m.inputs[inputTitle].Value())
+	if !m.isNewHost || (!titleIsEmpty && !m.titleDerived) {
+		return
+	}
+
+	title := deriveTitle(m.inputs[inputAddress].Value(), m.appState.ApplicationConfig.TitleDerivation)
+	m.setInputValue(inputTitle, title)
+	m.titleDerived = true
+	m.logger.Debug("[UI] Derive title '%s' from host address", title)
+}
+
 func (m *editModel) updateViewPort(msg tea.Msg) {
 	headerHeight := lipgloss.Height(m.headerView())
 	helpMenuHeight := lipgloss.Height(m.helpView())
 
 	if !m.ready {
 		m.ready = true
-		m.viewport = viewport.New(m.appState.Width, m.appState.Height-headerHeight-helpMenuHeight)
+		m.viewport = viewport.New(m.inputsViewSize(m.appState.Width, m.appState.Height-headerHeight-helpMenuHeight))
 		m.viewport.SetContent(m.inputsView())
 	} else if resizeMsg, ok := msg.(tea.WindowSizeMsg); ok {
-		m.viewport.Width = resizeMsg.Width
-		m.viewport.Height = resizeMsg.Height - headerHeight - helpMenuHeight
+		// Raw editor is displayed without notes panel, so it takes the whole area.
+		width, height := resizeMsg.Width, resizeMsg.Height-headerHeight-helpMenuHeight
+		m.viewport.Width, m.viewport.Height = m.inputsViewSize(width, height)
+		if m.rawMode {
+			m.rawEditor.SetWidth(width)
+			m.rawEditor.SetHeight(height)
+		}
 		m.logger.Debug("[UI] Set edit host viewport size: %d %d", m.viewport.Width, m.viewport.Height)
 	}
 }
@@ -405,48 +1337,58 @@ func (m *editModel) inputFocusChange(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 	keyMsg := msg.(tea.KeyMsg)
 
-	enabledInputs := lo.Filter(m.inputs, func(i input.Input, n int) bool {
-		return i.Enabled()
+	// Control viewport manually because height of input element is greater than one
+	// therefore, we need to scroll several lines at once instead of just a single line.
+	// Normally we don't need to handle scroll events, other than forward app messages to
+	// the viewport: m.viewport, cmd = m.viewport.Update(msg)
+	inputHeights := lo.Map(m.inputs, func(i input.Input, _ int) int {
+		// One extra line is a separator between inputs, see inputsView.
+		return lipgloss.Height(i.View()) + 1
 	})
 
-	minFocusIndex := 0
-	// maxFocusIndex is equal to number of inputs minus the number
-	// of disabled inputs. This works based on an assumption that
-	// all disabled inputs will be in the bottom of the hostlist.
-	maxFocusIndex := len(enabledInputs) - 1
-	inputHeight := 0
-
-	if len(m.inputs) > 0 {
-		// Control viewport manually because height of input element is greater than one
-		// therefore, we need to scroll several lines at once instead of just a single line.
-		// Normally we don't need to handle scroll events, other than forward app messages to
-		// the viewport: m.viewport, cmd = m.viewport.Update(msg)
-		inputHeight = lipgloss.Height(m.inputsView()) / len(m.inputs)
-	}
-
-	// Update index of the focused element
-	if key.Matches(keyMsg, m.keyMap.Up) && m.focusedInput > minFocusIndex { //nolint:gocritic // it's better without switch
-		m.focusedInput--
-		m.viewport.LineUp(inputHeight)
-	} else if key.Matches(keyMsg, m.keyMap.Down) && m.focusedInput < maxFocusIndex {
-		m.focusedInput++
-		m.viewport.LineDown(inputHeight)
+	// Disabled inputs are skipped, they can be located anywhere in the form.
+	nextFocusedInput := m.focusedInput
+	if key.Matches(keyMsg, m.keyMap.Up) {
+		for i := m.focusedInput - 1; i >= 0; i-- {
+			if m.inputs[i].Enabled() {
+				nextFocusedInput = i
+				break
+			}
+		}
 	} else {
+		for i := m.focusedInput + 1; i < len(m.inputs); i++ {
+			if m.inputs[i].Enabled() {
+				nextFocusedInput = i
+				break
+			}
+		}
+	}
+
+	if nextFocusedInput == m.focusedInput {
 		m.logger.Debug("[UI] Reached first or last selectable input field: %d", m.focusedInput)
 		return nil
 	}
 
+	// Skipped inputs are not displayed, so the viewport is scrolled by the step of adjacent enabled inputs.
+	if nextFocusedInput < m.focusedInput {
+		m.viewport.LineUp(scrollStep(inputHeights, m.focusedInput, nextFocusedInput, m.viewport.Height))
+	} else {
+		m.viewport.LineDown(scrollStep(inputHeights, m.focusedInput, nextFocusedInput, m.viewport.Height))
+	}
+	m.focusedInput = nextFocusedInput
+
 	// Should be extracted to "Validate" function
 	for i := 0; i <= len(m.inputs)-1; i++ {
 		if m.inputs[i].Validate != nil {
 			m.inputs[i].Err = m.inputs[i].Validate(m.inputs[i].Value())
 			m.logger.Debug("[UI] Input '%v' is valid: %v", m.inputs[i].Label(), m.inputs[i].Err == nil)
 		}
+		m.inputs[i].CheckWarning()
 
 		if i == m.focusedInput {
 			// KeyMap depends on focused input - when address is focused, we allow
 			// a user to copy address value to title.
-			m.keyMap = getKeyMap(i)
+			m.keyMap = getKeyMap(i, len(m.inputs[i].Options()) > 0)
 			m.logger.Debug("[UI] Focus input: '%s'", m.inputs[i].Label())
 
 			// Set focused state
@@ -460,6 +1402,21 @@ func (m *editModel) inputFocusChange(msg tea.Msg) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// scrollStep - returns number of lines which viewport should be scrolled by, when focus moves from
+// one input to an adjacent one. When moving down, the step is equal to the height of the input which
+// loses focus, when moving up - to the height of the input which receives focus. For tall inputs the step
+// never exceeds half of the viewport height, otherwise the focused input may be scrolled out of the view.
+func scrollStep(inputHeights []int, from, to, viewportHeight int) int {
+	if from < 0 || to < 0 || from >= len(inputHeights) || to >= len(inputHeights) {
+		return 0
+	}
+
+	step := lo.Ternary(to > from, inputHeights[from], inputHeights[to])
+	maxStep := max(1, viewportHeight/2)
+
+	return min(step, maxStep)
+}
+
 func (m *editModel) handleCopyInputValueShortcut() {
 	// Allow a user to copy values between address and title,
 	// because the chances are that these two inputs will have
@@ -473,32 +1430,65 @@ func (m *editModel) handleCopyInputValueShortcut() {
 
 func (m *editModel) updateInputFields() {
 	customConnectString := m.host.IsUserDefinedSSHCommand()
-	m.logger.Debug("[UI] Update input components. Additional SSH parameters disabled: %v", customConnectString)
-
-	prefix := lo.Ternary(customConnectString, "readonly", "default")
-	m.inputs[inputTitle].Placeholder = "*required*" //nolint:goconst
-	m.inputs[inputAddress].Placeholder = "*required*"
-	m.inputs[inputDescription].Placeholder = "n/a"
-	m.inputs[inputLogin].Placeholder = fmt.Sprintf("%s: %s", prefix, m.host.SSHClientConfig.User)
-	m.inputs[inputNetworkPort].Placeholder = fmt.Sprintf("%s: %s", prefix, m.host.SSHClientConfig.Port)
-	m.inputs[inputIdentityFile].Placeholder = fmt.Sprintf("%s: %s", prefix, m.host.SSHClientConfig.IdentityFile)
-	m.inputs[inputPassword].Placeholder = "Password"
-
-	hostInputLabel := lo.Ternary(customConnectString, "Command", "Host")
+	teleport := m.host.IsTeleport()
+	kubectl := m.host.IsKubectl()
+	m.logger.Debug(
+		"[UI] Update input components. Additional SSH parameters disabled: %v, Teleport: %v, Kubectl: %v",
+		customConnectString,
+		teleport,
+		kubectl,
+	)
+
+	m.updateInputPlaceholders()
+
+	hostInputLabel := lo.Ternary(customConnectString, i18n.T("Command"), i18n.T("Host"))
 	m.inputs[inputAddress].SetLabel(hostInputLabel)
 	m.inputs[inputAddress].SetDisplayTooltip(customConnectString)
 
 	sshParamsInputFields := []*input.Input{
 		&m.inputs[inputLogin],
 		&m.inputs[inputNetworkPort],
+	}
+
+	lo.ForEach(sshParamsInputFields, func(i *input.Input, n int) {
+		i.SetEnabled(!customConnectString && !kubectl)
+	})
+
+	// Keys, passwords and ssh options are managed by Teleport.
+	teleportManagedInputFields := []*input.Input{
 		&m.inputs[inputIdentityFile],
+		&m.inputs[inputRemoteForwards],
+		&m.inputs[inputIdentitiesOnly],
+		&m.inputs[inputForwardAgent],
+		&m.inputs[inputCompression],
+		&m.inputs[inputCompressionLevel],
 		&m.inputs[inputPassword],
+		&m.inputs[inputPasswordCommand],
+		&m.inputs[inputGatewayPorts],
+		&m.inputs[inputEscapeChar],
+		&m.inputs[inputBindAddress],
+		&m.inputs[inputHostKeyAlias],
+		&m.inputs[inputStrictHostKeyChecking],
+		&m.inputs[inputConnectTimeout],
+		&m.inputs[inputKeepAliveInterval],
+		&m.inputs[inputKeepAliveCountMax],
+		&m.inputs[inputLocalForwards],
+		&m.inputs[inputDynamicForward],
+		&m.inputs[inputExtraOptions],
 	}
 
-	lo.ForEach(sshParamsInputFields, func(i *input.Input, n int) {
-		i.SetEnabled(!customConnectString)
+	lo.ForEach(teleportManagedInputFields, func(i *input.Input, n int) {
+		i.SetEnabled(!customConnectString && !teleport && !kubectl)
 	})
 
+	// Background flags are added to custom connect strings as well.
+	m.inputs[inputBackgroundTunnel].SetEnabled(!teleport && !kubectl)
+	m.inputs[inputAutoConnect].SetEnabled(!teleport && !kubectl)
+	m.inputs[inputTeleportCluster].SetEnabled(teleport)
+	m.inputs[inputKubeNamespace].SetEnabled(kubectl)
+	m.inputs[inputKubePod].SetEnabled(kubectl)
+	m.inputs[inputKubeContainer].SetEnabled(kubectl)
+
 	lo.ForEach(m.inputs, func(i input.Input, n int) {
 		if m.inputs[n].Enabled() {
 			m.inputs[n].SetValue(m.host.getHostAttributeValueByIndex(n))
@@ -506,13 +1496,43 @@ func (m *editModel) updateInputFields() {
 			m.inputs[n].SetValue("")
 		}
 	})
+
+	m.inputs[inputCompressionLevel].CheckWarning()
+}
+
+// inputsOrder - returns indexes of inputs in the order they should be displayed. Only visual order
+// is affected, inputs are always addressed by their original indexes.
+func (m *editModel) inputsOrder() (order []int, invalidCount int) {
+	order = make([]int, 0, len(m.inputs))
+	if m.invalidInputsFirst && m.saveFailed {
+		for i := range m.inputs {
+			if m.inputs[i].Err != nil {
+				order = append(order, i)
+			}
+		}
+	}
+
+	invalidCount = len(order)
+	for i := range m.inputs {
+		if !lo.Contains(order[:invalidCount], i) {
+			order = append(order, i)
+		}
+	}
+
+	return order, invalidCount
 }
 
 func (m *editModel) inputsView() string {
 	var b strings.Builder
-	for i := range m.inputs {
+	order, invalidCount := m.inputsOrder()
+	for n, i := range order {
+		if invalidCount > 0 && n == invalidCount {
+			b.WriteString(separatorStyle.Render("────────────────"))
+			b.WriteString("\n\n")
+		}
+
 		b.WriteString(m.inputs[i].View())
-		if i < len(m.inputs) {
+		if n < len(order) {
 			b.WriteString("\n\n")
 		}
 	}
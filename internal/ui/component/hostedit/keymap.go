@@ -2,6 +2,8 @@ package hostedit
 
 import (
 	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/grafviktor/goto/internal/i18n"
 )
 
 type keyMap struct {
@@ -9,36 +11,85 @@ type keyMap struct {
 	Down           key.Binding
 	Save           key.Binding
 	CopyInputValue key.Binding
+	CopyValue      key.Binding
+	CopyCommand    key.Binding
 	Discard        key.Binding
+	ErrorsFirst    key.Binding
+	RawYAML        key.Binding
+	NotesPanel     key.Binding
+	ShowPassword   key.Binding
+	NextKey        key.Binding
+	Help           key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Save, k.CopyInputValue, k.Discard}
+	return []key.Binding{k.Up, k.Down, k.Save, k.CopyInputValue, k.NextKey, k.Discard, k.Help}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
-	return nil
+	return [][]key.Binding{
+		{k.Up, k.Down, k.CopyInputValue, k.NextKey, k.CopyValue, k.CopyCommand, k.RawYAML, k.NotesPanel, k.ShowPassword},
+		{k.Save, k.Discard, k.ErrorsFirst, k.Help},
+	}
 }
 
-var keys = keyMap{
-	Up: key.NewBinding(
-		key.WithKeys("up", "shift+tab"),
-		key.WithHelp("↑", "up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "tab", "enter"),
-		key.WithHelp("↓", "down"),
-	),
-	Save: key.NewBinding(
-		key.WithKeys("ctrl+s"),
-		key.WithHelp("ctrl+s", "save"),
-	),
-	CopyInputValue: key.NewBinding(
-		key.WithKeys("alt+enter"),
-		key.WithHelp("alt+enter", "title ↔ host"),
-	),
-	Discard: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "discard"),
-	),
+// newKeyMap - creates key bindings. Help descriptions are translated, so it should be called
+// after the language is selected.
+func newKeyMap() keyMap {
+	return keyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "shift+tab"),
+			key.WithHelp("↑", i18n.T("up")),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "tab", "enter"),
+			key.WithHelp("↓", i18n.T("down")),
+		),
+		Save: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", i18n.T("save")),
+		),
+		CopyInputValue: key.NewBinding(
+			key.WithKeys("alt+enter"),
+			key.WithHelp("alt+enter", i18n.T("title ↔ host")),
+		),
+		CopyValue: key.NewBinding(
+			key.WithKeys("alt+y"),
+			key.WithHelp("alt+y", i18n.T("copy value")),
+		),
+		CopyCommand: key.NewBinding(
+			key.WithKeys("ctrl+y"),
+			key.WithHelp("ctrl+y", i18n.T("copy command")),
+		),
+		Discard: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", i18n.T("discard")),
+		),
+		ErrorsFirst: key.NewBinding(
+			key.WithKeys("ctrl+e"),
+			key.WithHelp("ctrl+e", i18n.T("invalid fields first")),
+		),
+		NextKey: key.NewBinding(
+			key.WithKeys("ctrl+n"),
+			key.WithHelp("ctrl+n", i18n.T("next key from ~/.ssh")),
+		),
+		RawYAML: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", i18n.T("form ↔ yaml")),
+		),
+		NotesPanel: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", i18n.T("notes panel")),
+		),
+		ShowPassword: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", i18n.T("show password")),
+		),
+		// Question mark can be a part of input value, that's why only function key is used here.
+		// Selectors also accept question mark, see getKeyMap.
+		Help: key.NewBinding(
+			key.WithKeys("f1"),
+			key.WithHelp("f1", i18n.T("help")),
+		),
+	}
 }
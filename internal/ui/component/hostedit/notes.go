@@ -0,0 +1,107 @@
+package hostedit
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/grafviktor/goto/internal/i18n"
+	"github.com/grafviktor/goto/internal/utils"
+)
+
+type panelLayout int
+
+const (
+	// panelLayoutSplit - notes panel is displayed to the right of the inputs.
+	panelLayoutSplit panelLayout = iota
+	// panelLayoutStacked - notes panel is displayed above the inputs, it's used in narrow terminals.
+	panelLayoutStacked
+)
+
+const (
+	// notesPanelWidth - width of the notes panel in split layout, including the border and padding.
+	notesPanelWidth = 40
+	// notesPanelMinInputsWidth - inputs need at least this width to be displayed next to the notes panel.
+	notesPanelMinInputsWidth = 60
+	// notesPanelMaxStackedHeight - notes panel takes at most this number of lines in stacked layout.
+	notesPanelMaxStackedHeight = 6
+)
+
+// notesPanelLayout - returns layout of the notes panel for the terminal width. The panel is displayed next
+// to the inputs only when there is enough room for both of them.
+func notesPanelLayout(width int) panelLayout {
+	if width >= notesPanelWidth+notesPanelMinInputsWidth {
+		return panelLayoutSplit
+	}
+
+	return panelLayoutStacked
+}
+
+// toggleNotesPanel - shows or hides read-only panel with description and notes of the host.
+func (m *editModel) toggleNotesPanel() {
+	m.notesPanel = !m.notesPanel
+	m.logger.Debug("[UI] Display notes panel: %v", m.notesPanel)
+	m.updateViewPort(tea.WindowSizeMsg{Width: m.appState.Width, Height: m.appState.Height})
+}
+
+// inputsViewSize - returns the size of the inputs area, notes panel takes a part of the screen when it's displayed.
+func (m *editModel) inputsViewSize(width, height int) (int, int) {
+	if !m.notesPanel {
+		return width, height
+	}
+
+	if notesPanelLayout(width) == panelLayoutSplit {
+		return width - notesPanelWidth, height
+	}
+
+	return width, height - lipgloss.Height(m.notesPanelView(width))
+}
+
+// notesPanelView - renders saved description and notes of the host. Values are taken from the hosts file,
+// so they are not affected by the changes which are made in the form.
+func (m *editModel) notesPanelView(width int) string {
+	content := strings.Join([]string{
+		notesPanelLabelStyle.Render(i18n.T("Description")),
+		valueOrNotAvailable(m.savedDescription),
+		"",
+		notesPanelLabelStyle.Render(i18n.T("Notes")),
+		valueOrNotAvailable(m.savedNotes),
+	}, "\n")
+
+	split := notesPanelLayout(width) == panelLayoutSplit
+	if split {
+		width = notesPanelWidth
+	}
+
+	content = lipgloss.NewStyle().Width(width - notesPanelStyle.GetHorizontalFrameSize()).Render(content)
+	if !split {
+		// Long notes are cut, otherwise there would be no room for the inputs.
+		content = lipgloss.NewStyle().MaxHeight(notesPanelMaxStackedHeight).Render(content)
+	}
+
+	return notesPanelStyle.Render(content)
+}
+
+// withNotesPanel - places the notes panel next to or above the inputs.
+func (m *editModel) withNotesPanel(inputsView string) string {
+	if !m.notesPanel {
+		return inputsView
+	}
+
+	panel := m.notesPanelView(m.appState.Width)
+	if notesPanelLayout(m.appState.Width) == panelLayoutSplit {
+		return lipgloss.JoinHorizontal(lipgloss.Top, inputsView, panel)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, panel, inputsView)
+}
+
+// valueOrNotAvailable - returns "n/a" for an empty value, so the panel doesn't look broken.
+func valueOrNotAvailable(value string) string {
+	if utils.StringEmpty(value) {
+		return "n/a"
+	}
+
+	return value
+}
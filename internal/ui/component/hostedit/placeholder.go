@@ -0,0 +1,159 @@
+package hostedit
+
+import (
+	"strings"
+	"text/template"
+
+	hostModel "github.com/grafviktor/goto/internal/model/host"
+)
+
+// placeholderData is passed to a placeholder template.
+// Value - is a value which is used when the input is empty, for instance a login name from ssh config.
+// ReadOnly - is set when the input is disabled, because the host uses a custom connect string.
+// Source - is a place in ssh config file where the value is defined, empty if it's unknown. Ex: "~/.ssh/config:12".
+type placeholderData struct {
+	Value    string
+	ReadOnly bool
+	Source   string
+}
+
+const sshParameterPlaceholder = "{{if .ReadOnly}}readonly{{else}}default{{end}}: {{.Value}}" +
+	"{{with .Source}} ({{.}}){{end}}"
+
+// placeholderNames - are keys which are used to override placeholder templates in the user config.
+var placeholderNames = map[int]string{
+	inputTitle:                 "title",
+	inputAddress:               "address",
+	inputDescription:           "description",
+	inputGroup:                 "group",
+	inputTags:                  "tags",
+	inputAlias:                 "alias",
+	inputWebURL:                "web_url",
+	inputBanner:                "banner",
+	inputNotes:                 "notes",
+	inputProtocol:              "protocol",
+	inputTeleportCluster:       "teleport_cluster",
+	inputKubeNamespace:         "kube_namespace",
+	inputKubePod:               "kube_pod",
+	inputKubeContainer:         "kube_container",
+	inputLogin:                 "login",
+	inputNetworkPort:           "network_port",
+	inputIdentityFile:          "identity_file",
+	inputRemoteForwards:        "remote_forwards",
+	inputIdentitiesOnly:        "identities_only",
+	inputForwardAgent:          "forward_agent",
+	inputCompression:           "compression",
+	inputCompressionLevel:      "compression_level",
+	inputPassword:              "password",
+	inputPasswordCommand:       "password_command",
+	inputGatewayPorts:          "gateway_ports",
+	inputEscapeChar:            "escape_char",
+	inputBindAddress:           "bind_address",
+	inputHostKeyAlias:          "host_key_alias",
+	inputStrictHostKeyChecking: "strict_host_key_checking",
+	inputConnectTimeout:        "connect_timeout",
+	inputKeepAliveInterval:     "keep_alive_interval",
+	inputKeepAliveCountMax:     "keep_alive_count_max",
+	inputLocalForwards:         "local_forwards",
+	inputDynamicForward:        "dynamic_forward",
+	inputBackgroundTunnel:      "background_tunnel",
+	inputAutoConnect:           "auto_connect",
+	inputExtraOptions:          "extra_options",
+	inputTermType:              "term_type",
+	inputConnectTemplate:       "connect_template",
+}
+
+var defaultPlaceholderTemplates = map[int]string{
+	inputTitle:                 "*required*",
+	inputAddress:               "*required*",
+	inputDescription:           "n/a",
+	inputGroup:                 "n/a",
+	inputTags:                  "n/a, comma-separated list, ex: web, prod",
+	inputAlias:                 "n/a, short code to connect from quick connect box, ex: db1",
+	inputWebURL:                "n/a, ex: https://" + hostModel.WebURLAddressPlaceholder + ":8443",
+	inputBanner:                "n/a, must be acknowledged once per session before connecting",
+	inputNotes:                 "n/a, displayed every time before connecting",
+	inputProtocol:              "ssh, teleport to connect using tsh, or kubectl to open a shell in a pod",
+	inputTeleportCluster:       "n/a, current tsh cluster is used when empty",
+	inputKubeNamespace:         "*required*",
+	inputKubePod:               "*required*",
+	inputKubeContainer:         "n/a, default container of the pod is used when empty",
+	inputLogin:                 sshParameterPlaceholder,
+	inputNetworkPort:           sshParameterPlaceholder,
+	inputIdentityFile:          sshParameterPlaceholder,
+	inputRemoteForwards:        "n/a, comma-separated list, ex: 9000:localhost:3000, *:8080:localhost:80",
+	inputIdentitiesOnly:        "no",
+	inputForwardAgent:          "no",
+	inputCompression:           "no",
+	inputCompressionLevel:      "n/a, from 1 (fast) to 9 (best), applied only with compression",
+	inputPassword:              "Password",
+	inputPasswordCommand:       "n/a",
+	inputGatewayPorts:          sshParameterPlaceholder,
+	inputEscapeChar:            sshParameterPlaceholder,
+	inputBindAddress:           "n/a, local IP address of the outgoing connection",
+	inputHostKeyAlias:          "n/a, name to look up the host key instead of the address",
+	inputStrictHostKeyChecking: "n/a, ssh default is used, press space to choose yes, no or accept-new",
+	inputConnectTimeout:        "n/a, seconds, system default is used when empty",
+	inputKeepAliveInterval:     "n/a, seconds, 0 disables keepalive messages",
+	inputKeepAliveCountMax:     "n/a, unanswered keepalive messages before disconnect",
+	inputLocalForwards:         "n/a, comma-separated list, ex: 8080:localhost:80, 5432:db:5432",
+	inputDynamicForward:        "n/a, local port of SOCKS proxy, ex: 1080",
+	inputBackgroundTunnel:      "no, ssh only forwards ports in background when yes",
+	inputAutoConnect:           "no, background tunnel is established on app start when yes",
+	inputExtraOptions:          "n/a, comma-separated list of ssh options, ex: Ciphers=aes256-ctr, RekeyLimit=1G",
+	inputTermType:              "n/a, TERM of the local terminal is used, ex: xterm-256color, screen, vt100",
+	// Template actions are escaped, because placeholders are templates as well.
+	inputConnectTemplate: `n/a, ex: ssh -p {{"{{.RemotePort}} {{.LoginName}}@{{.Address}}"}}`,
+}
+
+// renderPlaceholder - executes placeholder template. Returns an error if the template is malformed.
+func renderPlaceholder(text string, data placeholderData) (string, error) {
+	// Single braces are not template actions, so "{address}" placeholder is rendered as is.
+	tmpl, err := template.New("placeholder").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	sb := strings.Builder{}
+	if err = tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+func (m *editModel) updateInputPlaceholders() {
+	readOnly := m.host.IsUserDefinedSSHCommand()
+	values := map[int]string{
+		inputLogin:        m.host.SSHClientConfig.User,
+		inputNetworkPort:  m.host.SSHClientConfig.Port,
+		inputIdentityFile: m.host.SSHClientConfig.IdentityFile,
+		inputGatewayPorts: "no",
+		inputEscapeChar:   "~",
+	}
+
+	sources := map[int]string{}
+	directives := map[int]string{inputLogin: "user", inputNetworkPort: "port", inputIdentityFile: "identityfile"}
+	for i, directive := range directives {
+		if source, ok := m.host.SSHClientConfig.Sources[directive]; ok {
+			sources[i] = source.String()
+		}
+	}
+
+	userTemplates := m.appState.ApplicationConfig.Placeholders
+	for i := range m.inputs {
+		data := placeholderData{Value: values[i], ReadOnly: readOnly, Source: sources[i]}
+		if userTemplate, ok := userTemplates[placeholderNames[i]]; ok {
+			placeholder, err := renderPlaceholder(userTemplate, data)
+			if err == nil {
+				m.inputs[i].Placeholder = placeholder
+				continue
+			}
+
+			m.logger.Debug("[UI] Cannot render placeholder template '%s'. %v", placeholderNames[i], err)
+		}
+
+		// Default templates are always valid.
+		m.inputs[i].Placeholder, _ = renderPlaceholder(defaultPlaceholderTemplates[i], data)
+	}
+}
@@ -0,0 +1,137 @@
+package hostedit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v2"
+
+	"github.com/grafviktor/goto/internal/i18n"
+	hostModel "github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/ui/message"
+	"github.com/grafviktor/goto/internal/utils"
+)
+
+// passwordMask - is displayed instead of the password in the yaml document, so that the password is not
+// revealed on the screen. Stored password is kept as long as the mask is left unchanged.
+const passwordMask = "********"
+
+// marshalHost - converts host into yaml document, the same way it's stored in the hosts file.
+func marshalHost(h hostModel.Host) (string, error) {
+	data, err := yaml.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// unmarshalHost - parses yaml document which is edited by the user. Unknown attributes are rejected,
+// because they are most likely misspelled and would be silently lost when the host is saved.
+func unmarshalHost(text string) (hostModel.Host, error) {
+	var h hostModel.Host
+	if err := yaml.UnmarshalStrict([]byte(text), &h); err != nil {
+		return hostModel.Host{}, err
+	}
+
+	if utils.StringEmpty(h.Title) {
+		return hostModel.Host{}, errors.New(i18n.T("title is required"))
+	}
+
+	if !h.HasDestination() {
+		return hostModel.Host{}, errors.New(i18n.T("host address is required to build connect command"))
+	}
+
+	if err := connectTemplateValidator(h.ConnectTemplate); err != nil {
+		return hostModel.Host{}, err
+	}
+
+	return h, nil
+}
+
+// enterRawMode - replaces the form with a text area which contains all host attributes in yaml format,
+// including those which are not displayed in the form.
+func (m *editModel) enterRawMode() tea.Cmd {
+	h := m.host.unwrap()
+	if h.Password != "" {
+		h.Password = passwordMask
+	}
+
+	text, err := marshalHost(h)
+	if err != nil {
+		m.logger.Info("[UI] Cannot convert host id: %v to yaml. %v", m.host.ID, err)
+		m.title = i18n.T("cannot convert host to yaml")
+		return nil
+	}
+
+	m.logger.Debug("[UI] Edit host id: %v as yaml", m.host.ID)
+	m.rawEditor = textarea.New()
+	m.rawEditor.ShowLineNumbers = false
+	m.rawEditor.CharLimit = 0
+	m.rawEditor.MaxHeight = 0
+	m.rawEditor.SetWidth(m.viewport.Width)
+	m.rawEditor.SetHeight(m.viewport.Height)
+	m.rawEditor.SetValue(text)
+	m.rawMode = true
+
+	return m.rawEditor.Focus()
+}
+
+// applyRawYAML - copies host attributes from the yaml document into the form. Returns false if the document
+// is not valid, in that case user stays in the raw mode.
+func (m *editModel) applyRawYAML() bool {
+	parsed, err := unmarshalHost(m.rawEditor.Value())
+	if err != nil {
+		m.logger.Info("[UI] Host id: %v yaml is not valid. %v", m.host.ID, err)
+		m.title = i18n.Tf("yaml is not valid: %v", err)
+		return false
+	}
+
+	if parsed.Password == passwordMask {
+		parsed.Password = m.host.Password
+	}
+
+	// Identifier and ssh config are not a part of the document.
+	parsed.ID = m.host.ID
+	parsed.SSHClientConfig = m.host.SSHClientConfig
+	*m.host.Host = parsed
+	m.rawMode = false
+	m.rawEditor.Blur()
+	m.updateInputFields()
+	m.viewport.SetContent(m.inputsView())
+
+	return true
+}
+
+func (m *editModel) handleKeyEventInRawMode(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.keyMap.Save):
+		if !m.applyRawYAML() {
+			return nil
+		}
+
+		m.logger.Info("[UI] Save changes for host id: %v", m.host.ID)
+		return m.save(msg)
+	case key.Matches(msg, m.keyMap.RawYAML):
+		m.applyRawYAML()
+		return nil
+	case key.Matches(msg, m.keyMap.Discard):
+		m.logger.Debug("[UI] Discard yaml changes of host id: %v", m.host.ID)
+		m.rawMode = false
+		return nil
+	case key.Matches(msg, m.keyMap.Help):
+		return message.TeaCmd(message.OpenHelpOverlay{KeyBindings: m.keyMap.FullHelp()})
+	}
+
+	var cmd tea.Cmd
+	m.rawEditor, cmd = m.rawEditor.Update(msg)
+
+	return cmd
+}
+
+func (m *editModel) rawView() string {
+	return fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.rawEditor.View(), m.helpView())
+}
@@ -1,25 +1,46 @@
 package hostedit
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/grafviktor/goto/internal/ui/theme"
+)
 
 var (
-	docStyle    = lipgloss.NewStyle().Margin(1, 2)
+	docStyle             = lipgloss.NewStyle().Margin(1, 2)
+	menuStyle            = lipgloss.NewStyle().Margin(3, 4, 0)
+	notesPanelLabelStyle = lipgloss.NewStyle().Bold(true)
+
+	// Styles below use colors of the theme, see setStyles.
+	cursorStyle     lipgloss.Style
+	titleStyle      lipgloss.Style
+	separatorStyle  lipgloss.Style
+	notesPanelStyle lipgloss.Style
+)
+
+// setStyles - builds styles which use colors of the palette. Theme is selected after the package is
+// initialized, that's why styles are built when the form is created.
+func setStyles(palette theme.Palette) {
 	cursorStyle = lipgloss.NewStyle().
-			BorderForeground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"}).
-			Foreground(lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"})
-
-	titleStyle = lipgloss.NewStyle(). //
-			Background(lipgloss.Color("#5f5fd7")).
-			Foreground(lipgloss.Color("#ffffd7	")).
-		// Background(lipgloss.Color("62")).
-		// Foreground(lipgloss.Color("230")).
+		BorderForeground(palette.Accent).
+		Foreground(palette.Highlight)
+
+	titleStyle = lipgloss.NewStyle().
+		Background(palette.TitleBackground).
+		Foreground(palette.TitleForeground).
 		Padding(0, 1).
 		// Instead of placing "1" to have a padding at the bottom,
 		// I use '\n' in the code base. That is to a rendering artifacts
 		Margin(1, 4, 0)
 
-	menuStyle = lipgloss.NewStyle().Margin(3, 4, 0)
-)
+	separatorStyle = lipgloss.NewStyle().
+		Foreground(palette.Muted)
+
+	notesPanelStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(palette.Muted).
+		Padding(0, 1)
+}
 
 //nolint:dupword
 /*
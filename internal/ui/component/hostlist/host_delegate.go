@@ -1,16 +1,83 @@
 package hostlist
 
 import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/samber/lo"
 
 	"github.com/grafviktor/goto/internal/constant"
+	"github.com/grafviktor/goto/internal/i18n"
+	"github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/model/ssh"
+	"github.com/grafviktor/goto/internal/ui/theme"
 )
 
 type hostDelegate struct {
 	list.DefaultDelegate
 	layout *constant.ScreenLayout
 	logger iLogger
+	// marked contains IDs of hosts which are selected for a bulk action. See listModel.marked.
+	marked map[int]struct{}
+}
+
+// customConnectBadge - is appended to the title of a host which uses a custom connect string, because most of
+// its attributes are ignored.
+const customConnectBadge = " [raw]"
+
+// decoratedItem - is a host which title is rendered with a prefix and a suffix. For instance, a check mark of
+// a host which is selected for a bulk action, an icon of the remote operating system, or a badge. Time of the
+// last connection is appended to the description.
+type decoratedItem struct {
+	ListItemHost
+	prefix        string
+	suffix        string
+	lastConnected string
+}
+
+func (l decoratedItem) Title() string { return l.prefix + l.ListItemHost.Title() + l.suffix }
+
+func (l decoratedItem) Description() string {
+	if l.lastConnected == "" {
+		return l.ListItemHost.Description()
+	}
+
+	if l.ListItemHost.Description() == "" {
+		return l.lastConnected
+	}
+
+	return l.ListItemHost.Description() + " • " + l.lastConnected
+}
+
+// lastConnectedSince - returns time which passed since the last connection in a short form, ex: "3d ago".
+func lastConnectedSince(lastConnected, now time.Time) string {
+	if lastConnected.IsZero() {
+		return i18n.T("never")
+	}
+
+	elapsed := now.Sub(lastConnected)
+	day := time.Hour * 24
+	switch {
+	case elapsed < time.Minute:
+		return i18n.T("just now")
+	case elapsed < time.Hour:
+		return i18n.Tf("%dm ago", elapsed/time.Minute)
+	case elapsed < day:
+		return i18n.Tf("%dh ago", elapsed/time.Hour)
+	case elapsed < day*30:
+		return i18n.Tf("%dd ago", elapsed/day)
+	case elapsed < day*365:
+		return i18n.Tf("%dmo ago", elapsed/(day*30))
+	default:
+		return i18n.Tf("%dy ago", elapsed/(day*365))
+	}
 }
 
 // NewHostDelegate creates a new Delegate object which can be used for customizing the view of a host.
@@ -21,15 +88,27 @@ func NewHostDelegate(layout *constant.ScreenLayout, log iLogger) *hostDelegate {
 		layout:          layout,
 	}
 
+	// Focused host is highlighted using colors of the theme.
+	palette := theme.Current()
+	styles := &delegate.Styles
+	styles.SelectedTitle = styles.SelectedTitle.BorderForeground(palette.Accent).Foreground(palette.Highlight)
+	styles.SelectedDesc = styles.SelectedDesc.BorderForeground(palette.Accent).Foreground(palette.Accent)
+	styles.NormalDesc = styles.NormalDesc.Foreground(palette.Muted)
+	styles.DimmedTitle = styles.DimmedTitle.Foreground(palette.Muted)
+
 	delegate.updateLayout()
 
 	delegate.UpdateFunc = func(msg tea.Msg, m *list.Model) tea.Cmd {
 		if _, ok := msg.(msgToggleLayout); ok {
-			if *delegate.layout == constant.ScreenLayoutTight {
-				*delegate.layout = constant.ScreenLayoutNormal
-			} else {
-				// If layout is not set or "Normal", switch to "tight" layout.
+			// Layouts are switched from the most spacious to the densest one: normal, compact, tight.
+			switch *delegate.layout {
+			case constant.ScreenLayoutCompact:
 				*delegate.layout = constant.ScreenLayoutTight
+			case constant.ScreenLayoutTight:
+				*delegate.layout = constant.ScreenLayoutNormal
+			default:
+				// If layout is not set or "Normal", switch to "compact" layout.
+				*delegate.layout = constant.ScreenLayoutCompact
 			}
 
 			delegate.updateLayout()
@@ -42,7 +121,7 @@ func NewHostDelegate(layout *constant.ScreenLayout, log iLogger) *hostDelegate {
 }
 
 func (hd *hostDelegate) updateLayout() {
-	if *hd.layout == constant.ScreenLayoutTight {
+	if *hd.layout == constant.ScreenLayoutTight || *hd.layout == constant.ScreenLayoutCompact {
 		hd.SetSpacing(0)
 		hd.ShowDescription = false
 	} else {
@@ -53,3 +132,98 @@ func (hd *hostDelegate) updateLayout() {
 
 	hd.logger.Debug("[UI] Change screen layout to: '%s'", *hd.layout)
 }
+
+// Render - renders a host, hosts which are selected for a bulk action are prefixed with a check mark,
+// followed by icons of the remote operating system and the cloud provider if they're known. Hosts which use a custom
+// connect string are marked with a badge. Description is followed by time of the last connection. In compact layout,
+// the title is followed by the address.
+func (hd *hostDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	hostItem, ok := item.(ListItemHost)
+	if !ok {
+		hd.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+
+	prefix := ""
+	if _, marked := hd.marked[hostItem.ID]; marked {
+		prefix = "✓ "
+	}
+
+	if hostItem.IsFavorite {
+		prefix += "★ "
+	}
+
+	if icon := ssh.RemoteOSIcon(hostItem.RemoteOS); icon != "" {
+		prefix += icon + " "
+	}
+
+	if icon := host.ProviderIcon(hostItem.Provider); icon != "" {
+		prefix += icon + " "
+	}
+
+	suffix := ""
+	if hostItem.IsUserDefinedSSHCommand() {
+		suffix = customConnectBadge
+	}
+
+	// Compact layout doesn't display description, address is displayed next to the title instead.
+	if *hd.layout == constant.ScreenLayoutCompact && hostItem.Address != "" {
+		suffix += " • " + hostItem.Address
+	}
+
+	// Scratch host is never stored, so it doesn't have time of the last connection.
+	lastConnected := ""
+	if hostItem.ID != host.ScratchHostID {
+		lastConnected = lastConnectedSince(hostItem.LastConnected, time.Now())
+	}
+
+	decorated := decoratedItem{ListItemHost: hostItem, prefix: prefix, suffix: suffix, lastConnected: lastConnected}
+	matches := hd.searchMatches(m, index)
+	if prefix == "" || len(matches) == 0 {
+		hd.DefaultDelegate.Render(w, m, index, decorated)
+		return
+	}
+
+	// Search matches are positions in the title of the host, default delegate would highlight the prefix instead.
+	hd.renderHighlighted(w, m, index, decorated, lo.Map(matches, func(i, _ int) int {
+		return i + utf8.RuneCountInString(prefix)
+	}))
+}
+
+// searchMatches - returns positions of the title runes which match the search query. Hosts are not highlighted
+// while user is typing an empty query, the same way as default delegate does.
+func (hd *hostDelegate) searchMatches(m list.Model, index int) []int {
+	if m.FilterState() == list.Unfiltered || m.FilterValue() == "" {
+		return nil
+	}
+
+	return m.MatchesForItem(index)
+}
+
+// renderHighlighted - renders a host the same way as default delegate, but highlights the given runes of the title.
+func (hd *hostDelegate) renderHighlighted(w io.Writer, m list.Model, index int, item decoratedItem, matches []int) {
+	s := &hd.Styles
+	titleStyle, descStyle := s.NormalTitle, s.NormalDesc
+	if index == m.Index() && m.FilterState() != list.Filtering {
+		titleStyle, descStyle = s.SelectedTitle, s.SelectedDesc
+	}
+
+	textWidth := m.Width() - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight()
+	unmatched := titleStyle.Inline(true)
+	title := ansi.Truncate(item.Title(), textWidth, "…")
+	title = titleStyle.Render(lipgloss.StyleRunes(title, matches, unmatched.Inherit(s.FilterMatch), unmatched))
+	if !hd.ShowDescription {
+		fmt.Fprint(w, title) //nolint:errcheck // same as default delegate
+		return
+	}
+
+	var lines []string
+	for i, line := range strings.Split(item.Description(), "\n") {
+		if i >= hd.Height()-1 {
+			break
+		}
+		lines = append(lines, ansi.Truncate(line, textWidth, "…"))
+	}
+	desc := descStyle.Render(strings.Join(lines, "\n"))
+	fmt.Fprintf(w, "%s\n%s", title, desc) //nolint:errcheck // same as default delegate
+}
@@ -5,21 +5,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"golang.org/x/exp/slices"
 
+	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/samber/lo"
 
 	"github.com/grafviktor/goto/internal/constant"
+	"github.com/grafviktor/goto/internal/i18n"
 	hostModel "github.com/grafviktor/goto/internal/model/host"
+	"github.com/grafviktor/goto/internal/model/ssh"
 	"github.com/grafviktor/goto/internal/state"
 	"github.com/grafviktor/goto/internal/storage"
 	"github.com/grafviktor/goto/internal/ui/message"
+	"github.com/grafviktor/goto/internal/ui/theme"
 	"github.com/grafviktor/goto/internal/utils"
 )
 
@@ -29,9 +37,26 @@ var (
 	modeRemoveItem         = "removeItem"
 	modeDefault            = ""
 	modeSSHCopyID          = "sshCopyID"
+	modeCloneToGroup       = "cloneToGroup"
+	modeSelectForward      = "selectForwardPreset"
+	modeSelectProfile      = "selectProfile"
+	modeMountSSHFS         = "mountSSHFS"
+	modeAcknowledgeBanner  = "acknowledgeBanner"
+	modeSetIdentityFile    = "setIdentityFile"
+	modeSelectAgentKey     = "selectAgentKey"
+	modeConfirmLoopback    = "confirmLoopback"
+	modeConfirmBusyPorts   = "confirmBusyPorts"
+	modeConfirmByName      = "confirmByName"
+	modeQuickConnect       = "quickConnect"
+	modeSelectIdentityFile = "selectIdentityFile"
+	modeShowNote           = "showNote"
+	modeFilterByTag        = "filterByTag"
 	defaultListTitle       = "press 'n' to add a new host"
 )
 
+// clipboardWriteAll is a variable in order to be replaced in unit tests.
+var clipboardWriteAll = clipboard.WriteAll
+
 type iLogger interface {
 	Debug(format string, args ...any)
 	Info(format string, args ...any)
@@ -39,10 +64,30 @@ type iLogger interface {
 }
 
 type (
-	// OpenEditForm fires when user press edit button.
-	OpenEditForm     struct{ HostID int }
-	msgErrorOccurred struct{ err error }
-	msgToggleLayout  struct{}
+	// OpenEditForm fires when user press edit button. ScratchHost is set when user edits the scratch host, which
+	// is not in the storage.
+	OpenEditForm struct {
+		HostID      int
+		ScratchHost *hostModel.Host
+	}
+	// MsgRefreshRepo fires when hosts should be re-read from the storage, for instance
+	// when user edited hosts file manually.
+	MsgRefreshRepo struct{}
+	// MsgFilterByTag fires when user chooses a tag, only hosts which have the tag are displayed then.
+	// Empty tag clears the filter.
+	MsgFilterByTag struct{ Tag string }
+	// MsgToggleGroup fires when user collapses or expands a group of hosts. Empty group stands for hosts which
+	// don't have a group.
+	MsgToggleGroup struct{ Group string }
+	// MsgSearch fires when hosts which match the search query are displayed. Focus stays on the host which
+	// was selected before the query changed, if it still matches.
+	MsgSearch struct{ Query string }
+	// MsgCycleSort fires when user switches sort order of hosts: by title, by last connection or by group.
+	MsgCycleSort struct{}
+	// MsgToggleFavorite fires when user marks a host as favorite or unmarks it. Favorite hosts are displayed on top.
+	MsgToggleFavorite struct{ HostID int }
+	msgErrorOccurred  struct{ err error }
+	msgToggleLayout   struct{}
 )
 
 type listModel struct {
@@ -52,6 +97,30 @@ type listModel struct {
 	appState *state.ApplicationState
 	logger   iLogger
 	mode     string
+	// prompt is used by the modes which require a text value from the user. For instance "cloneToGroup" or "mountSSHFS".
+	prompt textinput.Model
+	// afterConfirmed is invoked when user acknowledges the banner of the host, confirms connection to the local machine
+	// or types the title of the host which requires it.
+	afterConfirmed func() tea.Cmd
+	// selectedProfile is a connection profile which user has chosen, it's used when forward preset is selected next.
+	selectedProfile string
+	// selectedIdentityFile is an identity file which user has chosen, it's used when the host is connected.
+	selectedIdentityFile string
+	// marked contains IDs of hosts which are selected for a bulk action. The map is shared with the delegate.
+	marked map[int]struct{}
+	// agentKeys are keys loaded into ssh-agent, user picks one of them to pin it to the focused host.
+	agentKeys []ssh.AgentKey
+	// pickerInput is a number of the option which user is typing, when the picker has more options than digit keys.
+	pickerInput string
+	// scratch is a throwaway host which is displayed on top of the list. It's never stored, so it's lost on exit.
+	scratch hostModel.Host
+	// tagFilter is a tag which user filtered the hosts by, hosts without the tag are hidden. Empty means no filter.
+	tagFilter string
+	// searchFocusID is id of the host which was selected when user changed the search query. Zero means that
+	// focus should not be restored.
+	searchFocusID int
+	// collapsedGroups contains names of the groups which hosts are hidden. Empty name stands for hosts without a group.
+	collapsedGroups map[string]bool
 }
 
 // New - creates new host list model.
@@ -63,14 +132,19 @@ type listModel struct {
 func New(_ context.Context, storage storage.HostStorage, appState *state.ApplicationState, log iLogger) *listModel {
 	// delegate := buildScreenLayout(appState.ScreenLayout)
 	delegate := NewHostDelegate(&appState.ScreenLayout, log)
+	delegate.marked = map[int]struct{}{}
 	delegateKeys := newDelegateKeyMap()
+	// Full help bindings are provided by the delegate, so that the list renders every category as a separate column.
+	delegate.FullHelpFunc = delegateKeys.FullHelp
 
 	var listItems []list.Item
 	model := list.New(listItems, delegate, 0, 0)
-	// This line affects sorting when filtering enabled. What UnsortedFilter
-	// does - it filters the collection, but leaves initial items order unchanged.
-	// Default filter on the contrary - filters the collection based on the match rank.
-	model.Filter = list.UnsortedFilter
+	palette := theme.Current()
+	model.Styles.Title = model.Styles.Title.Background(palette.TitleBackground).Foreground(palette.TitleForeground)
+	model.Styles.FilterCursor = model.Styles.FilterCursor.Foreground(palette.Highlight)
+	// Search filter leaves initial items order unchanged. Default filter on the
+	// contrary - filters the collection based on the match rank.
+	model.Filter = searchFilter
 
 	m := listModel{
 		Model:    model,
@@ -78,20 +152,30 @@ func New(_ context.Context, storage storage.HostStorage, appState *state.Applica
 		repo:     storage,
 		appState: appState,
 		logger:   log,
+		marked:   delegate.marked,
+		// Groups are expanded on startup.
+		collapsedGroups: map[string]bool{},
+		scratch: hostModel.Host{
+			ID:          hostModel.ScratchHostID,
+			Title:       i18n.T("scratch"),
+			Description: i18n.T("temporary host, it's lost on exit"),
+		},
 	}
 
 	m.KeyMap.CursorUp.Unbind()
 	m.KeyMap.CursorUp = delegateKeys.cursorUp
 	m.KeyMap.CursorDown.Unbind()
 	m.KeyMap.CursorDown = delegateKeys.cursorDown
+	// Full help is displayed as an overlay by the parent model, instead of the inline help of the list.
+	m.KeyMap.ShowFullHelp = delegateKeys.help
+	m.KeyMap.CloseFullHelp.Unbind()
 
-	// Additional key mappings for the short and full help views. This allows
+	// Additional key mappings for the short help view. This allows
 	// you to add additional key mappings to the help menu without
 	// re-implementing the help component.
 	m.AdditionalShortHelpKeys = delegateKeys.ShortHelp
-	m.AdditionalFullHelpKeys = delegateKeys.FullHelp
 
-	m.Title = defaultListTitle
+	m.Title = i18n.T(defaultListTitle)
 	m.SetShowStatusBar(false)
 
 	return &m
@@ -100,26 +184,19 @@ func New(_ context.Context, storage storage.HostStorage, appState *state.Applica
 func (m *listModel) Init() tea.Cmd {
 	// This function is called from model.go#init() file
 	m.logger.Debug("[UI] Load hostnames from the database")
-	hosts, err := m.repo.GetAll()
+	hosts, err := m.loadHosts()
 	if err != nil {
 		m.logger.Error("[UI] Cannot read database. %v", err)
 		return message.TeaCmd(msgErrorOccurred{err})
 	}
 
-	slices.SortFunc(hosts, func(a, b hostModel.Host) int {
-		if a.Title < b.Title {
-			return -1
-		}
-		return 1
+	setItemsCmd := m.SetItems(m.buildItems(hosts))
+	// Unless user selected another host, focus the first stored one rather than the scratch host.
+	m.selectItem(func(item list.Item) bool {
+		hostItem, ok := item.(ListItemHost)
+		return ok && !hostItem.IsScratch()
 	})
 
-	// Wrap hosts into List items
-	items := make([]list.Item, 0, len(hosts))
-	for _, h := range hosts {
-		items = append(items, ListItemHost{Host: h})
-	}
-
-	setItemsCmd := m.SetItems(items)
 	selectHostByIDCmd := m.selectHostByID(m.appState.Selected)
 	return tea.Sequence(setItemsCmd, selectHostByIDCmd)
 }
@@ -143,6 +220,41 @@ func (m *listModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case message.HostCreated:
 		cmd := m.onHostCreated(msg)
 		return m, cmd
+	case MsgRefreshRepo:
+		m.logger.Debug("[UI] Reload hosts from the storage")
+		return m, m.Init()
+	case MsgFilterByTag:
+		m.tagFilter = msg.Tag
+		m.logger.Debug("[UI] Filter hosts by tag: '%s'", m.tagFilter)
+		return m, m.Init()
+	case list.FilterMatchesMsg:
+		// Search results are calculated asynchronously, focus is restored when they're displayed.
+		cmd := m.updateChildModel(msg)
+		return m, tea.Batch(cmd, message.TeaCmd(MsgSearch{Query: m.FilterValue()}))
+	case MsgSearch:
+		return m, m.onSearch(msg)
+	case MsgToggleGroup:
+		return m, m.toggleGroup(msg.Group)
+	case MsgCycleSort:
+		return m, m.cycleSort()
+	case MsgToggleFavorite:
+		return m, m.toggleFavorite(msg.HostID)
+	case msgErrorOccurred:
+		m.logger.Debug("[UI] Display error: %v", msg.err)
+		m.Title = msg.err.Error()
+		return m, nil
+	case message.HostListNotify:
+		m.logger.Debug("[UI] Display notification: %s", msg.Text)
+		m.Title = msg.Text
+		return m, nil
+	case message.AgentKeysLoaded:
+		return m, m.enterSelectAgentKeyMode(msg.Keys)
+	case message.SuggestSSHCopyID:
+		cmd := m.enterSSHCopyIDMode()
+		if m.mode == modeSSHCopyID {
+			m.Title = i18n.T("permission denied (publickey). copy ssh key to the remote host? (y/N)")
+		}
+		return m, cmd
 	default:
 		return m, m.updateChildModel(msg)
 	}
@@ -171,18 +283,74 @@ func (m *listModel) handleKeyboardEvent(msg tea.KeyMsg) tea.Cmd {
 
 			return m.updateChildModel(msg)
 		}
+
+		return m.updateSearchQuery(msg)
+	case key.Matches(msg, m.Model.KeyMap.Filter):
+		// When user starts a search, child model moves focus to the first item. Keep focus on the selected host.
+		if hostItem, ok := m.SelectedItem().(ListItemHost); ok {
+			return tea.Sequence(m.updateChildModel(msg), m.selectHostByID(hostItem.ID))
+		}
+
 		return m.updateChildModel(msg)
 	case key.Matches(msg, m.Model.KeyMap.ClearFilter):
 		// When user clears the host filter, child model resets the focus. Explicitly set focus on previously selected item.
-		selectedID := m.SelectedItem().(ListItemHost).ID
-		return tea.Sequence(m.updateChildModel(msg), m.selectHostByID(selectedID))
+		if hostItem, ok := m.SelectedItem().(ListItemHost); ok {
+			return tea.Sequence(m.updateChildModel(msg), m.selectHostByID(hostItem.ID))
+		}
+
+		return m.updateChildModel(msg)
 	case m.mode != modeDefault:
 		// Handle key event when some mode is enabled. For instance "removeMode".
 		return m.handleKeyEventWhenModeEnabled(msg)
+	case key.Matches(msg, m.keyMap.help):
+		return message.TeaCmd(message.OpenHelpOverlay{KeyBindings: m.FullHelp()})
 	case key.Matches(msg, m.keyMap.connect):
-		return m.constructProcessCmd(constant.ProcessTypeSSHConnect)
+		return m.connect()
+	case key.Matches(msg, m.keyMap.fastConnect):
+		return m.fastConnect()
+	case key.Matches(msg, m.keyMap.tailLog):
+		return m.tailLog()
+	case key.Matches(msg, m.keyMap.openSFTP):
+		return m.openSFTP()
+	case key.Matches(msg, m.keyMap.recentHost):
+		return m.selectRecentHost()
 	case key.Matches(msg, m.keyMap.copyID):
 		return m.enterSSHCopyIDMode()
+	case key.Matches(msg, m.keyMap.checkAuth):
+		return m.checkAuth()
+	case key.Matches(msg, m.keyMap.toggleJumpHost):
+		return m.toggleJumpHost()
+	case key.Matches(msg, m.keyMap.copyPublicKey):
+		return m.copyPublicKey()
+	case key.Matches(msg, m.keyMap.copyIdentityPath):
+		return m.copyIdentityFilePath()
+	case key.Matches(msg, m.keyMap.openWebURL):
+		return m.openWebURL()
+	case key.Matches(msg, m.keyMap.dashboard):
+		return message.TeaCmd(message.OpenDashboard{})
+	case key.Matches(msg, m.keyMap.mountSSHFS):
+		return m.enterMountSSHFSMode()
+	case key.Matches(msg, m.keyMap.toggleMark):
+		return m.toggleMark()
+	case key.Matches(msg, m.keyMap.toggleFavorite):
+		if item, ok := m.SelectedItem().(ListItemHost); ok {
+			return message.TeaCmd(MsgToggleFavorite{HostID: item.ID})
+		}
+
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	case key.Matches(msg, m.keyMap.setIdentityFile):
+		return m.enterSetIdentityFileMode()
+	case key.Matches(msg, m.keyMap.pinAgentKey):
+		return m.listAgentKeys()
+	case key.Matches(msg, m.keyMap.quickConnect):
+		return m.enterQuickConnectMode()
+	case key.Matches(msg, m.keyMap.filterByTag):
+		return m.enterFilterByTagMode()
+	case key.Matches(msg, m.keyMap.toggleGroup):
+		return m.toggleSelectedGroup()
+	case key.Matches(msg, m.keyMap.openInEditor):
+		m.logger.Info("[UI] Open hosts file in text editor")
+		return message.TeaCmd(message.RunProcessEditStorage{})
 	case key.Matches(msg, m.keyMap.remove):
 		return m.enterRemoveItemMode()
 	case key.Matches(msg, m.keyMap.edit):
@@ -191,6 +359,10 @@ func (m *listModel) handleKeyboardEvent(msg tea.KeyMsg) tea.Cmd {
 		return message.TeaCmd(OpenEditForm{}) // When create a new item, jump to edit mode.
 	case key.Matches(msg, m.keyMap.clone):
 		return m.copyItem()
+	case key.Matches(msg, m.keyMap.cloneToGroup):
+		return m.enterCloneToGroupMode()
+	case key.Matches(msg, m.keyMap.cycleSort):
+		return message.TeaCmd(MsgCycleSort{})
 	case key.Matches(msg, m.keyMap.toggleLayout):
 		m.updateChildModel(msgToggleLayout{})
 		// When switch between screen layouts, it's required to update pagination.
@@ -226,7 +398,7 @@ func (m *listModel) removeItem() tea.Cmd {
 		// We should not be here at all, because delete
 		// button isn't available when a host is not selected.
 		m.logger.Error("[UI] Cannot cast selected item to host model")
-		return message.TeaCmd(msgErrorOccurred{err: errors.New(itemNotSelectedMessage)})
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
 	}
 
 	err := m.repo.Delete(item.ID)
@@ -236,9 +408,18 @@ func (m *listModel) removeItem() tea.Cmd {
 	}
 
 	_, index, _ := lo.FindIndexOf(m.Items(), func(i list.Item) bool {
-		return i.(ListItemHost).ID == item.ID
+		hostItem, ok := i.(ListItemHost)
+		return ok && hostItem.ID == item.ID
 	})
 
+	if m.grouped() {
+		// Header displays number of hosts in the group, so the list is rebuilt instead of removing the item.
+		cmd := m.reloadItems(nil)
+		m.Model.ResetFilter()
+		m.Select(max(index-1, 0))
+		return tea.Sequence(cmd, m.onFocusChanged())
+	}
+
 	/*
 		nolint-godox BUG: Steps to reproduce:
 		Create hosts with following titles:
@@ -304,34 +485,54 @@ func (m *listModel) removeItem() tea.Cmd {
 func (m *listModel) editItem() tea.Cmd {
 	item, ok := m.SelectedItem().(ListItemHost)
 	if !ok {
-		return message.TeaCmd(msgErrorOccurred{err: errors.New(itemNotSelectedMessage)})
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
 	}
 
 	m.Model.ResetFilter()
 	m.logger.Info("[UI] Edit item id: %d, title: %s", item.ID, item.Title())
+	openEditForm := OpenEditForm{HostID: item.ID}
+	if item.IsScratch() {
+		openEditForm.ScratchHost = &item.Host
+	}
+
 	return tea.Sequence(
-		message.TeaCmd(OpenEditForm{HostID: item.ID}),
+		message.TeaCmd(openEditForm),
 		// Load SSH config for the selected host
 		message.TeaCmd(message.RunProcessSSHLoadConfig{Host: item.Host}),
 	)
 }
 
 func (m *listModel) copyItem() tea.Cmd {
+	return m.copyItemWith(func(*hostModel.Host) {})
+}
+
+// copyItemToGroup - clones selected host into a different group.
+func (m *listModel) copyItemToGroup(group string) tea.Cmd {
+	return m.copyItemWith(func(h *hostModel.Host) {
+		m.logger.Debug("[UI] Set group of the cloned host to '%s'", group)
+		h.Group = group
+	})
+}
+
+// copyItemWith - clones selected host, modify function is applied to the clone before it's saved.
+func (m *listModel) copyItemWith(modify func(*hostModel.Host)) tea.Cmd {
 	item, ok := m.SelectedItem().(ListItemHost)
 	if !ok {
 		m.logger.Error("[UI] Cannot cast selected item to host model")
-		return message.TeaCmd(msgErrorOccurred{err: errors.New(itemNotSelectedMessage)})
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
 	}
 
 	originalHost := item.Host
 	m.logger.Info("[UI] Copy host item id: %d, title: %s", originalHost.ID, originalHost.Title)
 	clonedHost := originalHost.Clone()
+	modify(&clonedHost)
 	for i := 1; ok; i++ {
 		// Keep generating new title until it's unique
 		clonedHostTitle := fmt.Sprintf("%s (%d)", originalHost.Title, i)
 		listItems := m.Items()
 		idx := slices.IndexFunc(listItems, func(li list.Item) bool {
-			return li.(ListItemHost).Title() == clonedHostTitle
+			hostItem, isHost := li.(ListItemHost)
+			return isHost && hostItem.Title() == clonedHostTitle
 		})
 
 		// If title is unique, then we assign the title to the cloned host
@@ -347,229 +548,1366 @@ func (m *listModel) copyItem() tea.Cmd {
 		return message.TeaCmd(msgErrorOccurred{err})
 	}
 
-	titles := lo.Reduce(m.Items(), func(agg []string, item list.Item, index int) []string {
-		return append(agg, item.(ListItemHost).Title())
-	}, []string{clonedHost.Title})
-
-	slices.Sort(titles)
-	index := lo.IndexOf(titles, clonedHost.Title)
 	// We should NOT call onFocusChanged here, because we do not change focus when copying an item.
+	if m.grouped() || strings.TrimSpace(clonedHost.Group) != "" {
+		return m.reloadItems(hostWithID(originalHost.ID))
+	}
+
+	index := m.sortedIndex(ListItemHost{Host: clonedHost}, false)
 	return m.Model.InsertItem(index, ListItemHost{Host: clonedHost})
 }
 
-/*
- * Event handlers - those events come from other components.
- */
-
-// onHostUpdated - not only updates a new host, it also re-inserts the host into
-// a correct position of the host list, to keep it sorted.
-func (m *listModel) onHostUpdated(msg message.HostUpdated) tea.Cmd {
-	var cmd tea.Cmd
-	updatedItem := ListItemHost{Host: msg.Host}
-	titles := lo.Map(m.Items(), func(item list.Item, index int) string {
-		if item.(ListItemHost).ID == updatedItem.ID {
-			return updatedItem.Title()
-		}
-
-		return item.(ListItemHost).Title()
-	})
+// toggleMark - selects or deselects focused host for a bulk action.
+func (m *listModel) toggleMark() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
 
-	// When sorting, shall we take description into account as well or sorting by title is enough ?
-	slices.Sort(titles)
-	newIndex := lo.IndexOf(titles, updatedItem.Title())
+	// Bulk actions store hosts, so scratch host cannot be selected.
+	if item.IsScratch() {
+		return m.rejectScratch()
+	}
 
-	if newIndex == m.Index() {
-		// Index isn't changed.
-		cmd = m.Model.SetItem(m.Index(), updatedItem)
+	if _, marked := m.marked[item.ID]; marked {
+		m.logger.Debug("[UI] Deselect host id: %d", item.ID)
+		delete(m.marked, item.ID)
 	} else {
-		// Index is changed, need to move the host into a new location
-		m.Model.RemoveItem(m.Index())
-		cmd = m.Model.InsertItem(newIndex, updatedItem)
-		m.Select(newIndex)
+		m.logger.Debug("[UI] Select host id: %d", item.ID)
+		m.marked[item.ID] = struct{}{}
 	}
 
-	return tea.Sequence(cmd, m.onFocusChanged())
+	m.Title = i18n.Tf("%d hosts selected", len(m.marked))
+
+	return nil
 }
 
-func (m *listModel) onHostCreated(msg message.HostCreated) tea.Cmd {
-	listItem := ListItemHost{Host: msg.Host}
-	titles := lo.Reduce(m.Items(), func(agg []string, item list.Item, index int) []string {
-		return append(agg, item.(ListItemHost).Title())
-	}, []string{listItem.Title()})
+// toggleFavorite - marks the host as favorite or unmarks it, the host is saved and moved to its new position.
+func (m *listModel) toggleFavorite(hostID int) tea.Cmd {
+	item, index, found := lo.FindIndexOf(m.Items(), hostWithID(hostID))
+	if !found {
+		m.logger.Error("[UI] Cannot toggle favorite. Host id: %d is not in the list", hostID)
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
 
-	slices.Sort(titles)
-	index := lo.IndexOf(titles, listItem.Title())
-	cmd := m.Model.InsertItem(index, listItem)
+	h := item.(ListItemHost).Host
+	if h.ID == hostModel.ScratchHostID {
+		return m.rejectScratch()
+	}
+
+	h.IsFavorite = !h.IsFavorite
+	m.logger.Info("[UI] Set favorite of host id: %d to %v", h.ID, h.IsFavorite)
+	savedHost, err := m.repo.Save(h)
+	if err != nil {
+		m.logger.Error("[UI] Cannot save host id: %d. %v", h.ID, err)
+		return message.TeaCmd(msgErrorOccurred{err})
+	}
 
+	// Host is re-inserted at the position of the focused item, see onHostUpdated.
 	m.Select(index)
+	return m.onHostUpdated(message.HostUpdated{Host: savedHost})
+}
 
-	return tea.Sequence(
-		// If host position coincides with other host, then let the underlying model to handle that
-		cmd,
-		m.onFocusChanged(),
-	)
+// markedHosts - returns hosts which are selected for a bulk action. If none selected, returns the focused host.
+func (m *listModel) markedHosts() []hostModel.Host {
+	hosts := lo.FilterMap(m.Items(), func(item list.Item, _ int) (hostModel.Host, bool) {
+		hostItem, ok := item.(ListItemHost)
+		_, marked := m.marked[hostItem.ID]
+		return hostItem.Host, ok && marked
+	})
+
+	if item, ok := m.SelectedItem().(ListItemHost); ok && len(hosts) == 0 {
+		hosts = append(hosts, item.Host)
+	}
+
+	return hosts
 }
 
-func (m *listModel) onFocusChanged() tea.Cmd {
-	m.updateTitle()
-	m.updateKeyMap()
+// setIdentityFile - sets identity file of all selected hosts. Identity file is checked once, if it's missing
+// or ssh would refuse it, user is warned, but hosts are updated anyway.
+func (m *listModel) setIdentityFile(identityFile string) tea.Cmd {
+	hosts := m.markedHosts()
+	if len(hosts) == 0 {
+		m.logger.Error("[UI] Cannot set identity file. Hosts are not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
 
-	if hostItem, ok := m.SelectedItem().(ListItemHost); ok {
-		m.logger.Debug("[UI] Focus changed to host id: %v, title: %s", hostItem.ID, hostItem.Title())
+	var warning string
+	if err := utils.CheckPrivateKey(identityFile); identityFile != "" && errors.Is(err, os.ErrNotExist) {
+		warning = i18n.T("identity file does not exist")
+	} else if identityFile != "" && err != nil {
+		warning = err.Error()
+	}
 
-		return tea.Sequence(
-			message.TeaCmd(message.HostListSelectItem{HostID: hostItem.ID}),
-			message.TeaCmd(message.RunProcessSSHLoadConfig{Host: hostItem.Host}),
-		)
+	for _, h := range hosts {
+		m.logger.Info("[UI] Set identity file of host id: %d to '%s'", h.ID, identityFile)
+		h.IdentityFilePath = identityFile
+		savedHost, err := m.repo.Save(h)
+		if err != nil {
+			m.logger.Error("[UI] Cannot save host id: %d. %v", h.ID, err)
+			return message.TeaCmd(msgErrorOccurred{err})
+		}
+
+		_, index, _ := lo.FindIndexOf(m.Items(), hostWithID(savedHost.ID))
+		m.SetItem(index, ListItemHost{Host: savedHost})
+	}
+
+	clear(m.marked)
+	m.Title = i18n.Tf("identity file is set for %d hosts", len(hosts))
+	if warning != "" {
+		m.logger.Info("[UI] Identity file '%s' warning: %s", identityFile, warning)
+		m.Title = fmt.Sprintf("%s, %s", m.Title, warning)
 	}
 
-	m.logger.Debug("[UI] Focus is not set to any item in the list")
 	return nil
 }
 
-func (m *listModel) onHostSSHConfigLoaded(msg message.HostSSHConfigLoaded) {
-	for index, item := range m.Items() {
-		if hostListItem, ok := item.(ListItemHost); ok && hostListItem.ID == msg.HostID {
-			hostListItem.SSHClientConfig = &msg.Config
-			m.SetItem(index, hostListItem)
-			break
-		}
+func (m *listModel) listAgentKeys() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Debug("[UI] Cannot list agent keys. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
 	}
+
+	if item.IsScratch() {
+		return m.rejectScratch()
+	}
+
+	m.logger.Info("[UI] List ssh-agent keys")
+	return message.TeaCmd(message.RunProcessListAgentKeys{})
 }
 
-/*
- * Helper methods.
- */
+// pinAgentKey - makes ssh use only the chosen agent key when connecting to the focused host, instead of
+// trying all agent keys one by one. Empty key name unpins the key, identity file is left as is.
+func (m *listModel) pinAgentKey(keyName string) tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
 
-func (m *listModel) constructProcessCmd(processType constant.ProcessType) tea.Cmd {
+	agentKey, _ := lo.Find(m.agentKeys, func(k ssh.AgentKey) bool { return k.String() == keyName })
+	m.agentKeys = nil
+	h := item.Host
+	switch {
+	case keyName == "":
+		m.logger.Info("[UI] Unpin agent key of host id: %d", h.ID)
+		h.IdentitiesOnly = false
+	case !agentKey.IsFile():
+		// ssh matches agent key by the public key file, which path is derived from the identity file.
+		m.logger.Info("[UI] Cannot pin agent key '%s'. Key comment is not a file path", keyName)
+		m.Title = i18n.T("cannot pin the key, its comment is not a file path")
+		return nil
+	default:
+		m.logger.Info("[UI] Pin agent key '%s' to host id: %d", agentKey.Fingerprint, h.ID)
+		h.IdentityFilePath = agentKey.Comment
+		h.IdentitiesOnly = true
+	}
+
+	savedHost, err := m.repo.Save(h)
+	if err != nil {
+		m.logger.Error("[UI] Cannot save host id: %d. %v", h.ID, err)
+		return message.TeaCmd(msgErrorOccurred{err})
+	}
+
+	cmd := m.SetItem(m.Index(), ListItemHost{Host: savedHost})
+	m.updateTitle()
+
+	return cmd
+}
+
+// connect - connects to the selected host. If the host has several identity files, connection profiles or
+// port forwarding presets, user is asked to choose one of them first.
+func (m *listModel) connect() tea.Cmd {
+	if header, ok := m.SelectedItem().(ListItemGroup); ok {
+		// Group header cannot be connected to, it's collapsed or expanded instead.
+		return message.TeaCmd(MsgToggleGroup{Group: header.Name})
+	}
+
+	if m.bannerNotAcknowledged() {
+		return m.enterAcknowledgeBannerMode(m.connect)
+	}
+
+	if item, ok := m.SelectedItem().(ListItemHost); ok && len(item.IdentityFileCandidates()) > 1 {
+		return m.enterSelectIdentityFileMode()
+	}
+
+	return m.connectWithIdentityFile("")
+}
+
+// connectWithIdentityFile - remembers the identity file which user selected and offers connection profiles if
+// there are any. Empty value means the first candidate, which is the identity file of the host if it's set.
+func (m *listModel) connectWithIdentityFile(identityFile string) tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if ok && identityFile == "" && utils.StringEmpty(item.IdentityFilePath) {
+		identityFile, _ = lo.First(item.IdentityFileCandidates())
+	}
+
+	m.selectedIdentityFile = identityFile
+	if ok && len(item.Profiles) > 0 {
+		return m.enterSelectProfileMode()
+	}
+
+	return m.connectWithProfile("")
+}
+
+// connectWithProfile - remembers the profile which user selected and offers forward presets if there are any.
+func (m *listModel) connectWithProfile(profileName string) tea.Cmd {
+	m.selectedProfile = profileName
+	if item, ok := m.SelectedItem().(ListItemHost); ok && len(item.ForwardPresets) > 0 {
+		return m.enterSelectForwardPresetMode()
+	}
+
+	return m.connectWithForwardPreset("")
+}
+
+// fastConnect - connects to the selected host without reading ssh config files. Forward presets are not offered.
+func (m *listModel) fastConnect() tea.Cmd {
 	item, ok := m.SelectedItem().(ListItemHost)
 	if !ok {
 		m.logger.Error("[UI] Cannot cast selected item to host model")
-		return message.TeaCmd(msgErrorOccurred{err: errors.New(itemNotSelectedMessage)})
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
 	}
 
-	if processType == constant.ProcessTypeSSHConnect {
-		return message.TeaCmd(message.RunProcessSSHConnect{Host: item.Host})
-	} else if processType == constant.ProcessTypeSSHCopyID {
-		return message.TeaCmd(message.RunProcessSSHCopyID{Host: item.Host})
+	if m.bannerNotAcknowledged() {
+		return m.enterAcknowledgeBannerMode(m.fastConnect)
 	}
 
-	return nil
+	m.logger.Info("[UI] Fast connect to host id: %d, title: %s", item.ID, item.Title())
+	return m.runSSHConnect(message.RunProcessSSHConnect{Host: item.Host, Fast: true})
 }
 
-func (m *listModel) updateTitle() {
-	var newTitle string
+// tailLog - connects to the selected host and follows its log file. Profiles and forward presets are not offered.
+func (m *listModel) tailLog() tea.Cmd {
 	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
 
-	switch {
-	case !ok:
-		newTitle = defaultListTitle
-	case m.mode == modeRemoveItem:
-		newTitle = fmt.Sprintf("delete \"%s\" ? (y/N)", item.Title())
-	default:
-		// Replace Windows ssh prefix "cmd /c ssh" with "ssh"
-		newTitle = strings.Replace(item.Host.CmdSSHConnect(), "cmd /c ", "", 1)
-		newTitle = utils.RemoveDuplicateSpaces(newTitle)
+	if utils.StringEmpty(item.LogPath) {
+		m.Title = i18n.T("log path is not set")
+		return nil
 	}
 
-	if m.Title != newTitle {
-		m.Title = newTitle
-		m.logger.Debug("[UI] New list title: %s", m.Title)
+	if m.bannerNotAcknowledged() {
+		return m.enterAcknowledgeBannerMode(m.tailLog)
 	}
+
+	m.logger.Info("[UI] Tail log '%s' of host id: %d, title: %s", item.LogPath, item.ID, item.Title())
+	return m.runSSHConnect(message.RunProcessSSHConnect{Host: item.Host, TailLog: true})
 }
 
-func (m *listModel) updateKeyMap() {
-	shouldShowEditButtons := m.SelectedItem() != nil
+// openSFTP - opens an interactive sftp session with the selected host. Profiles and forward presets are not offered.
+func (m *listModel) openSFTP() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
 
-	if shouldShowEditButtons != m.keyMap.ShouldShowEditButtons() {
-		m.logger.Debug("[UI] Show edit keyboard shortcuts: %v", shouldShowEditButtons)
-		m.keyMap.SetShouldShowEditButtons(shouldShowEditButtons)
+	if m.bannerNotAcknowledged() {
+		return m.enterAcknowledgeBannerMode(m.openSFTP)
 	}
+
+	m.logger.Info("[UI] Open sftp session with host id: %d, title: %s", item.ID, item.Title())
+	return m.runSSHConnect(message.RunProcessSSHConnect{Host: item.Host, SFTP: true})
 }
 
-func (m *listModel) selectHostByID(id int) tea.Cmd {
-	// Use VisibleItems() instead of Items() because we need to find the correct index when deleting an item
-	// while in filter mode where part of the collection is hidden. You can replicate a wrong behavior when using Items():
-	// Enter filter mode, enter remove mode and then cancel it. The focus will be lost.
-	_, index, found := lo.FindIndexOf(m.VisibleItems(), func(item list.Item) bool {
-		hostItem, ok := item.(ListItemHost)
-		return ok && hostItem.ID == id
+func (m *listModel) connectWithForwardPreset(presetName string) tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	profileName, identityFile := m.selectedProfile, m.selectedIdentityFile
+	m.selectedProfile, m.selectedIdentityFile = "", ""
+	m.logger.Info("[UI] Connect to host id: %d using profile: '%s', forward preset: '%s', identity file: '%s'",
+		item.ID, profileName, presetName, identityFile)
+	return m.runSSHConnect(message.RunProcessSSHConnect{
+		Host:          item.Host,
+		ForwardPreset: presetName,
+		Profile:       profileName,
+		IdentityFile:  identityFile,
 	})
+}
 
-	if found {
-		// Here we should check if the item with this index is already selected.
-		// However, this will cause problems with title update when we enter remove
-		// mode and then cancel it.
-		m.Select(index)
+// connectByAlias - focuses the host which has the alias and connects to it as if user pressed enter.
+func (m *listModel) connectByAlias(alias string) tea.Cmd {
+	h, err := storage.GetByAlias(m.repo, alias)
+	if err != nil {
+		m.logger.Debug("[UI] Cannot find host by alias '%s'. %v", alias, err)
+		m.Title = i18n.Tf("alias \"%s\" not found", alias)
+		return nil
 	}
 
-	return m.onFocusChanged()
+	// The host can be hidden by the filter.
+	m.ResetFilter()
+	focusCmd := m.selectHostByID(h.ID)
+	if item, ok := m.SelectedItem().(ListItemHost); !ok || item.ID != h.ID {
+		m.logger.Error("[UI] Host id: %d is not in the list. Cancel quick connect.", h.ID)
+		return focusCmd
+	}
+
+	m.logger.Info("[UI] Quick connect to host id: %d by alias: '%s'", h.ID, alias)
+	return tea.Sequence(focusCmd, m.connect())
 }
 
-/*
- * Deal with actions which require confirmation from the user.
- */
+// runSSHConnect - dispatches connect message. If user wants to be warned about connections to the local
+// machine and the host, with the profile applied, points to a loopback address, user is asked for confirmation.
+func (m *listModel) runSSHConnect(msg message.RunProcessSSHConnect) tea.Cmd {
+	// Stored hosts are validated when they are saved, but scratch host is empty until user edits it.
+	if !msg.Host.HasDestination() {
+		m.logger.Debug("[UI] Cannot connect to host id: %d. Connect command has no destination", msg.Host.ID)
+		m.Title = i18n.T("host address is not set, press 'e' to edit the host")
+		return nil
+	}
 
-func (m *listModel) enterSSHCopyIDMode() tea.Cmd {
-	// Check if item is selected.
-	_, ok := m.SelectedItem().(ListItemHost)
-	if !ok {
-		m.logger.Debug("[UI] Cannot copy id. Host is not selected.")
-		return message.TeaCmd(msgErrorOccurred{err: errors.New(itemNotSelectedMessage)})
+	connectHost := msg.Host.WithIdentityFile(msg.IdentityFile)
+	connectHost = connectHost.WithProfile(msg.Profile)
+	if !m.appState.ApplicationConfig.WarnLoopback || !connectHost.ConnectsToLoopback() {
+		return m.checkLocalPortsAndConnect(msg)
 	}
 
-	m.mode = modeSSHCopyID
-	m.logger.Debug("[UI] Enter %s mode. Ask user for confirmation.", m.mode)
-	m.Title = "copy ssh key to the remote host? (y/N)"
+	m.mode = modeConfirmLoopback
+	m.afterConfirmed = func() tea.Cmd { return m.checkLocalPortsAndConnect(msg) }
+	m.logger.Debug("[UI] Enter %s mode. Host id: %d connects to a loopback address.", m.mode, msg.Host.ID)
+	m.Title = i18n.T("host connects to the local machine, connect anyway? (y/N)")
 
 	return nil
 }
 
-func (m *listModel) enterRemoveItemMode() tea.Cmd {
-	// Check if item is selected.
-	_, ok := m.SelectedItem().(ListItemHost)
-	if !ok {
-		m.logger.Debug("[UI] Cannot remove. Host is not selected.")
-		return message.TeaCmd(msgErrorOccurred{err: errors.New(itemNotSelectedMessage)})
+// checkLocalPortsAndConnect - makes sure that local ports of the port forwards are free before connecting,
+// otherwise ssh connects, but the tunnels don't work. User can free the ports and connect again, or connect
+// anyway, for instance when some of the forwards are not important. File transfer doesn't use port forwards.
+func (m *listModel) checkLocalPortsAndConnect(msg message.RunProcessSSHConnect) tea.Cmd {
+	if msg.SFTP {
+		return m.confirmByNameAndConnect(msg)
 	}
 
-	m.mode = modeRemoveItem
-	m.logger.Debug("[UI] Enter %s mode. Ask user for confirmation.", m.mode)
-	m.updateTitle()
+	busy := utils.UnavailableAddresses(msg.Host.LocalBindAddresses(msg.ForwardPreset))
+	if len(busy) == 0 {
+		return m.confirmByNameAndConnect(msg)
+	}
+
+	m.mode = modeConfirmBusyPorts
+	m.afterConfirmed = func() tea.Cmd { return m.confirmByNameAndConnect(msg) }
+	m.logger.Info("[UI] Enter %s mode. Local ports of host id: %d are in use: %v", m.mode, msg.Host.ID, busy)
+	m.Title = i18n.Tf("local ports are in use: %s, connect anyway? (y/N)", strings.Join(busy, ", "))
 
 	return nil
 }
 
-func (m *listModel) handleKeyEventWhenModeEnabled(msg tea.KeyMsg) tea.Cmd {
-	if key.Matches(msg, m.keyMap.confirm) {
-		return m.confirmAction()
+// confirmByNameAndConnect - asks user to type the exact title of the host before connecting, if the host requires it.
+// Unlike yes/no prompt, the title can't be confirmed out of habit, so it's used for the most sensitive hosts.
+func (m *listModel) confirmByNameAndConnect(msg message.RunProcessSSHConnect) tea.Cmd {
+	if !msg.Host.ConfirmByName {
+		return m.showNoteAndConnect(msg)
 	}
 
-	// If user doesn't confirm the operation, we go back to normal mode and update
-	// title back to normal, this exact key event won't be handled
-	m.logger.Debug("[UI] Exit %s mode. Cancel action.", m.mode)
+	m.mode = modeConfirmByName
+	m.afterConfirmed = func() tea.Cmd {
+		if m.prompt.Value() != msg.Host.Title {
+			m.logger.Info("[UI] Typed title does not match title of host id: %d. Cancel connection.", msg.Host.ID)
+			m.Title = i18n.T("title does not match, connection is cancelled")
+			return nil
+		}
 
-	if hostListItem, ok := m.SelectedItem().(ListItemHost); ok {
-		m.mode = modeDefault
-		return m.selectHostByID(hostListItem.ID)
+		m.logger.Info("[UI] Connection to host id: %d confirmed by title", msg.Host.ID)
+		return m.showNoteAndConnect(msg)
 	}
+	m.logger.Debug("[UI] Enter %s mode. Ask user to type title of host id: %d", m.mode, msg.Host.ID)
+
+	return m.showPrompt(i18n.Tf("type \"%s\" to connect: ", msg.Host.Title), "")
+}
+
+// showNoteAndConnect - displays notes of the host right before ssh takes over the screen, so user remembers
+// the context of the session. Any key dismisses the note and connects, escape key cancels the connection.
+func (m *listModel) showNoteAndConnect(msg message.RunProcessSSHConnect) tea.Cmd {
+	if utils.StringEmpty(msg.Host.Notes) {
+		return message.TeaCmd(msg)
+	}
+
+	m.mode = modeShowNote
+	m.afterConfirmed = func() tea.Cmd { return message.TeaCmd(msg) }
+	m.logger.Debug("[UI] Enter %s mode. Show notes of host id: %d", m.mode, msg.Host.ID)
+	// Title is a single line, notes may contain line breaks.
+	notes := strings.Join(strings.Fields(msg.Host.Notes), " ")
+	m.Title = i18n.Tf("note: %s (any key to connect, esc to cancel)", notes)
 
-	m.logger.Error("[UI] Exit %s mode, but cannot set focus on an item in the list of hosts.", m.mode)
-	m.mode = modeDefault
 	return nil
 }
 
-func (m *listModel) confirmAction() tea.Cmd {
-	m.logger.Debug("[UI] Exit %s mode. Confirm action.", m.mode)
+// checkAuth - logs in to the selected host without opening a shell, to check that authentication works, for
+// instance after the key was copied to the host. Result is displayed in the title.
+func (m *listModel) checkAuth() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
 
-	var cmd tea.Cmd
-	if m.mode == modeRemoveItem {
-		m.mode = modeDefault
-		cmd = m.removeItem() // removeItem triggers title and keymap updates. See "onFocusChanged" method.
-	} else if m.mode == modeSSHCopyID {
-		m.mode = modeDefault
+	// Otherwise ssh would take the remote command for the hostname, for instance when scratch host is empty.
+	if !item.HasDestination() {
+		m.logger.Debug("[UI] Cannot check authentication on host id: %d. Connect command has no destination", item.ID)
+		m.Title = i18n.T("host address is not set, press 'e' to edit the host")
+		return nil
+	}
+
+	m.logger.Info("[UI] Check authentication on host id: %d, title: %s", item.ID, item.Title())
+	m.Title = i18n.T("checking authentication...")
+	return message.TeaCmd(message.RunProcessSSHCheckAuth{Host: item.Host})
+}
+
+// updateSearchQuery - forwards the key to the filter input. When the query changes, the selected host is
+// remembered, so that it stays selected if it matches the new query, see onSearch.
+func (m *listModel) updateSearchQuery(msg tea.KeyMsg) tea.Cmd {
+	query := m.FilterValue()
+	selectedID := 0
+	if item, ok := m.SelectedItem().(ListItemHost); ok {
+		selectedID = item.ID
+	}
+
+	cmd := m.updateChildModel(msg)
+	if m.SettingFilter() && m.FilterValue() != query {
+		m.searchFocusID = selectedID
+	}
+
+	return cmd
+}
+
+// onSearch - keeps focus on the host which was selected before the search query changed. When the host
+// doesn't match the query, the first matching host is focused.
+func (m *listModel) onSearch(msg MsgSearch) tea.Cmd {
+	// Results of the outdated query are ignored, user is still typing.
+	if m.searchFocusID == 0 || msg.Query != m.FilterValue() {
+		return nil
+	}
+
+	m.logger.Debug("[UI] Search hosts: '%s'", msg.Query)
+	focusID := m.searchFocusID
+	m.searchFocusID = 0
+	if lo.ContainsBy(m.VisibleItems(), hostWithID(focusID)) {
+		return m.selectHostByID(focusID)
+	}
+
+	m.Select(0)
+	return m.onFocusChanged()
+}
+
+// toggleJumpHost - makes connections to all other hosts jump through the selected host until the application
+// is closed, see host.WithJumpHost. When the selected host is already the jump host, the selection is cleared.
+func (m *listModel) toggleJumpHost() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	if jumpHostID, jumpHost := m.appState.JumpHost(); jumpHost != "" && jumpHostID == item.ID {
+		m.logger.Info("[UI] Clear jump host id: %d", item.ID)
+		m.appState.SetJumpHost(0, "")
+		m.Title = i18n.T("connections don't jump through a host anymore")
+		return nil
+	}
+
+	destination := item.JumpDestination()
+	if destination == "" {
+		m.logger.Debug("[UI] Host id: %d cannot be used as a jump host", item.ID)
+		m.Title = i18n.T("host cannot be used as a jump host")
+		return nil
+	}
+
+	m.logger.Info("[UI] Set jump host id: %d, destination: '%s'", item.ID, destination)
+	m.appState.SetJumpHost(item.ID, destination)
+	m.Title = i18n.Tf("connections jump through %s", destination)
+	return nil
+}
+
+func (m *listModel) mountSSHFS(mountPoint string) tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	if utils.StringEmpty(mountPoint) {
+		m.logger.Debug("[UI] Mount point is empty. Cancel action.")
+		m.Title = i18n.T("mount point is required")
+		return nil
+	}
+
+	m.logger.Info("[UI] Mount remote file system of host id: %d into '%s'", item.ID, mountPoint)
+	return message.TeaCmd(message.RunProcessSSHFS{Host: item.Host, MountPoint: mountPoint})
+}
+
+func (m *listModel) openWebURL() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	if utils.StringEmpty(item.WebURL) {
+		m.Title = i18n.T("web url is not set")
+		return nil
+	}
+
+	webURL := item.ResolveWebURL()
+	m.logger.Info("[UI] Open web url '%s' of host id: %d", webURL, item.ID)
+	return message.TeaCmd(message.RunProcessOpenURL{URL: webURL})
+}
+
+func (m *listModel) copyPublicKey() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	// If identity file is not set explicitly, fall back to the one which ssh is going to use by default.
+	publicKeyPath := utils.PublicKeyPath(item.ResolvedIdentityFile())
+	m.logger.Info("[UI] Copy public key '%s' to clipboard", publicKeyPath)
+	publicKey, err := os.ReadFile(publicKeyPath)
+	if err != nil {
+		m.logger.Error("[UI] Cannot read public key. %v", err)
+		m.Title = i18n.Tf("cannot read public key '%s'", publicKeyPath)
+		return nil
+	}
+
+	if err = clipboardWriteAll(strings.TrimSpace(string(publicKey))); err != nil {
+		m.logger.Error("[UI] Cannot copy public key to clipboard. %v", err)
+		m.Title = i18n.T("cannot copy public key to clipboard")
+		return nil
+	}
+
+	m.Title = i18n.T("public key copied to clipboard")
+	return nil
+}
+
+// copyIdentityFilePath - copies path to the identity file which ssh uses for the selected host. It helps to debug
+// authentication issues, when it's not obvious which key is offered to the remote host.
+func (m *listModel) copyIdentityFilePath() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	identityFile := item.ResolvedIdentityFile()
+	if identityFile == "" {
+		m.logger.Debug("[UI] Identity file of host id: %d is unknown", item.ID)
+		m.Title = i18n.T("identity file is not set")
+		return nil
+	}
+
+	m.logger.Info("[UI] Copy identity file path '%s' to clipboard", identityFile)
+	if err := clipboardWriteAll(identityFile); err != nil {
+		m.logger.Error("[UI] Cannot copy identity file path to clipboard. %v", err)
+		m.Title = i18n.T("cannot copy identity file path to clipboard")
+		return nil
+	}
+
+	m.Title = i18n.Tf("identity file path copied to clipboard: %s", identityFile)
+	return nil
+}
+
+/*
+ * Event handlers - those events come from other components.
+ */
+
+// onHostUpdated - not only updates a new host, it also re-inserts the host into
+// a correct position of the host list, to keep it sorted.
+func (m *listModel) onHostUpdated(msg message.HostUpdated) tea.Cmd {
+	var cmd tea.Cmd
+	updatedItem := ListItemHost{Host: msg.Host}
+	if updatedItem.IsScratch() {
+		// Scratch host is always on top, it's kept in memory only.
+		m.scratch = msg.Host
+		return tea.Sequence(m.Model.SetItem(m.Index(), updatedItem), m.onFocusChanged())
+	}
+
+	if m.grouped() || strings.TrimSpace(msg.Host.Group) != "" {
+		// Group of the host may be changed, make sure the host is visible after the list is rebuilt.
+		delete(m.collapsedGroups, strings.TrimSpace(msg.Host.Group))
+		return tea.Sequence(m.reloadItems(hostWithID(msg.Host.ID)), m.onFocusChanged())
+	}
+
+	newIndex := m.sortedIndex(updatedItem, true)
+
+	if newIndex == m.Index() {
+		// Index isn't changed.
+		cmd = m.Model.SetItem(m.Index(), updatedItem)
+	} else {
+		// Index is changed, need to move the host into a new location
+		m.Model.RemoveItem(m.Index())
+		cmd = m.Model.InsertItem(newIndex, updatedItem)
+		m.Select(newIndex)
+	}
+
+	return tea.Sequence(cmd, m.onFocusChanged())
+}
+
+func (m *listModel) onHostCreated(msg message.HostCreated) tea.Cmd {
+	if m.grouped() || strings.TrimSpace(msg.Host.Group) != "" {
+		delete(m.collapsedGroups, strings.TrimSpace(msg.Host.Group))
+		return tea.Sequence(m.reloadItems(hostWithID(msg.Host.ID)), m.onFocusChanged())
+	}
+
+	listItem := ListItemHost{Host: msg.Host}
+	index := m.sortedIndex(listItem, false)
+	cmd := m.Model.InsertItem(index, listItem)
+
+	m.Select(index)
+
+	return tea.Sequence(
+		// If host position coincides with other host, then let the underlying model to handle that
+		cmd,
+		m.onFocusChanged(),
+	)
+}
+
+func (m *listModel) onFocusChanged() tea.Cmd {
+	m.updateTitle()
+	m.updateKeyMap()
+
+	if hostItem, ok := m.SelectedItem().(ListItemHost); ok {
+		m.logger.Debug("[UI] Focus changed to host id: %v, title: %s", hostItem.ID, hostItem.Title())
+
+		return tea.Sequence(
+			message.TeaCmd(message.HostListSelectItem{HostID: hostItem.ID}),
+			message.TeaCmd(message.RunProcessSSHLoadConfig{Host: hostItem.Host}),
+		)
+	}
+
+	m.logger.Debug("[UI] Focus is not set to any item in the list")
+	return nil
+}
+
+func (m *listModel) onHostSSHConfigLoaded(msg message.HostSSHConfigLoaded) {
+	for index, item := range m.Items() {
+		if hostListItem, ok := item.(ListItemHost); ok && hostListItem.ID == msg.HostID {
+			hostListItem.SSHClientConfig = &msg.Config
+			m.SetItem(index, hostListItem)
+			break
+		}
+	}
+}
+
+/*
+ * Helper methods.
+ */
+
+// loadHosts - reads hosts from the storage, hosts are sorted in the order which user chose and filtered by the tag
+// if the filter is set.
+func (m *listModel) loadHosts() ([]hostModel.Host, error) {
+	hosts, err := m.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	sortHosts(hosts, m.sortOrder())
+	if m.tagFilter != "" {
+		hosts = lo.Filter(hosts, func(h hostModel.Host, _ int) bool { return h.HasTag(m.tagFilter) })
+	}
+
+	return hosts, nil
+}
+
+// sortHosts - sorts hosts in the order, favorite hosts go first. Hosts which were connected at the same time, or
+// never connected, are sorted by title, so the order is the same every time. Grouping is done by buildItems, so
+// grouped hosts are sorted by title here.
+func sortHosts(hosts []hostModel.Host, order constant.HostSortOrder) {
+	slices.SortFunc(hosts, func(a, b hostModel.Host) int {
+		if a.IsFavorite != b.IsFavorite {
+			return lo.Ternary(a.IsFavorite, -1, 1)
+		}
+
+		if order == constant.HostSortLastConnected && !a.LastConnected.Equal(b.LastConnected) {
+			return b.LastConnected.Compare(a.LastConnected)
+		}
+
+		if a.Title != b.Title {
+			return strings.Compare(a.Title, b.Title)
+		}
+
+		return a.ID - b.ID
+	})
+}
+
+// sortOrder - returns sort order of hosts which user chose, hosts are grouped by default.
+func (m *listModel) sortOrder() constant.HostSortOrder {
+	if m.appState.HostSortOrder == "" {
+		return constant.HostSortGroup
+	}
+
+	return m.appState.HostSortOrder
+}
+
+// cycleSort - switches sort order of hosts from title to last connection, then to group and back to title.
+// Focus stays on the selected host.
+func (m *listModel) cycleSort() tea.Cmd {
+	next := map[constant.HostSortOrder]constant.HostSortOrder{
+		constant.HostSortTitle:         constant.HostSortLastConnected,
+		constant.HostSortLastConnected: constant.HostSortGroup,
+		constant.HostSortGroup:         constant.HostSortTitle,
+	}
+	m.appState.HostSortOrder = next[m.sortOrder()]
+	m.logger.Debug("[UI] Sort hosts by: '%s'", m.sortOrder())
+
+	var focus func(list.Item) bool
+	if item, ok := m.SelectedItem().(ListItemHost); ok {
+		focus = hostWithID(item.ID)
+	}
+
+	cmd := m.reloadItems(focus)
+	switch m.sortOrder() {
+	case constant.HostSortTitle:
+		m.Title = i18n.T("hosts are sorted by title")
+	case constant.HostSortLastConnected:
+		m.Title = i18n.T("hosts are sorted by last connection")
+	default:
+		m.Title = i18n.T("hosts are sorted by group")
+	}
+
+	return cmd
+}
+
+// buildItems - wraps hosts into list items, scratch host is always on top. When hosts are sorted by group and any
+// host has a group, hosts are displayed under group headers sorted by name, hosts without a group go under
+// "Ungrouped" header which is always the last one. Hosts of collapsed groups are not added to the list.
+func (m *listModel) buildItems(hosts []hostModel.Host) []list.Item {
+	items := make([]list.Item, 0, len(hosts)+1)
+	items = append(items, ListItemHost{Host: m.scratch})
+
+	hostGroup := func(h hostModel.Host) string { return strings.TrimSpace(h.Group) }
+	hasGroups := lo.SomeBy(hosts, func(h hostModel.Host) bool { return hostGroup(h) != "" })
+	if m.sortOrder() != constant.HostSortGroup || !hasGroups {
+		for _, h := range hosts {
+			items = append(items, ListItemHost{Host: h})
+		}
+
+		return items
+	}
+
+	groups := lo.GroupBy(hosts, hostGroup)
+	names := lo.Keys(groups)
+	slices.SortFunc(names, func(a, b string) int {
+		if a == "" || b == "" {
+			// Hosts without a group go last.
+			return strings.Compare(b, a)
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	for _, name := range names {
+		collapsed := m.collapsedGroups[name]
+		items = append(items, ListItemGroup{Name: name, Collapsed: collapsed, Count: len(groups[name])})
+		if collapsed {
+			continue
+		}
+
+		for _, h := range groups[name] {
+			items = append(items, ListItemHost{Host: h})
+		}
+	}
+
+	return items
+}
+
+// grouped - returns true if hosts are displayed under group headers.
+func (m *listModel) grouped() bool {
+	return lo.SomeBy(m.Items(), func(item list.Item) bool {
+		_, ok := item.(ListItemGroup)
+		return ok
+	})
+}
+
+// reloadItems - re-reads hosts from the storage and rebuilds the list, because position of a host depends on its
+// group when hosts are grouped. Focus is set to the first item which matches the predicate, nil leaves it as is.
+func (m *listModel) reloadItems(focus func(list.Item) bool) tea.Cmd {
+	hosts, err := m.loadHosts()
+	if err != nil {
+		m.logger.Error("[UI] Cannot read database. %v", err)
+		return message.TeaCmd(msgErrorOccurred{err})
+	}
+
+	cmd := m.SetItems(m.buildItems(hosts))
+	if focus != nil {
+		m.selectItem(focus)
+	}
+
+	return cmd
+}
+
+// selectItem - sets focus to the first item which matches the predicate, if any.
+func (m *listModel) selectItem(predicate func(list.Item) bool) {
+	if _, index, found := lo.FindIndexOf(m.Items(), predicate); found {
+		m.Select(index)
+	}
+}
+
+// hostWithID - returns a predicate which matches the host with the id.
+func hostWithID(id int) func(list.Item) bool {
+	return func(item list.Item) bool {
+		hostItem, ok := item.(ListItemHost)
+		return ok && hostItem.ID == id
+	}
+}
+
+// toggleSelectedGroup - collapses or expands the group which header or host is focused.
+func (m *listModel) toggleSelectedGroup() tea.Cmd {
+	if !m.grouped() {
+		m.Title = i18n.T("hosts are not grouped")
+		return nil
+	}
+
+	switch item := m.SelectedItem().(type) {
+	case ListItemGroup:
+		return message.TeaCmd(MsgToggleGroup{Group: item.Name})
+	case ListItemHost:
+		if !item.IsScratch() {
+			return message.TeaCmd(MsgToggleGroup{Group: strings.TrimSpace(item.Group)})
+		}
+	}
+
+	return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+}
+
+// toggleGroup - collapses or expands the group, focus is moved to the header of the group.
+func (m *listModel) toggleGroup(group string) tea.Cmd {
+	m.collapsedGroups[group] = !m.collapsedGroups[group]
+	m.logger.Debug("[UI] Group '%s' collapsed: %v", group, m.collapsedGroups[group])
+
+	cmd := m.reloadItems(func(item list.Item) bool {
+		header, ok := item.(ListItemGroup)
+		return ok && header.Name == group
+	})
+
+	return tea.Sequence(cmd, m.onFocusChanged())
+}
+
+// sortedIndex - returns position of the item in the list which is sorted in the chosen order, see sortHosts. When
+// the item is updated, its current position is not taken into account. Scratch host is always on top and is not
+// sorted.
+func (m *listModel) sortedIndex(listItem ListItemHost, updated bool) int {
+	scratchCount := 0
+	// When sorting, shall we take description into account as well or sorting by title is enough ?
+	hosts := lo.FilterMap(m.Items(), func(item list.Item, _ int) (hostModel.Host, bool) {
+		hostItem := item.(ListItemHost)
+		if hostItem.IsScratch() {
+			scratchCount++
+			return hostModel.Host{}, false
+		}
+
+		return hostItem.Host, !updated || hostItem.ID != listItem.ID
+	})
+
+	hosts = append(hosts, listItem.Host)
+	sortHosts(hosts, m.sortOrder())
+
+	return scratchCount + slices.IndexFunc(hosts, func(h hostModel.Host) bool {
+		return h.ID == listItem.ID && h.Title == listItem.Title() && h.IsFavorite == listItem.IsFavorite
+	})
+}
+
+// rejectScratch - explains that the action is not available for the scratch host, because it's never stored.
+func (m *listModel) rejectScratch() tea.Cmd {
+	m.logger.Debug("[UI] Action is not available for the scratch host")
+	m.Title = i18n.T("scratch host is not stored, clone it to keep it")
+
+	return nil
+}
+
+func (m *listModel) constructProcessCmd(processType constant.ProcessType) tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Cannot cast selected item to host model")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	if processType == constant.ProcessTypeSSHConnect {
+		return message.TeaCmd(message.RunProcessSSHConnect{Host: item.Host})
+	} else if processType == constant.ProcessTypeSSHCopyID {
+		return message.TeaCmd(message.RunProcessSSHCopyID{Host: item.Host})
+	}
+
+	return nil
+}
+
+func (m *listModel) updateTitle() {
+	var newTitle string
+	item, ok := m.SelectedItem().(ListItemHost)
+
+	_, isHeader := m.SelectedItem().(ListItemGroup)
+
+	switch {
+	case isHeader:
+		newTitle = i18n.T("press enter to collapse or expand the group")
+	case !ok:
+		newTitle = i18n.T(defaultListTitle)
+	case m.mode == modeRemoveItem:
+		newTitle = i18n.Tf("delete \"%s\" ? (y/N)", item.Title())
+	default:
+		// Replace Windows ssh prefix "cmd /c ssh" with "ssh"
+		newTitle = strings.Replace(item.Host.CmdSSHConnectPreview(), "cmd /c ", "", 1)
+		newTitle = utils.RemoveDuplicateSpaces(newTitle)
+	}
+
+	if m.Title != newTitle {
+		m.Title = newTitle
+		m.logger.Debug("[UI] New list title: %s", m.Title)
+	}
+}
+
+func (m *listModel) updateKeyMap() {
+	shouldShowEditButtons := m.SelectedItem() != nil
+
+	if shouldShowEditButtons != m.keyMap.ShouldShowEditButtons() {
+		m.logger.Debug("[UI] Show edit keyboard shortcuts: %v", shouldShowEditButtons)
+		m.keyMap.SetShouldShowEditButtons(shouldShowEditButtons)
+	}
+}
+
+func (m *listModel) selectHostByID(id int) tea.Cmd {
+	// Use VisibleItems() instead of Items() because we need to find the correct index when deleting an item
+	// while in filter mode where part of the collection is hidden. You can replicate a wrong behavior when using Items():
+	// Enter filter mode, enter remove mode and then cancel it. The focus will be lost.
+	_, index, found := lo.FindIndexOf(m.VisibleItems(), func(item list.Item) bool {
+		hostItem, ok := item.(ListItemHost)
+		return ok && hostItem.ID == id
+	})
+
+	if found {
+		// Here we should check if the item with this index is already selected.
+		// However, this will cause problems with title update when we enter remove
+		// mode and then cancel it.
+		m.Select(index)
+	}
+
+	return m.onFocusChanged()
+}
+
+// selectRecentHost - moves focus between two recently used hosts, like alt-tab does.
+func (m *listModel) selectRecentHost() tea.Cmd {
+	selectedID := 0
+	if item, ok := m.SelectedItem().(ListItemHost); ok {
+		selectedID = item.ID
+	}
+
+	// Recent host may be removed or hidden by the filter.
+	hostID, ok := m.appState.AlternateRecentHost(selectedID)
+	if !ok || !lo.ContainsBy(m.VisibleItems(), func(item list.Item) bool {
+		hostItem, isHost := item.(ListItemHost)
+		return isHost && hostItem.ID == hostID
+	}) {
+		m.logger.Debug("[UI] No recent host to switch to")
+		m.Title = i18n.T("no recent host to switch to")
+		return nil
+	}
+
+	m.logger.Debug("[UI] Switch to recent host id: %d", hostID)
+	return m.selectHostByID(hostID)
+}
+
+/*
+ * Deal with actions which require confirmation from the user.
+ */
+
+func (m *listModel) enterSSHCopyIDMode() tea.Cmd {
+	// Check if item is selected.
+	_, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Debug("[UI] Cannot copy id. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	m.mode = modeSSHCopyID
+	m.logger.Debug("[UI] Enter %s mode. Ask user for confirmation.", m.mode)
+	m.Title = i18n.T("copy ssh key to the remote host? (y/N)")
+
+	return nil
+}
+
+func (m *listModel) enterCloneToGroupMode() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Debug("[UI] Cannot clone to group. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	m.mode = modeCloneToGroup
+	m.logger.Debug("[UI] Enter %s mode. Ask user for the target group.", m.mode)
+	return m.showPrompt(i18n.T("clone to group: "), item.Group)
+}
+
+func (m *listModel) enterMountSSHFSMode() tea.Cmd {
+	_, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Debug("[UI] Cannot mount sshfs. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	m.mode = modeMountSSHFS
+	m.logger.Debug("[UI] Enter %s mode. Ask user for the mount point.", m.mode)
+	return m.showPrompt(i18n.T("mount point: "), "")
+}
+
+func (m *listModel) enterSetIdentityFileMode() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Debug("[UI] Cannot set identity file. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	if item.IsScratch() {
+		return m.rejectScratch()
+	}
+
+	m.mode = modeSetIdentityFile
+	m.logger.Debug("[UI] Enter %s mode. Ask user for the identity file.", m.mode)
+	return m.showPrompt(i18n.Tf("identity file of %d hosts: ", len(m.markedHosts())), item.IdentityFilePath)
+}
+
+func (m *listModel) enterQuickConnectMode() tea.Cmd {
+	m.mode = modeQuickConnect
+	m.logger.Debug("[UI] Enter %s mode. Ask user for the host alias.", m.mode)
+	return m.showPrompt(i18n.T("connect to alias: "), "")
+}
+
+func (m *listModel) enterFilterByTagMode() tea.Cmd {
+	m.mode = modeFilterByTag
+	m.logger.Debug("[UI] Enter %s mode. Ask user for the tag.", m.mode)
+	return m.showPrompt(i18n.T("filter by tag (empty to clear): "), m.tagFilter)
+}
+
+// showPrompt - displays a text input in place of the list title.
+func (m *listModel) showPrompt(prompt, value string) tea.Cmd {
+	m.prompt = textinput.New()
+	m.prompt.Prompt = prompt
+	m.prompt.CharLimit = 128
+	m.prompt.SetValue(value)
+	m.prompt.Focus()
+	m.Title = m.prompt.View()
+
+	return textinput.Blink
+}
+
+// bannerNotAcknowledged - returns true if the selected host has a banner which user
+// hasn't acknowledged during the current session.
+func (m *listModel) bannerNotAcknowledged() bool {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok || utils.StringEmpty(item.Banner) {
+		return false
+	}
+
+	return !m.appState.IsBannerAcknowledged(item.ID, item.Banner)
+}
+
+// enterAcknowledgeBannerMode - displays banner of the selected host, next is invoked when user acknowledges it.
+func (m *listModel) enterAcknowledgeBannerMode(next func() tea.Cmd) tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Debug("[UI] Cannot acknowledge banner. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	m.mode = modeAcknowledgeBanner
+	m.afterConfirmed = next
+	m.logger.Debug("[UI] Enter %s mode. Ask user to acknowledge the banner.", m.mode)
+	// Title is a single line, banner may contain line breaks.
+	banner := strings.Join(strings.Fields(item.Banner), " ")
+	m.Title = i18n.Tf("%s acknowledge and connect? (y/N)", banner)
+
+	return nil
+}
+
+func (m *listModel) enterSelectForwardPresetMode() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Debug("[UI] Cannot select forward preset. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	m.mode = modeSelectForward
+	m.logger.Debug("[UI] Enter %s mode. Ask user to choose a forward preset.", m.mode)
+	m.Title = pickerTitle(i18n.T("forwards: 0) none"), item.ForwardPresetNames())
+
+	return nil
+}
+
+func (m *listModel) enterSelectIdentityFileMode() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Debug("[UI] Cannot select identity file. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	m.mode = modeSelectIdentityFile
+	m.logger.Debug("[UI] Enter %s mode. Ask user to choose an identity file.", m.mode)
+	m.Title = pickerTitle(i18n.T("identity files:"), item.IdentityFileCandidates())
+
+	return nil
+}
+
+func (m *listModel) enterSelectProfileMode() tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Debug("[UI] Cannot select connection profile. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	m.mode = modeSelectProfile
+	m.logger.Debug("[UI] Enter %s mode. Ask user to choose a connection profile.", m.mode)
+	m.Title = pickerTitle(i18n.T("profiles: 0) default"), item.ProfileNames())
+
+	return nil
+}
+
+func (m *listModel) enterSelectAgentKeyMode(keys []ssh.AgentKey) tea.Cmd {
+	if _, ok := m.SelectedItem().(ListItemHost); !ok {
+		m.logger.Debug("[UI] Cannot select agent key. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	if len(keys) == 0 {
+		m.Title = i18n.T("the agent has no keys")
+		return nil
+	}
+
+	m.agentKeys = keys
+	m.mode = modeSelectAgentKey
+	m.logger.Debug("[UI] Enter %s mode. Ask user to choose an agent key.", m.mode)
+	m.Title = pickerTitle(i18n.T("agent keys: 0) none"), m.agentKeyNames())
+
+	return nil
+}
+
+func (m *listModel) agentKeyNames() []string {
+	return lo.Map(m.agentKeys, func(k ssh.AgentKey, _ int) string { return k.String() })
+}
+
+// maxPickerKeyOption - is the largest option number which is picked with a single key.
+const maxPickerKeyOption = 9
+
+// pickerTitle - renders names as a numbered list after the header. Ex: "forwards: 0) none 1) db 2) web".
+// When numbers don't fit into a single key, the list ends with a prompt for the number of the option.
+func pickerTitle(header string, names []string) string {
+	sb := strings.Builder{}
+	sb.WriteString(header)
+	for i, name := range names {
+		sb.WriteString(fmt.Sprintf(" %d) %s", i+1, name))
+	}
+
+	if len(names) > maxPickerKeyOption {
+		sb.WriteString(" > ")
+	}
+
+	return sb.String()
+}
+
+func (m *listModel) enterRemoveItemMode() tea.Cmd {
+	// Check if item is selected.
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Debug("[UI] Cannot remove. Host is not selected.")
+		return message.TeaCmd(msgErrorOccurred{err: errors.New(i18n.T(itemNotSelectedMessage))})
+	}
+
+	if item.IsScratch() {
+		return m.rejectScratch()
+	}
+
+	m.mode = modeRemoveItem
+	m.logger.Debug("[UI] Enter %s mode. Ask user for confirmation.", m.mode)
+	m.updateTitle()
+
+	return nil
+}
+
+func (m *listModel) handleKeyEventWhenModeEnabled(msg tea.KeyMsg) tea.Cmd {
+	if m.mode == modeCloneToGroup || m.mode == modeMountSSHFS || m.mode == modeSetIdentityFile ||
+		m.mode == modeQuickConnect || m.mode == modeConfirmByName || m.mode == modeFilterByTag {
+		return m.handleKeyEventWhenPromptEnabled(msg)
+	}
+
+	if m.mode == modeSelectForward || m.mode == modeSelectProfile || m.mode == modeSelectAgentKey ||
+		m.mode == modeSelectIdentityFile {
+		return m.handleKeyEventWhenPickerEnabled(msg)
+	}
+
+	if key.Matches(msg, m.keyMap.confirm) || (m.mode == modeShowNote && msg.Type != tea.KeyEsc) {
+		return m.confirmAction()
+	}
+
+	// If user doesn't confirm the operation, we go back to normal mode and update
+	// title back to normal, this exact key event won't be handled
+	m.logger.Debug("[UI] Exit %s mode. Cancel action.", m.mode)
+	m.afterConfirmed = nil
+
+	if hostListItem, ok := m.SelectedItem().(ListItemHost); ok {
+		m.mode = modeDefault
+		return m.selectHostByID(hostListItem.ID)
+	}
+
+	m.logger.Error("[UI] Exit %s mode, but cannot set focus on an item in the list of hosts.", m.mode)
+	m.mode = modeDefault
+	return nil
+}
+
+// handleKeyEventWhenPromptEnabled - forwards key events to the prompt until user accepts or cancels the input.
+func (m *listModel) handleKeyEventWhenPromptEnabled(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEnter:
+		return m.confirmAction()
+	case tea.KeyEsc:
+		m.logger.Debug("[UI] Exit %s mode. Cancel action.", m.mode)
+		m.mode = modeDefault
+		m.afterConfirmed = nil
+		m.updateTitle()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	m.Title = m.prompt.View()
+
+	return cmd
+}
+
+// handleKeyEventWhenPickerEnabled - picks identity file, connection profile, forward preset or agent key which number
+// user pressed. Enter key picks the default option, any other key cancels the action. Agent key has no default
+// option, because unpinning the key changes the host, so Enter key cancels the action as well. When there are more
+// than 9 options, user types the number and accepts it with Enter key.
+func (m *listModel) handleKeyEventWhenPickerEnabled(msg tea.KeyMsg) tea.Cmd {
+	item, ok := m.SelectedItem().(ListItemHost)
+	if !ok {
+		m.logger.Error("[UI] Exit %s mode, but cannot find selected item in the list of hosts.", m.mode)
+		m.mode = modeDefault
+		return nil
+	}
+
+	names, pick := item.ForwardPresetNames(), m.connectWithForwardPreset
+	if m.mode == modeSelectProfile {
+		names, pick = item.ProfileNames(), m.connectWithProfile
+	} else if m.mode == modeSelectAgentKey {
+		names, pick = m.agentKeyNames(), m.pinAgentKey
+	} else if m.mode == modeSelectIdentityFile {
+		names, pick = item.IdentityFileCandidates(), m.connectWithIdentityFile
+	}
+
+	isDigit := msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && unicode.IsDigit(msg.Runes[0])
+	if len(names) > maxPickerKeyOption && isDigit {
+		// Typed number is displayed after the options, see pickerTitle.
+		m.pickerInput += string(msg.Runes)
+		m.Title += string(msg.Runes)
+		return nil
+	}
+
+	typed := m.pickerInput
+	m.pickerInput = ""
+	mode := m.mode
+	m.logger.Debug("[UI] Exit %s mode.", m.mode)
+	m.mode = modeDefault
+	m.updateTitle()
+
+	option := msg.String()
+	if msg.Type == tea.KeyEnter {
+		if typed == "" && mode != modeSelectAgentKey {
+			return pick("")
+		}
+
+		option = typed
+	}
+
+	index, err := strconv.Atoi(option)
+	if err != nil || index < 0 || index > len(names) {
+		m.logger.Debug("[UI] Option is not selected. Cancel action.")
+		m.selectedProfile = ""
+		m.selectedIdentityFile = ""
+		m.agentKeys = nil
+		return nil
+	}
+
+	if index == 0 {
+		return pick("")
+	}
+
+	return pick(names[index-1])
+}
+
+func (m *listModel) confirmAction() tea.Cmd {
+	m.logger.Debug("[UI] Exit %s mode. Confirm action.", m.mode)
+
+	var cmd tea.Cmd
+	if m.mode == modeRemoveItem {
+		m.mode = modeDefault
+		cmd = m.removeItem() // removeItem triggers title and keymap updates. See "onFocusChanged" method.
+	} else if m.mode == modeSSHCopyID {
+		m.mode = modeDefault
 		m.updateTitle()
 		cmd = m.constructProcessCmd(constant.ProcessTypeSSHCopyID)
+	} else if m.mode == modeCloneToGroup {
+		m.mode = modeDefault
+		m.updateTitle()
+		cmd = m.copyItemToGroup(strings.TrimSpace(m.prompt.Value()))
+	} else if m.mode == modeAcknowledgeBanner {
+		m.mode = modeDefault
+		m.updateTitle()
+		if item, ok := m.SelectedItem().(ListItemHost); ok {
+			m.logger.Info("[UI] Banner of host id: %d acknowledged", item.ID)
+			m.appState.AcknowledgeBanner(item.ID, item.Banner)
+			// Connection may ask for another confirmation, so the callback is cleared before it's invoked.
+			afterConfirmed := m.afterConfirmed
+			m.afterConfirmed = nil
+			cmd = afterConfirmed()
+		}
+	} else if m.mode == modeConfirmLoopback {
+		m.mode = modeDefault
+		m.updateTitle()
+		m.logger.Info("[UI] Connection to the local machine confirmed")
+		// Notes of the host can be displayed next, so the callback is cleared before it's invoked.
+		afterConfirmed := m.afterConfirmed
+		m.afterConfirmed = nil
+		cmd = afterConfirmed()
+	} else if m.mode == modeConfirmBusyPorts {
+		m.mode = modeDefault
+		m.updateTitle()
+		m.logger.Info("[UI] Connection with busy local ports confirmed")
+		afterConfirmed := m.afterConfirmed
+		m.afterConfirmed = nil
+		cmd = afterConfirmed()
+	} else if m.mode == modeConfirmByName {
+		m.mode = modeDefault
+		m.updateTitle()
+		// Typed title is checked by the callback, notes of the host can be displayed next.
+		afterConfirmed := m.afterConfirmed
+		m.afterConfirmed = nil
+		cmd = afterConfirmed()
+	} else if m.mode == modeShowNote {
+		m.mode = modeDefault
+		m.updateTitle()
+		m.logger.Debug("[UI] Notes dismissed")
+		cmd = m.afterConfirmed()
+		m.afterConfirmed = nil
+	} else if m.mode == modeMountSSHFS {
+		m.mode = modeDefault
+		m.updateTitle()
+		cmd = m.mountSSHFS(strings.TrimSpace(m.prompt.Value()))
+	} else if m.mode == modeSetIdentityFile {
+		m.mode = modeDefault
+		m.updateTitle()
+		cmd = m.setIdentityFile(strings.TrimSpace(m.prompt.Value()))
+	} else if m.mode == modeQuickConnect {
+		m.mode = modeDefault
+		m.updateTitle()
+		cmd = m.connectByAlias(strings.TrimSpace(m.prompt.Value()))
+	} else if m.mode == modeFilterByTag {
+		m.mode = modeDefault
+		m.updateTitle()
+		cmd = message.TeaCmd(MsgFilterByTag{Tag: strings.TrimSpace(m.prompt.Value())})
 	}
 
 	return cmd
@@ -4,10 +4,19 @@ package hostlist
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -17,6 +26,7 @@ import (
 	"github.com/grafviktor/goto/internal/state"
 	"github.com/grafviktor/goto/internal/test"
 	"github.com/grafviktor/goto/internal/ui/message"
+	"github.com/grafviktor/goto/internal/ui/theme"
 )
 
 func TestListModel_Init(t *testing.T) {
@@ -44,9 +54,10 @@ func TestListModel_Init(t *testing.T) {
 		},
 	})
 
-	// Check that hosts are filtered by Title
-	require.Equal(t, "Mock Host 1", lm.Items()[0].(ListItemHost).Title())
-	require.Equal(t, "Mock Host 2", lm.Items()[1].(ListItemHost).Title())
+	// Check that scratch host is on top and hosts are sorted by Title
+	require.Equal(t, host.ScratchHostID, lm.Items()[0].(ListItemHost).ID)
+	require.Equal(t, "Mock Host 1", lm.Items()[1].(ListItemHost).Title())
+	require.Equal(t, "Mock Host 2", lm.Items()[2].(ListItemHost).Title())
 	// Check that currently selected item is "1"
 	require.Equal(t, 1, lm.SelectedItem().(ListItemHost).ID)
 
@@ -280,6 +291,19 @@ func TestEnterSSHCopyIDMode(t *testing.T) {
 	require.Equal(t, model.Title, "copy ssh key to the remote host? (y/N)")
 }
 
+func TestUpdate_SuggestSSHCopyID(t *testing.T) {
+	model := *NewMockListModel(false)
+	model.Select(0)
+	model.Update(message.SuggestSSHCopyID{})
+
+	require.Equal(t, modeSSHCopyID, model.mode)
+	require.Equal(t, "permission denied (publickey). copy ssh key to the remote host? (y/N)", model.Title)
+
+	// Once user confirms, ssh-copy-id process should be started
+	cmd := model.confirmAction()
+	require.IsType(t, message.RunProcessSSHCopyID{}, cmd())
+}
+
 func TestEnterRemoveItemMode(t *testing.T) {
 	// Create a new model
 	model := *NewMockListModel(false)
@@ -461,6 +485,227 @@ func TestListModel_copyItem(t *testing.T) {
 	require.Equal(t, "Mock Host 1 (1)", host.Title)
 }
 
+func TestListModel_copyItemToGroup(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(0)
+
+	// Press 'C' and type the target group name
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	require.Equal(t, modeCloneToGroup, lm.mode)
+	for _, r := range "prod" {
+		lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	require.Contains(t, lm.Title, "clone to group: prod")
+
+	lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, lm.mode)
+
+	// Cloned host has a group, so the list is rebuilt and mock storage is sorted by title
+	hosts, err := lm.repo.GetAll()
+	require.NoError(t, err)
+	original, _ := lo.Find(hosts, func(h host.Host) bool { return h.Title == "Mock Host 1" })
+	cloned, found := lo.Find(hosts, func(h host.Host) bool { return h.Title == "Mock Host 1 (1)" })
+	require.True(t, found)
+
+	// Group is changed, all other fields are preserved
+	require.Equal(t, "prod", cloned.Group)
+	require.Equal(t, "Mock Host 1 (1)", cloned.Title)
+	require.NotEqual(t, original.ID, cloned.ID)
+	require.Equal(t, original.Address, cloned.Address)
+	require.Equal(t, original.LoginName, cloned.LoginName)
+	require.Equal(t, original.RemotePort, cloned.RemotePort)
+	require.Equal(t, original.IdentityFilePath, cloned.IdentityFilePath)
+	// Original host is untouched
+	require.Empty(t, original.Group)
+	require.Equal(t, "Mock Host 1", original.Title)
+}
+
+func TestListModel_copyItemToGroup_Cancel(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(0)
+
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	lm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	require.Equal(t, modeDefault, lm.mode)
+	hosts, _ := lm.repo.GetAll()
+	require.Len(t, hosts, 3)
+}
+
+func TestListModel_mountSSHFS(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(0)
+
+	// Press 'm' and type the mount point
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	require.Equal(t, modeMountSSHFS, lm.mode)
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/mnt/remote")})
+	require.Contains(t, lm.Title, "mount point: /mnt/remote")
+
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, lm.mode)
+	msg, ok := cmd().(message.RunProcessSSHFS)
+	require.True(t, ok)
+	require.Equal(t, "/mnt/remote", msg.MountPoint)
+	require.Equal(t, lm.SelectedItem().(ListItemHost).ID, msg.Host.ID)
+}
+
+func TestListModel_mountSSHFS_EmptyMountPoint(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(0)
+
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, cmd)
+	require.Equal(t, modeDefault, lm.mode)
+	require.Equal(t, "mount point is required", lm.Title)
+}
+
+func TestListModel_toggleMark(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.SetSize(100, 30)
+	lm.Select(1)
+
+	lm.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	require.Contains(t, lm.marked, 2)
+	require.Equal(t, "1 hosts selected", lm.Title)
+	require.Contains(t, lm.View(), "✓ Mock Host 2")
+	require.NotContains(t, lm.View(), "✓ Mock Host 1")
+
+	// Press space once again to deselect the host
+	lm.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	require.Empty(t, lm.marked)
+	require.NotContains(t, lm.View(), "✓")
+}
+
+func TestHostDelegate_RemoteOSIcon(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.SetSize(100, 30)
+	item := lm.Items()[1].(ListItemHost)
+	item.RemoteOS = ssh.RemoteOSLinux
+	lm.SetItem(1, item)
+
+	require.Contains(t, lm.View(), "🐧 Mock Host 2")
+	// Title itself is not changed, because it's used for sorting
+	require.Equal(t, "Mock Host 2", lm.Items()[1].(ListItemHost).Title())
+
+	lm.Select(1)
+	lm.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	require.Contains(t, lm.View(), "✓ 🐧 Mock Host 2")
+}
+
+func TestHostDelegate_ProviderIcon(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.SetSize(100, 30)
+	item := lm.Items()[1].(ListItemHost)
+	item.Provider = host.ProviderGCP
+	item.RemoteOS = ssh.RemoteOSLinux
+	lm.SetItem(1, item)
+
+	// Provider icon follows the icon of the operating system
+	require.Contains(t, lm.View(), "🐧 🟦 Mock Host 2")
+
+	item.Provider = "hetzner"
+	lm.SetItem(1, item)
+	require.Contains(t, lm.View(), "🐧 Mock Host 2")
+}
+
+func TestHostDelegate_CustomConnectBadge(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.SetSize(100, 30)
+	require.NotContains(t, lm.View(), customConnectBadge)
+
+	item := lm.Items()[1].(ListItemHost)
+	item.Address = "root@localhost -p 2222"
+	lm.SetItem(1, item)
+
+	require.Contains(t, lm.View(), "Mock Host 2"+customConnectBadge)
+	require.NotContains(t, lm.View(), "Mock Host 1"+customConnectBadge)
+	require.NotContains(t, lm.View(), "Mock Host 3"+customConnectBadge)
+	// Title itself is not changed, because it's used for sorting
+	require.Equal(t, "Mock Host 2", lm.Items()[1].(ListItemHost).Title())
+}
+
+func TestListModel_setIdentityFile_OnlyMarkedHosts(t *testing.T) {
+	keyPath := path.Join(t.TempDir(), "id_new")
+	require.NoError(t, os.WriteFile(keyPath, []byte("mock key"), 0o600))
+
+	lm := NewMockListModel(false)
+	lm.Select(0)
+	lm.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	lm.Select(2)
+	lm.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	// Press 'I' and replace the prefilled value with a new identity file
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	require.Equal(t, modeSetIdentityFile, lm.mode)
+	require.Contains(t, lm.Title, "identity file of 2 hosts: id_rsa")
+	lm.prompt.SetValue(keyPath)
+	lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, lm.mode)
+	require.Equal(t, "identity file is set for 2 hosts", lm.Title)
+
+	identityFiles := lo.Map(lm.Items(), func(item list.Item, _ int) string {
+		return item.(ListItemHost).IdentityFilePath
+	})
+	require.Equal(t, []string{keyPath, "id_rsa", keyPath}, identityFiles)
+
+	// Only selected hosts are saved. Mock storage appends saved hosts to the end of the collection.
+	hosts, _ := lm.repo.GetAll()
+	savedIDs := lo.Map(hosts[3:], func(h host.Host, _ int) int { return h.ID })
+	require.Equal(t, []int{1, 3}, savedIDs)
+	// Selection is reset after a bulk action
+	require.Empty(t, lm.marked)
+}
+
+func TestListModel_setIdentityFile_FocusedHostWhenNothingMarked(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(1)
+
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	require.Contains(t, lm.Title, "identity file of 1 hosts: ")
+	lm.prompt.SetValue("/missing/id_rsa")
+	lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Host is updated, but user is warned once that the key does not exist
+	require.Equal(t, "identity file is set for 1 hosts, identity file does not exist", lm.Title)
+	require.Equal(t, "/missing/id_rsa", lm.Items()[1].(ListItemHost).IdentityFilePath)
+	require.Equal(t, "id_rsa", lm.Items()[0].(ListItemHost).IdentityFilePath)
+}
+
+func TestListModel_setIdentityFile_Cancel(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(0)
+	lm.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	lm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	require.Equal(t, modeDefault, lm.mode)
+	hosts, _ := lm.repo.GetAll()
+	require.Len(t, hosts, 3)
+	// Selection is preserved when the action is cancelled
+	require.Contains(t, lm.marked, 1)
+}
+
+func TestListModel_openWebURL(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(0)
+
+	// Web url is not set
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	require.Nil(t, cmd)
+	require.Equal(t, "web url is not set", lm.Title)
+
+	item := lm.SelectedItem().(ListItemHost)
+	item.WebURL = "https://{address}:8443"
+	lm.SetItem(0, item)
+
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	require.Equal(t, message.RunProcessOpenURL{URL: "https://" + item.Address + ":8443"}, cmd())
+}
+
 func TestListModel_updateKeyMap(t *testing.T) {
 	// Case 1: Test that if a host list contains items and item is selected, then all keyboard shortcuts are shown on the screen
 	lm := *NewMockListModel(false)
@@ -479,15 +724,21 @@ func TestListModel_updateKeyMap(t *testing.T) {
 	require.Contains(t, displayedKeys, availableKeys.edit)
 	require.Contains(t, displayedKeys, availableKeys.remove)
 
-	// Case 2: Test that if a host list does not contain any items,
+	// Case 2: Test that when all hosts are removed, the scratch host is left and keyboard shortcuts are still shown.
+	for range 3 {
+		lm.Select(1)
+		lm.enterRemoveItemMode()
+		lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	}
+
+	require.Len(t, lm.Items(), 1)
+	require.Equal(t, host.ScratchHostID, lm.SelectedItem().(ListItemHost).ID)
+	require.Equal(t, 5, len(lm.keyMap.ShortHelp()))
+
+	// Case 3: Test that if a host list does not contain any items,
 	// then some of the keyboard shortcuts should NOT be shown.
-	// Removing all hosts.
-	lm.enterRemoveItemMode()
-	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
-	lm.enterRemoveItemMode()
-	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
-	lm.enterRemoveItemMode()
-	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	lm.RemoveItem(0)
+	lm.updateKeyMap()
 
 	displayedKeys = lm.keyMap.ShortHelp()
 
@@ -524,7 +775,7 @@ func TestUpdate_HostSSHConfigLoaded(t *testing.T) {
 		Config: expectedConfig,
 	})
 
-	actualConfig := lm.Items()[0].(ListItemHost).SSHClientConfig
+	actualConfig := lm.Items()[1].(ListItemHost).SSHClientConfig
 	require.Equal(t, &expectedConfig, actualConfig)
 }
 
@@ -534,7 +785,7 @@ func TestUpdate_HostUpdated(t *testing.T) {
 	lm.Init()
 
 	// Check that the host we're going to update exists and has the expected title
-	require.Equal(t, lm.Items()[0].(ListItemHost).Title(), "Mock Host 1")
+	require.Equal(t, lm.Items()[1].(ListItemHost).Title(), "Mock Host 1")
 
 	updatedHost := host.Host{
 		ID:               1,
@@ -548,7 +799,7 @@ func TestUpdate_HostUpdated(t *testing.T) {
 	}
 
 	lm.Update(message.HostUpdated{Host: updatedHost})
-	require.Equal(t, updatedHost, lm.Items()[0].(ListItemHost).Host)
+	require.Equal(t, updatedHost, lm.Items()[1].(ListItemHost).Host)
 
 	// Also check that host is inserted into a correct position of the hostlist model
 	updatedHost = host.Host{
@@ -563,7 +814,7 @@ func TestUpdate_HostUpdated(t *testing.T) {
 	}
 
 	lm.Update(message.HostUpdated{Host: updatedHost})
-	lastIndex := 2
+	lastIndex := 3
 	require.Equal(t, updatedHost, lm.Items()[lastIndex].(ListItemHost).Host)
 }
 
@@ -573,7 +824,7 @@ func TestUpdate_HostCreated(t *testing.T) {
 	lm := *NewMockListModel(false)
 	lm.Init()
 
-	require.Equal(t, lm.Items()[0].(ListItemHost).Title(), "Mock Host 1")
+	require.Equal(t, lm.Items()[1].(ListItemHost).Title(), "Mock Host 1")
 
 	createdHost1 := host.Host{
 		ID:               999,
@@ -587,8 +838,9 @@ func TestUpdate_HostCreated(t *testing.T) {
 	}
 
 	lm.Update(message.HostCreated{Host: createdHost1})
-	require.Len(t, lm.Items(), 4, "Wrong host list size")
-	require.Equal(t, createdHost1, lm.Items()[0].(ListItemHost).Host)
+	require.Len(t, lm.Items(), 5, "Wrong host list size")
+	// Scratch host is still on top
+	require.Equal(t, createdHost1, lm.Items()[1].(ListItemHost).Host)
 
 	// Also check that host is inserted into a correct position of the hostlist model
 	createdHost2 := host.Host{
@@ -603,8 +855,8 @@ func TestUpdate_HostCreated(t *testing.T) {
 	}
 
 	lm.Update(message.HostCreated{Host: createdHost2})
-	require.Len(t, lm.Items(), 5, "Wrong host list size")
-	lastIndex := 4 // because we have 5 hosts in total
+	require.Len(t, lm.Items(), 6, "Wrong host list size")
+	lastIndex := 5 // because we have 5 hosts in total and the scratch host
 	require.Equal(t, createdHost2, lm.Items()[lastIndex].(ListItemHost).Host)
 }
 
@@ -614,10 +866,10 @@ func Test_handleKeyboardEvent_cancelWhileFiltering(t *testing.T) {
 	model := NewMockListModel(false)
 	model.Init()
 
-	// Make sure there are 3 items in the collection
-	require.Len(t, model.VisibleItems(), 3)
+	// Make sure there are 3 hosts and the scratch host in the collection
+	require.Len(t, model.VisibleItems(), 4)
 
-	// Check that first item is selected
+	// Check that first stored host is selected
 	require.IsType(t, ListItemHost{}, model.SelectedItem())
 	require.Equal(t, "Mock Host 1", model.SelectedItem().(ListItemHost).Title())
 
@@ -639,9 +891,12 @@ func Test_handleKeyboardEvent_cancelWhileFiltering(t *testing.T) {
 	msgs := []tea.Msg{}
 	test.CmdToMessage(cmds, &msgs)
 
-	// Send those messages back to the model
+	// Send those messages back to the model, search results are followed by MsgSearch which sets focus
 	for _, m := range msgs {
-		model.Update(m)
+		_, cmd := model.Update(m)
+		if _, ok := m.(list.FilterMatchesMsg); ok {
+			model.Update(cmd())
+		}
 	}
 
 	require.Len(t, model.VisibleItems(), 1)
@@ -658,7 +913,7 @@ func Test_handleKeyboardEvent_cancelWhileFiltering(t *testing.T) {
 	}
 
 	require.Equal(t, list.Unfiltered, model.FilterState())
-	require.Len(t, model.VisibleItems(), 3)
+	require.Len(t, model.VisibleItems(), 4)
 	// By triggering filter, though we haven't selected anything, we implicitly selected the first item from the search results
 	require.Equal(t, "Mock Host 2", model.SelectedItem().(ListItemHost).Title())
 }
@@ -671,10 +926,10 @@ func Test_handleKeyboardEvent_clearFilter(t *testing.T) {
 	model := NewMockListModel(false)
 	model.Init()
 
-	// Make sure there are 3 items in the collection
-	require.Len(t, model.VisibleItems(), 3)
+	// Make sure there are 3 hosts and the scratch host in the collection
+	require.Len(t, model.VisibleItems(), 4)
 
-	// Check that first item is selected
+	// Check that first stored host is selected
 	require.IsType(t, ListItemHost{}, model.SelectedItem())
 	require.Equal(t, "Mock Host 1", model.SelectedItem().(ListItemHost).Title())
 
@@ -725,7 +980,7 @@ func Test_handleKeyboardEvent_clearFilter(t *testing.T) {
 	for _, m := range msgs {
 		model.Update(m)
 	}
-	require.Len(t, model.VisibleItems(), 3)
+	require.Len(t, model.VisibleItems(), 4)
 	require.Equal(t, list.Unfiltered, model.FilterState())
 	require.Equal(t, "Mock Host 2", model.SelectedItem().(ListItemHost).Title())
 }
@@ -736,9 +991,9 @@ func Test_handleKeyboardEvent_connect(t *testing.T) {
 	model := NewMockListModel(false)
 	model.Init()
 
-	// Make sure there are 3 items in the collection
-	require.Len(t, model.VisibleItems(), 3)
-	// Check that first item is selected
+	// Make sure there are 3 hosts and the scratch host in the collection
+	require.Len(t, model.VisibleItems(), 4)
+	// Check that first stored host is selected
 	require.IsType(t, ListItemHost{}, model.SelectedItem())
 	require.Equal(t, "Mock Host 1", model.SelectedItem().(ListItemHost).Title())
 
@@ -747,14 +1002,392 @@ func Test_handleKeyboardEvent_connect(t *testing.T) {
 	require.IsType(t, message.RunProcessSSHConnect{}, cmd())
 }
 
+func Test_handleKeyboardEvent_connectWithBanner(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	item := model.SelectedItem().(ListItemHost)
+	item.Banner = "Authorized\nuse only."
+	model.SetItem(model.Index(), item)
+
+	// User is asked to acknowledge the banner
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, cmd)
+	require.Equal(t, modeAcknowledgeBanner, model.mode)
+	require.Equal(t, "Authorized use only. acknowledge and connect? (y/N)", model.Title)
+
+	// User declines - not connected, banner is not acknowledged
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	require.Equal(t, modeDefault, model.mode)
+	require.False(t, model.appState.IsBannerAcknowledged(item.ID, item.Banner))
+	var msgs []tea.Msg
+	test.CmdToMessage(cmd, &msgs)
+	require.NotContains(t, msgs, message.RunProcessSSHConnect{Host: item.Host})
+
+	// User is asked again and acknowledges the banner
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeAcknowledgeBanner, model.mode)
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	require.Equal(t, modeDefault, model.mode)
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, cmd())
+
+	// Banner is acknowledged once per session, both for regular and fast connect
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, model.mode)
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, cmd())
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host, Fast: true}, cmd())
+
+	// Other host with a banner should be acknowledged separately
+	other := model.Items()[2].(ListItemHost)
+	other.Banner = "Authorized\nuse only."
+	model.SetItem(2, other)
+	model.Select(2)
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	require.Equal(t, modeAcknowledgeBanner, model.mode)
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	require.Equal(t, message.RunProcessSSHConnect{Host: other.Host, Fast: true}, cmd())
+}
+
+func Test_handleKeyboardEvent_connectWithNotes(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	item := model.SelectedItem().(ListItemHost)
+
+	// Host without notes is connected immediately
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, model.mode)
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, cmd())
+
+	item.Notes = "   "
+	model.SetItem(model.Index(), item)
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, model.mode)
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, cmd())
+
+	// Notes are displayed before connecting
+	item.Notes = "Production!\nDo not restart services."
+	model.SetItem(model.Index(), item)
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, cmd)
+	require.Equal(t, modeShowNote, model.mode)
+	require.Equal(t, "note: Production! Do not restart services. (any key to connect, esc to cancel)", model.Title)
+
+	// Escape key cancels the connection
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Equal(t, modeDefault, model.mode)
+	require.Nil(t, model.afterConfirmed)
+	var msgs []tea.Msg
+	test.CmdToMessage(cmd, &msgs)
+	require.NotContains(t, msgs, message.RunProcessSSHConnect{Host: item.Host})
+
+	// Any other key dismisses the note and connects, both for regular and fast connect
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	require.Equal(t, modeDefault, model.mode)
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, cmd())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	require.Equal(t, modeShowNote, model.mode)
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host, Fast: true}, cmd())
+}
+
+func Test_handleKeyboardEvent_connectConfirmByName(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	item := model.SelectedItem().(ListItemHost)
+	item.ConfirmByName = true
+	model.SetItem(model.Index(), item)
+	typeTitle := func(title string) tea.Cmd {
+		model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		require.Equal(t, modeConfirmByName, model.mode)
+		model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(title)})
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return cmd
+	}
+
+	// User is asked to type the title of the host
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeConfirmByName, model.mode)
+	require.Contains(t, model.Title, "type \"Mock Host 1\" to connect: ")
+	var msgs []tea.Msg
+	test.CmdToMessage(cmd, &msgs)
+	require.NotContains(t, msgs, message.RunProcessSSHConnect{Host: item.Host})
+
+	// Escape key cancels the connection
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Equal(t, modeDefault, model.mode)
+	require.Nil(t, model.afterConfirmed)
+
+	// Title which does not match exactly cancels the connection
+	for _, title := range []string{"", "Mock Host", "mock host 1", "Mock Host 1 "} {
+		require.Nil(t, typeTitle(title))
+		require.Equal(t, modeDefault, model.mode)
+		require.Nil(t, model.afterConfirmed)
+		require.Equal(t, "title does not match, connection is cancelled", model.Title)
+	}
+
+	// Exact title connects
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, typeTitle("Mock Host 1")())
+
+	// Title is asked every time, unlike banner which is acknowledged once per session
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, typeTitle("Mock Host 1")())
+
+	// Notes are displayed after the title is confirmed
+	item.Notes = "Production"
+	model.SetItem(model.Index(), item)
+	require.Nil(t, typeTitle("Mock Host 1"))
+	require.Equal(t, modeShowNote, model.mode)
+}
+
+func Test_handleKeyboardEvent_connectWithBannerAndNotes(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	item := model.SelectedItem().(ListItemHost)
+	item.Address = "localhost"
+	item.Banner = "Authorized use only."
+	item.Notes = "Production"
+	model.SetItem(model.Index(), item)
+	model.appState.ApplicationConfig.WarnLoopback = true
+
+	// Banner, loopback warning and notes are displayed one after another
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeAcknowledgeBanner, model.mode)
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	require.Equal(t, modeConfirmLoopback, model.mode)
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	require.Equal(t, modeShowNote, model.mode)
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, model.mode)
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, cmd())
+}
+
+func Test_handleKeyboardEvent_fastConnect(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	item := model.SelectedItem().(ListItemHost)
+	// Forward presets are not offered on fast connect
+	item.ForwardPresets = map[string][]string{"db": {"-L 5432:localhost:5432"}}
+	model.SetItem(model.Index(), item)
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	var msgs []tea.Msg
+	test.CmdToMessage(cmd, &msgs)
+
+	// Only connect message is dispatched, ssh config is not loaded
+	require.Equal(t, []tea.Msg{message.RunProcessSSHConnect{Host: item.Host, Fast: true}}, msgs)
+	require.Equal(t, modeDefault, model.mode)
+}
+
+func Test_handleKeyboardEvent_tailLog(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+
+	// Log path is not set
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	require.Nil(t, cmd)
+	require.Equal(t, "log path is not set", model.Title)
+
+	item := model.SelectedItem().(ListItemHost)
+	item.LogPath = "/var/log/syslog"
+	model.SetItem(model.Index(), item)
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	var msgs []tea.Msg
+	test.CmdToMessage(cmd, &msgs)
+	require.Equal(t, []tea.Msg{message.RunProcessSSHConnect{Host: item.Host, TailLog: true}}, msgs)
+	require.Equal(t, modeDefault, model.mode)
+}
+
+func Test_handleKeyboardEvent_openSFTP(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	item := model.SelectedItem().(ListItemHost)
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host, SFTP: true}, cmd())
+
+	// Banner is acknowledged before sftp session is opened, the same way as for ssh connection
+	item.Banner = "Authorized use only."
+	model.SetItem(model.Index(), item)
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	require.Nil(t, cmd)
+	require.Equal(t, modeAcknowledgeBanner, model.mode)
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host, SFTP: true}, cmd())
+}
+
+func Test_handleKeyboardEvent_connectWithForwardPreset(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	item := model.SelectedItem().(ListItemHost)
+	item.ForwardPresets = map[string][]string{
+		"web": {"-L 8080:localhost:80"},
+		"db":  {"-L 5432:localhost:5432"},
+	}
+	model.SetItem(model.Index(), item)
+
+	tests := []struct {
+		name           string
+		key            tea.KeyMsg
+		expectedPreset string
+		connect        bool
+	}{
+		{"Select first preset", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}}, "db", true},
+		{"Select second preset", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}}, "web", true},
+		{"Connect without forwards", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'0'}}, "", true},
+		{"Connect without forwards using enter", tea.KeyMsg{Type: tea.KeyEnter}, "", true},
+		{"Preset number out of range", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}}, "", false},
+		{"Cancel", tea.KeyMsg{Type: tea.KeyEsc}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Hit enter, host has forward presets, so user should choose one of them
+			_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			require.Nil(t, cmd)
+			require.Equal(t, modeSelectForward, model.mode)
+			require.Equal(t, "forwards: 0) none 1) db 2) web", model.Title)
+
+			_, cmd = model.Update(tt.key)
+			require.Equal(t, modeDefault, model.mode)
+			if !tt.connect {
+				require.Nil(t, cmd)
+				return
+			}
+
+			msg, ok := cmd().(message.RunProcessSSHConnect)
+			require.True(t, ok)
+			require.Equal(t, tt.expectedPreset, msg.ForwardPreset)
+			require.Equal(t, item.ID, msg.Host.ID)
+		})
+	}
+}
+
+func Test_handleKeyboardEvent_connectWithProfile(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	item := model.SelectedItem().(ListItemHost)
+	item.Profiles = map[string]host.Profile{
+		"work-hours": {Address: "jump.example.com"},
+		"off-hours":  {RemotePort: "2222"},
+	}
+	model.SetItem(model.Index(), item)
+
+	// Hit enter, host has connection profiles, so user should choose one of them
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, cmd)
+	require.Equal(t, modeSelectProfile, model.mode)
+	require.Equal(t, "profiles: 0) default 1) off-hours 2) work-hours", model.Title)
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	require.Equal(t, modeDefault, model.mode)
+	msg, ok := cmd().(message.RunProcessSSHConnect)
+	require.True(t, ok)
+	require.Equal(t, "work-hours", msg.Profile)
+	// Profile is applied when the command is built, the host itself is not modified
+	require.Equal(t, item.Host, msg.Host)
+
+	// Enter key connects using the default profile
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg = cmd().(message.RunProcessSSHConnect)
+	require.Empty(t, msg.Profile)
+}
+
+func Test_handleKeyboardEvent_connectWithProfileAndForwardPreset(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	item := model.SelectedItem().(ListItemHost)
+	item.Profiles = map[string]host.Profile{"off-hours": {RemotePort: "2222"}}
+	item.ForwardPresets = map[string][]string{"db": {"-L 5432:localhost:5432"}}
+	model.SetItem(model.Index(), item)
+
+	// Profile is chosen first, then forward preset
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	require.Nil(t, cmd)
+	require.Equal(t, modeSelectForward, model.mode)
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	msg := cmd().(message.RunProcessSSHConnect)
+	require.Equal(t, "off-hours", msg.Profile)
+	require.Equal(t, "db", msg.ForwardPreset)
+
+	// Cancelled selection does not leak the profile into the next connection
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Empty(t, model.selectedProfile)
+}
+
+func Test_handleKeyboardEvent_connectWithIdentityFile(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	item := model.SelectedItem().(ListItemHost)
+	item.Address = "localhost"
+	item.IdentityFilePath = "/home/user/.ssh/id_rsa"
+	item.IdentityFiles = []string{"/home/user/.ssh/id_ed25519", "/home/user/.ssh/id_rsa"}
+	model.SetItem(model.Index(), item)
+
+	tests := []struct {
+		name                 string
+		key                  tea.KeyMsg
+		expectedIdentityFile string
+		connect              bool
+	}{
+		{"Select first identity file", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}}, "/home/user/.ssh/id_rsa", true},
+		{"Select second identity file", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}}, "/home/user/.ssh/id_ed25519", true},
+		// Identity file of the host is used as is
+		{"Connect using default identity file", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'0'}}, "", true},
+		{"Connect using enter", tea.KeyMsg{Type: tea.KeyEnter}, "", true},
+		{"Number out of range", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}}, "", false},
+		{"Cancel", tea.KeyMsg{Type: tea.KeyEsc}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Hit enter, host has several identity files, so user should choose one of them. Duplicates are not offered.
+			_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			require.Nil(t, cmd)
+			require.Equal(t, modeSelectIdentityFile, model.mode)
+			require.Equal(t, "identity files: 1) /home/user/.ssh/id_rsa 2) /home/user/.ssh/id_ed25519", model.Title)
+
+			_, cmd = model.Update(tt.key)
+			require.Equal(t, modeDefault, model.mode)
+			require.Empty(t, model.selectedIdentityFile)
+			if !tt.connect {
+				require.Nil(t, cmd)
+				return
+			}
+
+			msg, ok := cmd().(message.RunProcessSSHConnect)
+			require.True(t, ok)
+			require.Equal(t, item.Host, msg.Host)
+			require.Equal(t, tt.expectedIdentityFile, msg.IdentityFile)
+			connectHost := msg.Host.WithIdentityFile(msg.IdentityFile)
+			require.Contains(t, connectHost.CmdSSHConnect(), "-i "+lo.CoalesceOrEmpty(tt.expectedIdentityFile, item.IdentityFilePath))
+		})
+	}
+
+	// Single candidate is used without asking
+	item.IdentityFilePath = ""
+	item.IdentityFiles = []string{"/home/user/.ssh/id_ed25519"}
+	model.SetItem(model.Index(), item)
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, model.mode)
+	msg := cmd().(message.RunProcessSSHConnect)
+	require.Equal(t, "/home/user/.ssh/id_ed25519", msg.IdentityFile)
+}
+
 func Test_handleKeyboardEvent_copyID(t *testing.T) {
 	// Just check that we enter copyID mode when a host is selected and press "t" button
 	model := NewMockListModel(false)
 	model.Init()
 
-	// Make sure there are 3 items in the collection
-	require.Len(t, model.VisibleItems(), 3)
-	// Check that first item is selected
+	// Make sure there are 3 hosts and the scratch host in the collection
+	require.Len(t, model.VisibleItems(), 4)
+	// Check that first stored host is selected
 	require.IsType(t, ListItemHost{}, model.SelectedItem())
 	require.Equal(t, "Mock Host 1", model.SelectedItem().(ListItemHost).Title())
 
@@ -777,9 +1410,9 @@ func Test_handleKeyboardEvent_remove(t *testing.T) {
 	model := NewMockListModel(false)
 	model.Init()
 
-	// Make sure there are 3 items in the collection
-	require.Len(t, model.VisibleItems(), 3)
-	// Check that first item is selected
+	// Make sure there are 3 hosts and the scratch host in the collection
+	require.Len(t, model.VisibleItems(), 4)
+	// Check that first stored host is selected
 	require.IsType(t, ListItemHost{}, model.SelectedItem())
 	require.Equal(t, "Mock Host 1", model.SelectedItem().(ListItemHost).Title())
 
@@ -801,10 +1434,146 @@ func Test_handleKeyboardEvent_edit(t *testing.T) {
 	t.Skip("In progress")
 }
 
+func Test_handleKeyboardEvent_scratch(t *testing.T) {
+	model := NewMockListModel(false)
+	model.Init()
+	model.Select(0)
+	scratch := model.SelectedItem().(ListItemHost).Host
+	require.Equal(t, host.ScratchHostID, scratch.ID)
+	press := func(r rune) tea.Cmd {
+		_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		return cmd
+	}
+
+	// Scratch host is empty until user edits it
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, cmd)
+	require.Equal(t, "host address is not set, press 'e' to edit the host", model.Title)
+
+	// Edit form receives the scratch host, because it can't be read from the storage
+	var msgs []tea.Msg
+	test.CmdToMessage(press('e'), &msgs)
+	require.Contains(t, msgs, OpenEditForm{HostID: scratch.ID, ScratchHost: &scratch})
+
+	// Actions which store the host are not available
+	for _, r := range []rune{'x', ' ', 'I', 'A'} {
+		model.Title = ""
+		require.Nil(t, press(r))
+		require.Equal(t, modeDefault, model.mode)
+		require.Equal(t, "scratch host is not stored, clone it to keep it", model.Title)
+	}
+
+	// Updated scratch host stays on top and user can connect to it
+	scratch.Title = "zzz"
+	scratch.Address = "scratch.example.com"
+	test.CmdToMessage(model.onHostUpdated(message.HostUpdated{Host: scratch}), &msgs)
+	require.Equal(t, scratch, model.Items()[0].(ListItemHost).Host)
+	require.Equal(t, 0, model.Index())
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, message.RunProcessSSHConnect{Host: scratch}, cmd())
+
+	// Scratch host is kept when hosts are re-read from the storage
+	hosts, _ := model.repo.GetAll()
+	test.CmdToMessage(model.Init(), &msgs)
+	require.Equal(t, scratch, model.Items()[0].(ListItemHost).Host)
+	require.Len(t, model.Items(), len(hosts)+1)
+
+	// Clone is stored as a regular host
+	model.Select(0)
+	press('c')
+	hostsAfterClone, _ := model.repo.GetAll()
+	require.Len(t, hostsAfterClone, len(hosts)+1)
+	require.Equal(t, "zzz (1)", lo.LastOrEmpty(hostsAfterClone).Title)
+	require.NotEqual(t, host.ScratchHostID, lo.LastOrEmpty(hostsAfterClone).ID)
+}
+
 func Test_handleKeyboardEvent_append(t *testing.T) {
 	t.Skip("In progress")
 }
 
+func Test_handleKeyboardEvent_help(t *testing.T) {
+	// Test that help overlay contains all key bindings which are defined in the host list keymap
+	model := NewMockListModel(false)
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(message.OpenHelpOverlay)
+	require.True(t, ok)
+
+	helpKeys := []string{}
+	for _, group := range msg.KeyBindings {
+		for _, binding := range group {
+			helpKeys = append(helpKeys, binding.Help().Key+binding.Help().Desc)
+		}
+	}
+
+	for _, category := range model.keyMap.FullHelp() {
+		for _, binding := range category {
+			require.Contains(t, helpKeys, binding.Help().Key+binding.Help().Desc)
+		}
+	}
+}
+
+func TestKeyMap_FullHelpCategories(t *testing.T) {
+	// Test that every binding is rendered under its category
+	km := newDelegateKeyMap()
+	categories := km.FullHelp()
+	require.Len(t, categories, 3)
+
+	tests := []struct {
+		name     string
+		binding  key.Binding
+		category int
+	}{
+		{"select", km.toggleMark, helpCategoryNavigation},
+		{"toggle view", km.toggleLayout, helpCategoryNavigation},
+		{"sort order", km.cycleSort, helpCategoryNavigation},
+		{"recent host", km.recentHost, helpCategoryNavigation},
+		{"filter by tag", km.filterByTag, helpCategoryNavigation},
+		{"collapse group", km.toggleGroup, helpCategoryNavigation},
+		{"summary", km.dashboard, helpCategoryNavigation},
+		{"new", km.append, helpCategoryEditing},
+		{"clone", km.clone, helpCategoryEditing},
+		{"clone to group", km.cloneToGroup, helpCategoryEditing},
+		{"edit", km.edit, helpCategoryEditing},
+		{"delete", km.remove, helpCategoryEditing},
+		{"favorite", km.toggleFavorite, helpCategoryEditing},
+		{"set identity file", km.setIdentityFile, helpCategoryEditing},
+		{"pin agent key", km.pinAgentKey, helpCategoryEditing},
+		{"edit hosts file", km.openInEditor, helpCategoryEditing},
+		{"connect", km.connect, helpCategoryConnection},
+		{"fast connect", km.fastConnect, helpCategoryConnection},
+		{"tail log", km.tailLog, helpCategoryConnection},
+		{"sftp session", km.openSFTP, helpCategoryConnection},
+		{"connect by alias", km.quickConnect, helpCategoryConnection},
+		{"ssh-copy-id", km.copyID, helpCategoryConnection},
+		{"check auth", km.checkAuth, helpCategoryConnection},
+		{"jump host", km.toggleJumpHost, helpCategoryConnection},
+		{"copy public key", km.copyPublicKey, helpCategoryConnection},
+		{"copy key path", km.copyIdentityPath, helpCategoryConnection},
+		{"mount sshfs", km.mountSSHFS, helpCategoryConnection},
+		{"open web url", km.openWebURL, helpCategoryConnection},
+	}
+
+	bindingKeys := func(bindings []key.Binding) []string {
+		return lo.Map(bindings, func(b key.Binding, _ int) string { return b.Help().Key })
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for category, bindings := range categories {
+				if category == tt.category {
+					require.Contains(t, bindingKeys(bindings), tt.binding.Help().Key)
+				} else {
+					require.NotContains(t, bindingKeys(bindings), tt.binding.Help().Key)
+				}
+			}
+		})
+	}
+
+	// Every binding of the full help belongs to a category which is covered by this test.
+	require.Len(t, lo.Flatten(categories), len(tests))
+}
+
 func Test_constructProcessCmd(t *testing.T) {
 	// Test that we receive expected messages when invoke constructProcessCmd function
 	lm := *NewMockListModel(false)
@@ -832,8 +1601,8 @@ func TestUpdate_SearchFunctionOfInnerModelIsNotRegressed(t *testing.T) {
 	model.logger = &test.MockLogger{}
 	model.Init()
 
-	// Make sure there are 3 items in the collection
-	assert.Len(t, model.VisibleItems(), 3)
+	// Make sure there are 3 hosts and the scratch host in the collection
+	assert.Len(t, model.VisibleItems(), 4)
 
 	// Enable filtering mode
 	model.Update(tea.KeyMsg{
@@ -878,8 +1647,8 @@ func TestUpdate_ToggleBetweenNormalAndCompactLayout(t *testing.T) {
 	model.logger = &test.MockLogger{}
 	model.Init()
 
-	// Make sure there are 3 items in the collection
-	assert.Len(t, model.VisibleItems(), 3)
+	// Make sure there are 3 hosts and the scratch host in the collection
+	assert.Len(t, model.VisibleItems(), 4)
 	// Ensure that screen layout is not set
 	layoutNotSet := constant.ScreenLayout("")
 	assert.Equal(t, fakeAppState.ScreenLayout, layoutNotSet)
@@ -913,6 +1682,47 @@ func TestBuildScreenLayout(t *testing.T) {
 	screenLayoutDelegate = NewHostDelegate(&layout, &test.MockLogger{})
 	require.Equal(t, 0, screenLayoutDelegate.Spacing())
 	require.False(t, screenLayoutDelegate.ShowDescription)
+
+	layout = constant.ScreenLayoutCompact
+	screenLayoutDelegate = NewHostDelegate(&layout, &test.MockLogger{})
+	require.Equal(t, 0, screenLayoutDelegate.Spacing())
+	require.False(t, screenLayoutDelegate.ShowDescription)
+
+	// Layouts are switched from the most spacious to the densest one
+	layout = constant.ScreenLayoutNormal
+	for _, expected := range []constant.ScreenLayout{
+		constant.ScreenLayoutCompact,
+		constant.ScreenLayoutTight,
+		constant.ScreenLayoutNormal,
+	} {
+		screenLayoutDelegate.UpdateFunc(msgToggleLayout{}, nil)
+		require.Equal(t, expected, layout)
+	}
+}
+
+func TestHostDelegate_Render_Compact(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.SetSize(100, 40)
+	h := host.Host{ID: 1, Title: "web", Address: "10.0.0.1", Description: "frontend"}
+	render := func(layout constant.ScreenLayout) string {
+		delegate := NewHostDelegate(&layout, &test.MockLogger{})
+		var b strings.Builder
+		delegate.Render(&b, lm.Model, 0, ListItemHost{Host: h})
+		require.Equal(t, lipgloss.Height(b.String()), delegate.Height())
+		return b.String()
+	}
+
+	// Normal layout displays title and description in separate lines
+	normal := render(constant.ScreenLayoutNormal)
+	require.Equal(t, 2, lipgloss.Height(normal))
+	require.Contains(t, normal, "frontend")
+	require.NotContains(t, normal, "10.0.0.1")
+
+	// Compact layout displays title and address in a single line
+	compact := render(constant.ScreenLayoutCompact)
+	require.Equal(t, 1, lipgloss.Height(compact))
+	require.Contains(t, compact, "web • 10.0.0.1")
+	require.NotContains(t, compact, "frontend")
 }
 
 func TestUpdate_HostFocusPreservedAfterClearFilterMessage(t *testing.T) {
@@ -930,10 +1740,10 @@ func TestUpdate_HostFocusPreservedAfterClearFilterMessage(t *testing.T) {
 	model.logger = &test.MockLogger{}
 	model.Init()
 
-	// Make sure there are 3 items in the collection
-	assert.Len(t, model.VisibleItems(), 3)
+	// Make sure there are 3 hosts and the scratch host in the collection
+	assert.Len(t, model.VisibleItems(), 4)
 
-	// Check that first item is selected
+	// Check that first stored host is selected
 	assert.IsType(t, ListItemHost{}, model.SelectedItem())
 	assert.Equal(t, "Mock Host 1", model.SelectedItem().(ListItemHost).Title())
 
@@ -967,6 +1777,23 @@ func TestUpdate_HostFocusPreservedAfterClearFilterMessage(t *testing.T) {
 // ============================================== List Model
 // ==============================================
 
+func TestUpdate_MsgRefreshRepo(t *testing.T) {
+	model := NewMockListModel(false)
+	_, _ = model.repo.Save(host.NewHost(0, "A new host", "", "localhost", "", "", "", ""))
+
+	_, cmd := model.Update(MsgRefreshRepo{})
+	test.CmdToMessage(cmd, &[]tea.Msg{})
+	require.Len(t, model.Items(), 5)
+	require.Equal(t, "A new host", model.Items()[1].(ListItemHost).Title())
+
+	// When hosts cannot be read, the error is displayed and the list is not changed
+	model = NewMockListModel(true)
+	_, cmd = model.Update(MsgRefreshRepo{})
+	model.Update(cmd())
+	require.Equal(t, "mock error", model.Title)
+	require.Len(t, model.Items(), 3)
+}
+
 func NewMockListModel(storageShouldFail bool) *listModel {
 	storage := test.NewMockStorage(storageShouldFail)
 
@@ -984,3 +1811,644 @@ func NewMockListModel(storageShouldFail bool) *listModel {
 
 	return lm
 }
+
+func TestListModel_selectRecentHost(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(0)
+	recentHost := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'`'}}
+
+	// No hosts were used
+	lm.Update(recentHost)
+	require.Equal(t, 0, lm.Index())
+	require.Equal(t, "no recent host to switch to", lm.Title)
+
+	// Only one host was used and it's already selected
+	lm.appState.AddRecentHost(1)
+	lm.Update(recentHost)
+	require.Equal(t, 0, lm.Index())
+	require.Equal(t, "no recent host to switch to", lm.Title)
+
+	// Selection toggles between two recent hosts
+	lm.appState.AddRecentHost(3)
+	lm.Update(recentHost)
+	require.Equal(t, 3, lm.SelectedItem().(ListItemHost).ID)
+	lm.Update(recentHost)
+	require.Equal(t, 0, lm.Index())
+	lm.Update(recentHost)
+	require.Equal(t, 2, lm.Index())
+
+	// Recent host which is removed can't be selected
+	lm.appState.RecentHosts = []int{3, 10}
+	lm.Update(recentHost)
+	require.Equal(t, 2, lm.Index())
+	require.Equal(t, "no recent host to switch to", lm.Title)
+}
+
+func TestListModel_pinAgentKey(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(1)
+
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	require.Equal(t, message.RunProcessListAgentKeys{}, cmd())
+
+	keys := []ssh.AgentKey{
+		{Bits: 256, Fingerprint: "SHA256:a", Comment: "/home/user/.ssh/id_ed25519", Type: "ED25519"},
+		{Bits: 3072, Fingerprint: "SHA256:b", Comment: "user@laptop", Type: "RSA"},
+	}
+	lm.Update(message.AgentKeysLoaded{Keys: keys})
+	require.Equal(t, modeSelectAgentKey, lm.mode)
+	require.Equal(t, "agent keys: 0) none 1) /home/user/.ssh/id_ed25519 (ED25519) 2) user@laptop (RSA)", lm.Title)
+
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	require.Equal(t, modeDefault, lm.mode)
+	item := lm.SelectedItem().(ListItemHost)
+	require.Equal(t, "/home/user/.ssh/id_ed25519", item.IdentityFilePath)
+	require.True(t, item.IdentitiesOnly)
+	hosts, _ := lm.repo.GetAll()
+	require.True(t, hosts[len(hosts)-1].IdentitiesOnly)
+
+	// Key which comment is not a file path cannot be pinned
+	lm.Update(message.AgentKeysLoaded{Keys: keys})
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	require.Equal(t, "cannot pin the key, its comment is not a file path", lm.Title)
+	require.True(t, lm.SelectedItem().(ListItemHost).IdentitiesOnly)
+
+	// Enter doesn't unpin the key, only an explicit choice does
+	lm.Update(message.AgentKeysLoaded{Keys: keys})
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, cmd)
+	require.Equal(t, modeDefault, lm.mode)
+	require.Nil(t, lm.agentKeys)
+	require.True(t, lm.SelectedItem().(ListItemHost).IdentitiesOnly)
+
+	// '0' unpins the key, identity file is preserved
+	lm.Update(message.AgentKeysLoaded{Keys: keys})
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'0'}})
+	item = lm.SelectedItem().(ListItemHost)
+	require.False(t, item.IdentitiesOnly)
+	require.Equal(t, "/home/user/.ssh/id_ed25519", item.IdentityFilePath)
+}
+
+func TestListModel_pickerWithManyOptions(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(1)
+	keys := make([]ssh.AgentKey, 12)
+	for i := range keys {
+		keys[i] = ssh.AgentKey{Comment: fmt.Sprintf("/home/user/.ssh/id_%d", i+1), Type: "ED25519"}
+	}
+
+	// Number of the option is typed and accepted with enter
+	lm.Update(message.AgentKeysLoaded{Keys: keys})
+	require.True(t, strings.HasSuffix(lm.Title, "12) /home/user/.ssh/id_12 (ED25519) > "))
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	require.Equal(t, modeSelectAgentKey, lm.mode)
+	require.True(t, strings.HasSuffix(lm.Title, " > 11"))
+	lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, lm.mode)
+	require.Equal(t, "/home/user/.ssh/id_11", lm.SelectedItem().(ListItemHost).IdentityFilePath)
+
+	// Any other key cancels the typed number
+	lm.Update(message.AgentKeysLoaded{Keys: keys})
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	lm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Equal(t, modeDefault, lm.mode)
+	require.Empty(t, lm.pickerInput)
+	require.Equal(t, "/home/user/.ssh/id_11", lm.SelectedItem().(ListItemHost).IdentityFilePath)
+}
+
+func TestListModel_pinAgentKey_NoKeys(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(0)
+
+	lm.Update(message.AgentKeysLoaded{})
+	require.Equal(t, modeDefault, lm.mode)
+	require.Equal(t, "the agent has no keys", lm.Title)
+}
+
+func TestListModel_connectToLoopback(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(0)
+	item := lm.SelectedItem().(ListItemHost)
+	item.Address = "localhost"
+	lm.SetItem(0, item)
+
+	// Warning is disabled by default
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.IsType(t, message.RunProcessSSHConnect{}, cmd())
+
+	lm.appState.ApplicationConfig.WarnLoopback = true
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, cmd)
+	require.Equal(t, modeConfirmLoopback, lm.mode)
+	require.Equal(t, "host connects to the local machine, connect anyway? (y/N)", lm.Title)
+
+	// Any key except 'y' cancels the connection
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	require.Equal(t, modeDefault, lm.mode)
+	require.Nil(t, lm.afterConfirmed)
+
+	lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, cmd())
+
+	// Profile which points to a remote address does not require confirmation
+	item.Profiles = map[string]host.Profile{"remote": {Address: "example.com"}}
+	lm.SetItem(0, item)
+	lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host, Profile: "remote"}, cmd())
+}
+
+func TestListModel_connectWithBusyLocalPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	_, busyPort, _ := net.SplitHostPort(listener.Addr().String())
+
+	lm := NewMockListModel(false)
+	lm.Select(0)
+	item := lm.SelectedItem().(ListItemHost)
+	item.LocalForwards = []string{busyPort + ":localhost:80"}
+	lm.SetItem(0, item)
+
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, cmd)
+	require.Equal(t, modeConfirmBusyPorts, lm.mode)
+	require.Equal(t, "local ports are in use: 127.0.0.1:"+busyPort+", connect anyway? (y/N)", lm.Title)
+
+	// Any key except 'y' cancels the connection
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	require.Equal(t, modeDefault, lm.mode)
+	require.Nil(t, lm.afterConfirmed)
+
+	lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, cmd())
+
+	// Connection is not blocked once the port is free
+	listener.Close()
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, message.RunProcessSSHConnect{Host: item.Host}, cmd())
+}
+
+func TestListModel_connectByAlias(t *testing.T) {
+	lm := NewMockListModel(false)
+	hosts, _ := lm.repo.GetAll()
+	hosts[2].Alias = "db1"
+	lm.Select(0)
+
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+	require.Equal(t, modeQuickConnect, lm.mode)
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("DB1")})
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, lm.mode)
+	require.Equal(t, 3, lm.SelectedItem().(ListItemHost).ID)
+
+	var actual []tea.Msg
+	test.CmdToMessage(cmd, &actual)
+	connectMsg, found := lo.Find(actual, func(msg tea.Msg) bool {
+		_, ok := msg.(message.RunProcessSSHConnect)
+		return ok
+	})
+	require.True(t, found)
+	require.Equal(t, 3, connectMsg.(message.RunProcessSSHConnect).Host.ID)
+
+	// Unknown alias
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("db2")})
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Nil(t, cmd)
+	require.Equal(t, `alias "db2" not found`, lm.Title)
+}
+
+func TestListModel_filterByTag(t *testing.T) {
+	lm := NewMockListModel(false)
+	hosts, _ := lm.repo.GetAll()
+	hosts[0].Tags = []string{"web", "prod"}
+	hosts[2].Tags = []string{"Prod"}
+	lm.Select(0)
+
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'#'}})
+	require.Equal(t, modeFilterByTag, lm.mode)
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("prod")})
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, modeDefault, lm.mode)
+	require.Equal(t, MsgFilterByTag{Tag: "prod"}, cmd())
+
+	// Scratch host stays on top, hosts without the tag are hidden
+	_, cmd = lm.Update(MsgFilterByTag{Tag: "prod"})
+	test.CmdToMessage(cmd, &[]tea.Msg{})
+	titles := lo.Map(lm.Items(), func(item list.Item, _ int) string { return item.(ListItemHost).Title() })
+	require.Equal(t, []string{"scratch", "Mock Host 1", "Mock Host 3"}, titles)
+
+	// Prompt is pre-filled with the current filter, empty value clears it
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'#'}})
+	require.Equal(t, "prod", lm.prompt.Value())
+	lm.prompt.SetValue(" ")
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd = lm.Update(cmd())
+	test.CmdToMessage(cmd, &[]tea.Msg{})
+	require.Len(t, lm.Items(), 4)
+}
+
+func TestListModel_copyIdentityFilePath(t *testing.T) {
+	var copied string
+	originalClipboardWriteAll := clipboardWriteAll
+	clipboardWriteAll = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { clipboardWriteAll = originalClipboardWriteAll }()
+
+	lm := NewMockListModel(false)
+	lm.Select(0)
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	require.Equal(t, "id_rsa", copied)
+	require.Equal(t, "identity file path copied to clipboard: id_rsa", lm.Title)
+
+	// Host without identity file
+	item := lm.SelectedItem().(ListItemHost)
+	item.IdentityFilePath = ""
+	lm.SetItem(0, item)
+	copied = ""
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	require.Empty(t, copied)
+	require.Equal(t, "identity file is not set", lm.Title)
+}
+
+func TestListModel_groups(t *testing.T) {
+	lm := NewMockListModel(false)
+	hosts, _ := lm.repo.GetAll()
+	hosts[0].Group = "web"
+	hosts[2].Group = "db"
+	test.CmdToMessage(lm.Init(), &[]tea.Msg{})
+
+	itemTitles := func() []string {
+		return lo.Map(lm.Items(), func(item list.Item, _ int) string {
+			return item.(interface{ Title() string }).Title()
+		})
+	}
+
+	// Groups are sorted by name, hosts without a group are displayed last
+	require.Equal(t, []string{
+		"scratch", "▾ db", "Mock Host 3", "▾ web", "Mock Host 1", "▾ Ungrouped", "Mock Host 2",
+	}, itemTitles())
+	require.Equal(t, "2 hosts", ListItemGroup{Count: 2}.Description())
+
+	// Collapse group of the focused host, focus moves to the header
+	lm.selectItem(hostWithID(1))
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	require.Equal(t, MsgToggleGroup{Group: "web"}, cmd())
+	lm.Update(MsgToggleGroup{Group: "web"})
+	require.Equal(t, []string{"scratch", "▾ db", "Mock Host 3", "▸ web", "▾ Ungrouped", "Mock Host 2"}, itemTitles())
+	require.Equal(t, ListItemGroup{Name: "web", Collapsed: true, Count: 1}, lm.SelectedItem())
+	require.Equal(t, "press enter to collapse or expand the group", lm.Title)
+
+	// Enter key expands the group instead of connecting
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, MsgToggleGroup{Group: "web"}, cmd())
+	lm.Update(MsgToggleGroup{Group: "web"})
+	require.Len(t, lm.Items(), 7)
+
+	// Header of the group can't be edited or deleted
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	require.Equal(t, msgErrorOccurred{err: errors.New(itemNotSelectedMessage)}, cmd())
+}
+
+func TestListModel_groupsAreNotDisplayedWithoutGroups(t *testing.T) {
+	lm := NewMockListModel(false)
+	test.CmdToMessage(lm.Init(), &[]tea.Msg{})
+	require.False(t, lm.grouped())
+	require.Len(t, lm.Items(), 4)
+
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	require.Nil(t, cmd)
+	require.Equal(t, "hosts are not grouped", lm.Title)
+
+	// When a host is moved to a group, the list is rebuilt with group headers. Host is saved by the edit form.
+	hosts, _ := lm.repo.GetAll()
+	hosts[1].Group = "web"
+	lm.Update(message.HostUpdated{Host: hosts[1]})
+	require.True(t, lm.grouped())
+	require.Equal(t, hosts[1].ID, lm.SelectedItem().(ListItemHost).ID)
+}
+
+func TestListModel_checkAuth(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Init()
+	lm.Select(1)
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	require.Equal(t, "checking authentication...", lm.Title)
+	require.Equal(t, message.RunProcessSSHCheckAuth{Host: lm.SelectedItem().(ListItemHost).Host}, cmd())
+
+	// Scratch host is empty until user edits it
+	lm.Select(0)
+	_, cmd = lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	require.Nil(t, cmd)
+	require.Equal(t, "host address is not set, press 'e' to edit the host", lm.Title)
+}
+
+func TestListModel_toggleJumpHost(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.Select(1)
+	jumpKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'J'}}
+	lm.Update(jumpKey)
+	require.Equal(t, "connections jump through root@localhost:2222", lm.Title)
+	hostID, jumpHost := lm.appState.JumpHost()
+	require.Equal(t, lm.SelectedItem().(ListItemHost).ID, hostID)
+	require.Equal(t, "root@localhost:2222", jumpHost)
+
+	// Another host replaces the jump host
+	lm.Select(0)
+	lm.Update(jumpKey)
+	hostID, _ = lm.appState.JumpHost()
+	require.Equal(t, lm.SelectedItem().(ListItemHost).ID, hostID)
+
+	// Same host clears the selection
+	lm.Update(jumpKey)
+	require.Equal(t, "connections don't jump through a host anymore", lm.Title)
+	_, jumpHost = lm.appState.JumpHost()
+	require.Empty(t, jumpHost)
+
+	// Host which is not reachable over ssh cannot be a jump host
+	hosts, _ := lm.repo.GetAll()
+	hosts[0].Protocol = constant.ProtocolKubectl
+	lm.reloadItems(hostWithID(hosts[0].ID))
+	lm.Update(jumpKey)
+	require.Equal(t, "host cannot be used as a jump host", lm.Title)
+	_, jumpHost = lm.appState.JumpHost()
+	require.Empty(t, jumpHost)
+}
+
+func TestSearchFilter(t *testing.T) {
+	targets := []string{
+		ListItemHost{Host: host.Host{Title: "Web", Address: "10.0.0.1", Description: "Frontend"}}.FilterValue(),
+		ListItemHost{Host: host.Host{Title: "Database", Address: "db.example.com"}}.FilterValue(),
+		ListItemHost{Host: host.Host{Title: "Über", Address: "uber.example.com"}}.FilterValue(),
+		ListItemGroup{Name: "web"}.FilterValue(),
+		ListItemHost{Host: host.Host{
+			Title:           "Cache",
+			Address:         "cache",
+			SSHClientConfig: &ssh.Config{Hostname: "192.168.1.7"},
+		}}.FilterValue(),
+	}
+
+	tests := []struct {
+		name     string
+		term     string
+		expected []list.Rank
+	}{
+		{"Title is case-insensitive", "web", []list.Rank{{Index: 0, MatchedIndexes: []int{0, 1, 2}}}},
+		// Only the title is highlighted
+		{"Address", "EXAMPLE", []list.Rank{{Index: 1}, {Index: 2}}},
+		{"Description", "front", []list.Rank{{Index: 0}}},
+		{"Non-ASCII title", "über", []list.Rank{{Index: 2, MatchedIndexes: []int{0, 1, 2, 3}}}},
+		{"Characters must go one after another", "wb", []list.Rank{}},
+		{"Query doesn't span fields", "web10", []list.Rank{}},
+		{"Resolved IP address", "168.1.7", []list.Rank{{Index: 4}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, searchFilter(tt.term, targets))
+		})
+	}
+}
+
+func TestListModel_search(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.SetItems([]list.Item{
+		ListItemHost{Host: host.Host{ID: 1, Title: "Web", Address: "db.example.com"}},
+		ListItemHost{Host: host.Host{ID: 2, Title: "Cache", Address: "localhost", Description: "Primary"}},
+		ListItemHost{Host: host.Host{ID: 3, Title: "Queue", Address: "localhost"}},
+	})
+	selectedID := func() int { return lm.SelectedItem().(ListItemHost).ID }
+	// Search results are calculated asynchronously, they're sent back to the model along with MsgSearch.
+	search := func(msg tea.KeyMsg) {
+		_, cmd := lm.Update(msg)
+		msgs := []tea.Msg{}
+		test.CmdToMessage(cmd, &msgs)
+		for _, msg := range msgs {
+			if matches, ok := msg.(list.FilterMatchesMsg); ok {
+				_, cmd = lm.Update(matches)
+				require.Equal(t, MsgSearch{Query: lm.FilterValue()}, cmd())
+				lm.Update(MsgSearch{Query: lm.FilterValue()})
+			}
+		}
+	}
+
+	lm.Select(2)
+	search(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	// Selected host matches the query, so it stays selected
+	search(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("LOCAL")})
+	require.Len(t, lm.VisibleItems(), 2)
+	require.Equal(t, 3, selectedID())
+
+	// Selected host doesn't match the query, the first match is focused
+	search(tea.KeyMsg{Type: tea.KeyCtrlU})
+	search(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("primary")})
+	require.Len(t, lm.VisibleItems(), 1)
+	require.Equal(t, 2, selectedID())
+
+	// Esc clears the search and keeps the selection
+	search(tea.KeyMsg{Type: tea.KeyEscape})
+	require.Equal(t, list.Unfiltered, lm.FilterState())
+	require.Len(t, lm.VisibleItems(), 3)
+	require.Equal(t, 2, selectedID())
+
+	// Outdated results don't change the selection
+	lm.searchFocusID = 3
+	lm.Update(MsgSearch{Query: "queue"})
+	require.Equal(t, 2, selectedID())
+
+	// Host is found by the address which its short name is resolved to in ssh config
+	lm.Update(message.HostSSHConfigLoaded{HostID: 3, Config: ssh.Config{Hostname: "10.0.0.7"}})
+	search(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	search(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("10.0.0.7")})
+	require.Len(t, lm.VisibleItems(), 1)
+	require.Equal(t, 3, selectedID())
+}
+
+func TestListModel_toggleFavorite(t *testing.T) {
+	lm := NewMockListModel(false)
+	titles := func() []string {
+		return lo.Map(lm.Items(), func(item list.Item, _ int) string { return item.(ListItemHost).Title() })
+	}
+
+	// Favorite host goes to the top, other hosts keep their order
+	lm.Select(2)
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'*'}})
+	require.Equal(t, MsgToggleFavorite{HostID: 3}, cmd())
+	lm.Update(MsgToggleFavorite{HostID: 3})
+	require.Equal(t, []string{"Mock Host 3", "Mock Host 1", "Mock Host 2"}, titles())
+	require.Equal(t, 3, lm.SelectedItem().(ListItemHost).ID)
+	require.True(t, lm.SelectedItem().(ListItemHost).IsFavorite)
+
+	// Favorite is persisted
+	hosts, _ := lm.repo.GetAll()
+	saved, found := lo.Find(hosts, func(h host.Host) bool { return h.ID == 3 && h.IsFavorite })
+	require.True(t, found)
+	require.Equal(t, "Mock Host 3", saved.Title)
+
+	// Favorites are sorted by title as well
+	lm.Update(MsgToggleFavorite{HostID: 2})
+	require.Equal(t, []string{"Mock Host 2", "Mock Host 3", "Mock Host 1"}, titles())
+
+	lm.Update(MsgToggleFavorite{HostID: 3})
+	require.Equal(t, []string{"Mock Host 2", "Mock Host 1", "Mock Host 3"}, titles())
+}
+
+func TestSortHosts(t *testing.T) {
+	titles := func(hosts []host.Host) []string {
+		return lo.Map(hosts, func(h host.Host, _ int) string { return h.Title })
+	}
+	hosts := []host.Host{{Title: "b"}, {Title: "d", IsFavorite: true}, {Title: "a"}, {Title: "c", IsFavorite: true}}
+	sortHosts(hosts, constant.HostSortTitle)
+	require.Equal(t, []string{"c", "d", "a", "b"}, titles(hosts))
+
+	// Recently connected hosts go first, hosts which were never connected or connected at the same time are sorted
+	// by title
+	now := time.Now()
+	hosts = []host.Host{
+		{Title: "a"},
+		{Title: "d", LastConnected: now.Add(-time.Hour)},
+		{Title: "c", LastConnected: now},
+		{Title: "b", LastConnected: now.Add(-time.Hour)},
+		{Title: "e", LastConnected: now.Add(-time.Minute), IsFavorite: true},
+	}
+	sortHosts(hosts, constant.HostSortLastConnected)
+	require.Equal(t, []string{"e", "c", "b", "d", "a"}, titles(hosts))
+
+	// Hosts with the same title are sorted by id
+	hosts = []host.Host{{ID: 2, Title: "a"}, {ID: 1, Title: "a"}}
+	sortHosts(hosts, constant.HostSortGroup)
+	require.Equal(t, []int{1, 2}, lo.Map(hosts, func(h host.Host, _ int) int { return h.ID }))
+}
+
+func TestListModel_cycleSort(t *testing.T) {
+	lm := NewMockListModel(false)
+	hosts, _ := lm.repo.GetAll()
+	hosts[0].Group = "web"
+	hosts[1].LastConnected = time.Now().Add(-time.Hour)
+	hosts[2].LastConnected = time.Now()
+	test.CmdToMessage(lm.Init(), &[]tea.Msg{})
+
+	itemTitles := func() []string {
+		return lo.Map(lm.Items(), func(item list.Item, _ int) string {
+			return item.(interface{ Title() string }).Title()
+		})
+	}
+
+	// Hosts are grouped by default
+	require.Equal(t, []string{
+		"scratch", "▾ web", "Mock Host 1", "▾ Ungrouped", "Mock Host 2", "Mock Host 3",
+	}, itemTitles())
+
+	lm.selectItem(hostWithID(2))
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	require.Equal(t, MsgCycleSort{}, cmd())
+
+	// Group headers are not displayed when hosts are sorted by title, focused host stays selected
+	lm.Update(MsgCycleSort{})
+	require.Equal(t, constant.HostSortTitle, lm.appState.HostSortOrder)
+	require.Equal(t, []string{"scratch", "Mock Host 1", "Mock Host 2", "Mock Host 3"}, itemTitles())
+	require.Equal(t, 2, lm.SelectedItem().(ListItemHost).ID)
+	require.Equal(t, "hosts are sorted by title", lm.Title)
+
+	lm.Update(MsgCycleSort{})
+	require.Equal(t, constant.HostSortLastConnected, lm.appState.HostSortOrder)
+	require.Equal(t, []string{"scratch", "Mock Host 3", "Mock Host 2", "Mock Host 1"}, itemTitles())
+	require.Equal(t, 2, lm.SelectedItem().(ListItemHost).ID)
+	require.Equal(t, "hosts are sorted by last connection", lm.Title)
+
+	lm.Update(MsgCycleSort{})
+	require.Equal(t, constant.HostSortGroup, lm.appState.HostSortOrder)
+	require.Len(t, lm.Items(), 6)
+	require.Equal(t, "hosts are sorted by group", lm.Title)
+
+	// Sort order is kept in the application state, so it's used when the list is created again
+	lm.appState.HostSortOrder = constant.HostSortLastConnected
+	lm.reloadItems(nil)
+	require.Equal(t, []string{"scratch", "Mock Host 3", "Mock Host 2", "Mock Host 1"}, itemTitles())
+}
+
+func TestNew_Theme(t *testing.T) {
+	t.Cleanup(func() { theme.Set(theme.DefaultTheme) })
+	defaultList := NewMockListModel(false)
+
+	// Title and focused host use colors of the selected theme
+	theme.Set("forest")
+	palette := theme.Current()
+	lm := NewMockListModel(false)
+	require.NotEqual(t, defaultList.Styles.Title.GetBackground(), lm.Styles.Title.GetBackground())
+	require.Equal(t, palette.TitleBackground, lm.Styles.Title.GetBackground())
+	require.Equal(t, palette.TitleForeground, lm.Styles.Title.GetForeground())
+
+	layout := constant.ScreenLayoutNormal
+	delegate := NewHostDelegate(&layout, &test.MockLogger{})
+	require.Equal(t, palette.Highlight, delegate.Styles.SelectedTitle.GetForeground())
+	require.Equal(t, palette.Accent, delegate.Styles.SelectedTitle.GetBorderLeftForeground())
+	require.Equal(t, palette.Accent, delegate.Styles.SelectedDesc.GetForeground())
+}
+
+func TestLastConnectedSince(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, "never", lastConnectedSince(time.Time{}, now))
+	require.Equal(t, "just now", lastConnectedSince(now.Add(-time.Second*30), now))
+	require.Equal(t, "5m ago", lastConnectedSince(now.Add(-time.Minute*5-time.Second*10), now))
+	require.Equal(t, "3h ago", lastConnectedSince(now.Add(-time.Hour*3-time.Minute*20), now))
+	require.Equal(t, "3d ago", lastConnectedSince(now.Add(-time.Hour*75), now))
+	require.Equal(t, "4mo ago", lastConnectedSince(now.AddDate(0, -4, 0), now))
+	require.Equal(t, "2y ago", lastConnectedSince(now.AddDate(-2, 0, -1), now))
+}
+
+func TestHostDelegate_Render_SearchMatches(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.SetSize(100, 40)
+	lm.SetItems([]list.Item{
+		ListItemHost{Host: host.Host{ID: 1, Title: "Web", IsFavorite: true}},
+		ListItemHost{Host: host.Host{ID: 2, Title: "Web", Address: "web.example.com"}},
+	})
+	lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("we")})
+	msgs := []tea.Msg{}
+	test.CmdToMessage(cmd, &msgs)
+	for _, msg := range msgs {
+		if matches, ok := msg.(list.FilterMatchesMsg); ok {
+			lm.Update(matches)
+		}
+	}
+
+	layout := constant.ScreenLayoutTight
+	delegate := NewHostDelegate(&layout, &test.MockLogger{})
+	delegate.marked = map[int]struct{}{1: {}}
+	// Matched runes are made upper case, because colors are not rendered in tests
+	delegate.Styles.FilterMatch = lipgloss.NewStyle().Transform(strings.ToUpper)
+	render := func(index int) string {
+		var b strings.Builder
+		delegate.Render(&b, lm.Model, index, lm.VisibleItems()[index])
+		return b.String()
+	}
+
+	// Matches are highlighted in the title, not in the check mark and the icon in front of it
+	require.Contains(t, render(0), "✓ ★ WEb")
+	require.Contains(t, render(1), "WEb")
+}
+
+func TestHostDelegate_Render_LastConnected(t *testing.T) {
+	lm := NewMockListModel(false)
+	lm.SetSize(100, 40)
+	layout := constant.ScreenLayoutNormal
+	delegate := NewHostDelegate(&layout, &test.MockLogger{})
+	render := func(h host.Host) string {
+		var b strings.Builder
+		delegate.Render(&b, lm.Model, 0, ListItemHost{Host: h})
+		return b.String()
+	}
+
+	require.Contains(t, render(host.Host{ID: 1, Title: "web", Description: "frontend"}), "frontend • never")
+	require.Contains(t, render(host.Host{ID: 1, Title: "web", LastConnected: time.Now().Add(-time.Hour * 75)}), "3d ago")
+	// Scratch host is never stored
+	require.NotContains(t, render(host.Host{ID: host.ScratchHostID, Title: "scratch"}), "never")
+}
@@ -1,6 +1,12 @@
 package hostlist
 
 import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/list"
+
+	"github.com/grafviktor/goto/internal/i18n"
 	"github.com/grafviktor/goto/internal/model/host"
 )
 
@@ -16,4 +22,77 @@ func (l ListItemHost) Title() string { return l.Host.Title }
 func (l ListItemHost) Description() string { return l.Host.Description }
 
 // FilterValue - returns the field combination which are used when user performs a search in the list.
-func (l ListItemHost) FilterValue() string { return l.Host.Title + l.Host.Description }
+// Fields are separated by line breaks, so that a query doesn't match the end of one field and the beginning
+// of another. Hostname from ssh config is included once the config is loaded, so a host can be found by
+// its IP address when it's stored as an alias or a short name.
+func (l ListItemHost) FilterValue() string {
+	value := l.Host.Title + "\n" + l.Host.Address + "\n" + l.Host.Description
+	if l.SSHClientConfig != nil && l.SSHClientConfig.Hostname != "" &&
+		!strings.EqualFold(l.SSHClientConfig.Hostname, strings.TrimSpace(l.Host.Address)) {
+		value += "\n" + l.SSHClientConfig.Hostname
+	}
+
+	return value
+}
+
+// searchFilter - returns items which contain the search term, case-insensitive. Unlike the default fuzzy
+// filter, characters of the term must go one after another, and items keep their order in the list.
+// Matched indexes point to the title only, see hostDelegate.Render.
+func searchFilter(term string, targets []string) []list.Rank {
+	term = strings.ToLower(term)
+	ranks := []list.Rank{}
+	for i, target := range targets {
+		target = strings.ToLower(target)
+		index := strings.Index(target, term)
+		if index < 0 {
+			continue
+		}
+
+		// Matched indexes are highlighted in the title, so hosts which match by other fields are not highlighted.
+		title, _, _ := strings.Cut(target, "\n")
+		if index+len(term) > len(title) {
+			ranks = append(ranks, list.Rank{Index: i})
+			continue
+		}
+
+		// Matched indexes are positions of runes, not bytes.
+		start := utf8.RuneCountInString(target[:index])
+		matched := make([]int, utf8.RuneCountInString(term))
+		for j := range matched {
+			matched[j] = start + j
+		}
+
+		ranks = append(ranks, list.Rank{Index: i, MatchedIndexes: matched})
+	}
+
+	return ranks
+}
+
+// ListItemGroup is a header which is displayed above hosts of a group. Hosts of a collapsed group are hidden.
+// Empty name stands for hosts which don't have a group.
+type ListItemGroup struct {
+	Name      string
+	Collapsed bool
+	// Count is number of hosts in the group, including the hidden ones.
+	Count int
+}
+
+// Title - returns name of the group prefixed with an arrow which shows whether the group is collapsed.
+func (l ListItemGroup) Title() string {
+	arrow := "▾ "
+	if l.Collapsed {
+		arrow = "▸ "
+	}
+
+	if l.Name == "" {
+		return arrow + i18n.T("Ungrouped")
+	}
+
+	return arrow + l.Name
+}
+
+// Description - returns number of hosts in the group.
+func (l ListItemGroup) Description() string { return i18n.Tf("%d hosts", l.Count) }
+
+// FilterValue - returns an empty string, so that group headers are hidden when user performs a search in the list.
+func (l ListItemGroup) FilterValue() string { return "" }
@@ -3,19 +3,43 @@ package hostlist
 import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/samber/lo"
+
+	"github.com/grafviktor/goto/internal/i18n"
 )
 
 type keyMap struct {
 	cursorUp              key.Binding
 	cursorDown            key.Binding
 	connect               key.Binding
+	fastConnect           key.Binding
+	tailLog               key.Binding
+	openSFTP              key.Binding
 	copyID                key.Binding
+	checkAuth             key.Binding
+	toggleJumpHost        key.Binding
+	copyPublicKey         key.Binding
+	copyIdentityPath      key.Binding
+	openInEditor          key.Binding
+	mountSSHFS            key.Binding
+	dashboard             key.Binding
+	openWebURL            key.Binding
 	append                key.Binding
 	clone                 key.Binding
+	cloneToGroup          key.Binding
 	edit                  key.Binding
 	remove                key.Binding
 	toggleLayout          key.Binding
+	cycleSort             key.Binding
+	toggleMark            key.Binding
+	toggleFavorite        key.Binding
+	setIdentityFile       key.Binding
+	pinAgentKey           key.Binding
+	quickConnect          key.Binding
+	filterByTag           key.Binding
+	toggleGroup           key.Binding
+	recentHost            key.Binding
 	confirm               key.Binding
+	help                  key.Binding
 	shouldShowEditButtons bool
 }
 
@@ -23,43 +47,131 @@ func newDelegateKeyMap() *keyMap {
 	km := keyMap{
 		cursorUp: key.NewBinding(
 			key.WithKeys("up", "k", "shift+tab"),
-			key.WithHelp("↑/k", "up"),
+			key.WithHelp("↑/k", i18n.T("up")),
 		),
 		cursorDown: key.NewBinding(
 			key.WithKeys("down", "j", "tab"),
-			key.WithHelp("↓/j", "down"),
+			key.WithHelp("↓/j", i18n.T("down")),
 		),
 		connect: key.NewBinding(
 			key.WithKeys("enter"),
-			key.WithHelp("↩", "connect"),
+			key.WithHelp("↩", i18n.T("connect")),
+		),
+		fastConnect: key.NewBinding(
+			key.WithKeys("alt+enter"),
+			key.WithHelp("alt+↩", i18n.T("fast connect")),
+		),
+		tailLog: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", i18n.T("tail log")),
+		),
+		openSFTP: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", i18n.T("sftp session")),
 		),
 		append: key.NewBinding(
 			key.WithKeys("i", "n", "insert"),
-			key.WithHelp("i/n", "new"),
+			key.WithHelp("i/n", i18n.T("new")),
 		),
 		edit: key.NewBinding(
 			key.WithKeys("e"),
-			key.WithHelp("e", "edit"),
+			key.WithHelp("e", i18n.T("edit")),
 		),
 		clone: key.NewBinding(
 			key.WithKeys("c"),
-			key.WithHelp("c", "clone"),
+			key.WithHelp("c", i18n.T("clone")),
+		),
+		cloneToGroup: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", i18n.T("clone to group")),
 		),
 		remove: key.NewBinding(
 			key.WithKeys("d", "x"),
-			key.WithHelp("d/x", "delete"),
+			key.WithHelp("d/x", i18n.T("delete")),
 		),
 		copyID: key.NewBinding(
 			key.WithKeys("t"),
-			key.WithHelp("t", "ssh-copy-id"),
+			key.WithHelp("t", i18n.T("ssh-copy-id")),
+		),
+		checkAuth: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", i18n.T("check auth")),
+		),
+		toggleJumpHost: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", i18n.T("jump host")),
+		),
+		copyPublicKey: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", i18n.T("copy public key")),
+		),
+		copyIdentityPath: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", i18n.T("copy key path")),
+		),
+		openInEditor: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", i18n.T("edit hosts file")),
+		),
+		mountSSHFS: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", i18n.T("mount sshfs")),
+		),
+		openWebURL: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", i18n.T("open web url")),
+		),
+		dashboard: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", i18n.T("summary")),
+		),
+		toggleMark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", i18n.T("select")),
+		),
+		toggleFavorite: key.NewBinding(
+			key.WithKeys("*"),
+			key.WithHelp("*", i18n.T("favorite")),
+		),
+		setIdentityFile: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", i18n.T("set identity file")),
+		),
+		pinAgentKey: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", i18n.T("pin agent key")),
+		),
+		quickConnect: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", i18n.T("connect by alias")),
+		),
+		filterByTag: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", i18n.T("filter by tag")),
+		),
+		toggleGroup: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", i18n.T("collapse group")),
+		),
+		recentHost: key.NewBinding(
+			key.WithKeys("`"),
+			key.WithHelp("`", i18n.T("recent host")),
 		),
 		toggleLayout: key.NewBinding(
 			key.WithKeys("v"),
-			key.WithHelp("v", "toggle view"),
+			key.WithHelp("v", i18n.T("toggle view")),
+		),
+		cycleSort: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", i18n.T("sort order")),
 		),
 		confirm: key.NewBinding(
 			key.WithKeys("y", "Y"),
-			key.WithHelp("y", "confirm"),
+			key.WithHelp("y", i18n.T("confirm")),
+		),
+		help: key.NewBinding(
+			key.WithKeys("?", "f1"),
+			key.WithHelp("?", i18n.T("help")),
 		),
 	}
 
@@ -70,12 +182,26 @@ func newDelegateKeyMap() *keyMap {
 func (k *keyMap) SetShouldShowEditButtons(val bool) {
 	k.shouldShowEditButtons = val
 	k.clone.SetEnabled(val)
+	k.cloneToGroup.SetEnabled(val)
 	k.connect.SetEnabled(val)
+	k.fastConnect.SetEnabled(val)
+	k.tailLog.SetEnabled(val)
+	k.openSFTP.SetEnabled(val)
 	k.copyID.SetEnabled(val)
+	k.checkAuth.SetEnabled(val)
+	k.toggleJumpHost.SetEnabled(val)
+	k.copyPublicKey.SetEnabled(val)
+	k.copyIdentityPath.SetEnabled(val)
 	k.cursorDown.SetEnabled(val)
 	k.cursorUp.SetEnabled(val)
 	k.edit.SetEnabled(val)
+	k.mountSSHFS.SetEnabled(val)
+	k.openWebURL.SetEnabled(val)
 	k.remove.SetEnabled(val)
+	k.toggleMark.SetEnabled(val)
+	k.toggleFavorite.SetEnabled(val)
+	k.setIdentityFile.SetEnabled(val)
+	k.pinAgentKey.SetEnabled(val)
 	k.copyID.SetEnabled(val)
 }
 
@@ -98,14 +224,49 @@ func (k *keyMap) ShortHelp() []key.Binding {
 	})
 }
 
-func (k *keyMap) FullHelp() []key.Binding {
-	return []key.Binding{
-		k.connect,
+// Full help categories. Each category is rendered as a separate column of the help overlay.
+const (
+	helpCategoryNavigation = iota
+	helpCategoryEditing
+	helpCategoryConnection
+)
+
+func (k *keyMap) FullHelp() [][]key.Binding {
+	categories := make([][]key.Binding, 3)
+	categories[helpCategoryNavigation] = []key.Binding{
+		k.toggleMark,
+		k.toggleLayout,
+		k.cycleSort,
+		k.recentHost,
+		k.filterByTag,
+		k.toggleGroup,
+		k.dashboard,
+	}
+	categories[helpCategoryEditing] = []key.Binding{
 		k.append,
 		k.clone,
+		k.cloneToGroup,
 		k.edit,
 		k.remove,
+		k.toggleFavorite,
+		k.setIdentityFile,
+		k.pinAgentKey,
+		k.openInEditor,
+	}
+	categories[helpCategoryConnection] = []key.Binding{
+		k.connect,
+		k.fastConnect,
+		k.tailLog,
+		k.openSFTP,
+		k.quickConnect,
 		k.copyID,
-		k.toggleLayout,
+		k.checkAuth,
+		k.toggleJumpHost,
+		k.copyPublicKey,
+		k.copyIdentityPath,
+		k.mountSSHFS,
+		k.openWebURL,
 	}
+
+	return categories
 }
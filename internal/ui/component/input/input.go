@@ -26,12 +26,17 @@ func New() *Input {
 // Input - input UI component.
 type Input struct {
 	textinput.Model
-	label          string
-	FocusedPrompt  string
-	Tooltip        string
-	Err            error
+	label         string
+	FocusedPrompt string
+	Tooltip       string
+	Err           error
+	// Warn - checks the value, but unlike Validate, does not prevent the form from being saved.
+	Warn           func(string) error
+	Warning        error
 	enabled        bool
 	displayTooltip bool
+	// options - when set, the input is a selector which cycles through the options instead of accepting text.
+	options []string
 }
 
 //nolint:revive // Init function is a part of tea component interface
@@ -47,18 +52,33 @@ func (l *Input) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return l, nil
 	}
 
-	l.Model, cmd = l.Model.Update(msg)
+	if keyMsg, isKey := msg.(tea.KeyMsg); isKey && len(l.options) > 0 {
+		switch keyMsg.String() {
+		case " ", "right":
+			l.selectOption(1)
+		case "left":
+			l.selectOption(-1)
+		}
+	} else {
+		l.Model, cmd = l.Model.Update(msg)
+	}
 
 	if l.Model.Validate != nil {
 		l.Err = l.Model.Validate(l.Model.Value())
 	}
 
+	l.CheckWarning()
+
 	return l, cmd
 }
 
 //nolint:revive // View function is a part of tea component interface
 func (l *Input) View() string {
 	view := l.Model.View()
+	if len(l.options) > 0 && l.Value() != "" {
+		// Selector has no cursor, the arrows hint that the value can be changed.
+		view = fmt.Sprintf("◂ %s ▸", l.Value())
+	}
 
 	if l.Focused() {
 		view = focusedInputText.Render(view)
@@ -95,6 +115,8 @@ func (l *Input) labelView() string {
 	switch {
 	case l.Err != nil:
 		return l.prompt() + errorStyle.Render(l.Label())
+	case l.Warning != nil:
+		return l.prompt() + warningStyle.Render(fmt.Sprintf("%s (%s)", l.Label(), l.Warning.Error()))
 	case l.Focused():
 		return l.prompt() + focusedStyle.Render(l.Label())
 	case !l.Enabled():
@@ -104,6 +126,33 @@ func (l *Input) labelView() string {
 	}
 }
 
+// CheckWarning - runs Warn function against the current value and stores the result in Warning field.
+func (l *Input) CheckWarning() {
+	if l.Warn != nil {
+		l.Warning = l.Warn(l.Model.Value())
+	}
+}
+
+// SetOptions turns the Input into a selector, space and arrow keys cycle through the options.
+// The first option is selected unless the current value is one of the options.
+func (l *Input) SetOptions(options ...string) {
+	l.options = options
+	if len(options) > 0 && !lo.Contains(options, l.Value()) {
+		l.SetValue(options[0])
+	}
+}
+
+// Options returns the options of the selector, it's empty for a text Input.
+func (l *Input) Options() []string {
+	return l.options
+}
+
+func (l *Input) selectOption(step int) {
+	index := lo.IndexOf(l.options, l.Value()) + step
+	count := len(l.options)
+	l.SetValue(l.options[(index%count+count)%count])
+}
+
 // SetEnabled controls whether the component can be focused and changed.
 func (l *Input) SetEnabled(isEnabled bool) {
 	l.enabled = isEnabled
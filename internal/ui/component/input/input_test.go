@@ -1,11 +1,15 @@
 package input
 
 import (
+	"errors"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/grafviktor/goto/internal/ui/theme"
 )
 
 func TestInput_Update_KeyMsg(t *testing.T) {
@@ -45,3 +49,65 @@ func TestInput_Display_Tooltip(t *testing.T) {
 	require.NotContains(t, model.View(), "mock tooltip")
 	require.Contains(t, model.View(), "mock text")
 }
+
+func TestInput_Warning(t *testing.T) {
+	// Test that a warning is displayed next to the label and it's updated when the value changes
+
+	model := New()
+	model.SetLabel("Label")
+	model.Focus()
+	model.Warn = func(s string) error {
+		if s == "risky" {
+			return errors.New("mock warning")
+		}
+
+		return nil
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("risky")})
+	require.EqualError(t, model.Warning, "mock warning")
+	require.Contains(t, model.View(), "Label (mock warning)")
+	// Warning does not make the input invalid
+	require.NoError(t, model.Err)
+
+	model.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	require.NoError(t, model.Warning)
+	require.NotContains(t, model.View(), "mock warning")
+}
+
+func TestInput_Options(t *testing.T) {
+	// Test that the selector cycles through the options and ignores typed text
+
+	model := New()
+	model.SetOptions("no", "yes")
+	model.Focus()
+	require.Equal(t, "no", model.Value())
+	require.Contains(t, model.View(), "◂ no ▸")
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("maybe")})
+	require.Equal(t, "no", model.Value())
+
+	model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	require.Equal(t, "yes", model.Value())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRight})
+	require.Equal(t, "no", model.Value())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	require.Equal(t, "yes", model.Value())
+
+	// Value which is one of the options is preserved
+	model.SetOptions("no", "yes")
+	require.Equal(t, "yes", model.Value())
+}
+
+func TestSetStyles(t *testing.T) {
+	palette := theme.Current()
+	palette.Highlight = lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"}
+	palette.Error = lipgloss.AdaptiveColor{Light: "#FF0000", Dark: "#FF0000"}
+	SetStyles(palette)
+
+	require.Equal(t, palette.Highlight, focusedStyle.GetForeground())
+	require.Equal(t, palette.Error, errorStyle.GetForeground())
+	require.Equal(t, palette.Warning, warningStyle.GetForeground())
+}
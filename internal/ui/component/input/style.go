@@ -1,19 +1,37 @@
 package input
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/grafviktor/goto/internal/ui/theme"
+)
 
 // Ansi to hex color cheat-sheet: https://www.ditig.com/publications/256-colors-cheat-sheet
 
 var (
+	greyedOutStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#585858"))
+	noStyle        = lipgloss.NewStyle()
+
+	// Styles below use colors of the theme, see SetStyles.
+	focusedStyle     lipgloss.Style
+	errorStyle       lipgloss.Style
+	warningStyle     lipgloss.Style
+	focusedInputText lipgloss.Style
+)
+
+// SetStyles - builds styles of the inputs which use colors of the palette. It should be called by a component
+// which displays inputs when it's created, because theme is selected after the package is initialized.
+func SetStyles(palette theme.Palette) {
 	focusedStyle = lipgloss.NewStyle().
-			BorderForeground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"}).
-			Foreground(lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"})
+		BorderForeground(palette.Accent).
+		Foreground(palette.Highlight)
 
 	errorStyle = lipgloss.NewStyle().
-			BorderForeground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"}).
-			Foreground(lipgloss.AdaptiveColor{Light: "#FF7783", Dark: "#FF7783"})
+		BorderForeground(palette.Accent).
+		Foreground(palette.Error)
 
-	focusedInputText = lipgloss.NewStyle().Foreground(lipgloss.Color("#AD58B4"))
-	greyedOutStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#585858"))
-	noStyle          = lipgloss.NewStyle()
-)
+	warningStyle = lipgloss.NewStyle().
+		Foreground(palette.Warning)
+
+	focusedInputText = lipgloss.NewStyle().Foreground(palette.Accent)
+}
@@ -7,6 +7,7 @@ import (
 
 	"golang.org/x/term"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/grafviktor/goto/internal/constant"
@@ -21,6 +22,8 @@ type (
 	TerminalSizePolling struct{ Width, Height int }
 	// HostListSelectItem is required to let host list know that it's time to update title.
 	HostListSelectItem struct{ HostID int }
+	// HostListNotify - is dispatched when a notification should be displayed in the host list title.
+	HostListNotify struct{ Text string }
 	// HostCreated - is dispatched when a new host was added to the database.
 	HostCreated struct{ Host host.Host }
 	// HostUpdated - is dispatched when host model is updated.
@@ -32,17 +35,57 @@ type (
 		Config ssh.Config
 	}
 	// RunProcessSSHConnect is dispatched when user wants to connect to a host.
-	RunProcessSSHConnect struct{ Host host.Host }
+	// ForwardPreset is a name of the port forwarding preset which user selected, can be empty.
+	// Profile is a name of the connection profile which user selected, can be empty. See host.WithProfile.
+	// IdentityFile is an identity file which user selected, can be empty. See host.WithIdentityFile.
+	// Fast is set when ssh should not read config files, see host.CmdSSHFastConnect.
+	// TailLog is set when ssh should follow log file of the host, see host.CmdSSHTailLog.
+	// SFTP is set when sftp session should be opened instead of ssh, see host.CmdSFTP.
+	RunProcessSSHConnect struct {
+		Host          host.Host
+		ForwardPreset string
+		Profile       string
+		IdentityFile  string
+		Fast          bool
+		TailLog       bool
+		SFTP          bool
+	}
 	// RunProcessSSHLoadConfig is dispatched it's required to read .ssh/config file for a certain host.
 	RunProcessSSHLoadConfig struct{ Host host.Host }
 	// RunProcessSSHCopyID is dispatched when user wants to copy SSH key to a remote host.
 	RunProcessSSHCopyID struct{ Host host.Host }
+	// RunProcessSSHCheckAuth is dispatched when user wants to check that they can log in to a remote host.
+	RunProcessSSHCheckAuth struct{ Host host.Host }
+	// RunProcessSSHFS is dispatched when user wants to mount remote file system of a host into MountPoint folder.
+	RunProcessSSHFS struct {
+		Host       host.Host
+		MountPoint string
+	}
+	// RunProcessOpenURL is dispatched when user wants to open web url of a host in the default browser.
+	RunProcessOpenURL struct{ URL string }
+	// RunProcessListAgentKeys is dispatched when user wants to choose one of ssh-agent keys.
+	RunProcessListAgentKeys struct{}
+	// AgentKeysLoaded triggers when app lists ssh-agent keys using ssh-add -l.
+	AgentKeysLoaded struct{ Keys []ssh.AgentKey }
+	// RunProcessEditStorage is dispatched when user wants to edit hosts file in a text editor.
+	RunProcessEditStorage struct{}
 	// RunProcessErrorOccurred fires when there is an error executing an external process.
+	// StdErr contains the command which failed and the error details, Reason contains error details only.
+	// ExitCode is -1 if the process didn't start or was terminated by a signal.
 	RunProcessErrorOccurred struct {
 		ProcessType constant.ProcessType
 		StdOut      string // Even if process fails, it may have some output.
 		StdErr      string
+		Reason      string
+		ExitCode    int
 	}
+	// SuggestSSHCopyID is dispatched when connection fails because the remote host rejected
+	// the public key. User is asked whether the key should be copied to the host.
+	SuggestSSHCopyID struct{}
+	// OpenDashboard is dispatched when user wants to see a summary of all hosts.
+	OpenDashboard struct{}
+	// OpenHelpOverlay is dispatched when user wants to see all keyboard shortcuts of the current screen.
+	OpenHelpOverlay struct{ KeyBindings [][]key.Binding }
 	// RunProcessSuccess fires when external process exits normally.
 	RunProcessSuccess struct {
 		ProcessType constant.ProcessType
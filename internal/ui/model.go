@@ -2,24 +2,85 @@
 package ui
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
 
 	"github.com/grafviktor/goto/internal/constant"
+	"github.com/grafviktor/goto/internal/i18n"
+	hostModel "github.com/grafviktor/goto/internal/model/host"
 	"github.com/grafviktor/goto/internal/model/ssh"
 	"github.com/grafviktor/goto/internal/state"
 	"github.com/grafviktor/goto/internal/storage"
+	"github.com/grafviktor/goto/internal/ui/component/dashboard"
 	"github.com/grafviktor/goto/internal/ui/component/hostedit"
 	"github.com/grafviktor/goto/internal/ui/component/hostlist"
 	"github.com/grafviktor/goto/internal/ui/message"
 	"github.com/grafviktor/goto/internal/utils"
 )
 
+var helpOverlayStyle = lipgloss.NewStyle().Margin(1, 2)
+
+// echoCommandDelay is how long connect command is displayed before ssh takes over the screen. See config.User.EchoCommand.
+const echoCommandDelay = time.Millisecond * 700
+
+// passwordCommandRunner executes a command which prints a password to stdout, for instance 'pass show servers/db'.
+// The command takes over the terminal, so password manager can ask user to unlock the store. onExit callback
+// receives the output of the command. It is a variable in order to be replaced in unit tests.
+var passwordCommandRunner = func(command string, onExit func(output string, err error) tea.Msg) tea.Cmd {
+	process := utils.BuildProcess(command)
+	if process == nil {
+		return message.TeaCmd(onExit("", errors.New("password command is empty")))
+	}
+
+	stdout, stderr := &utils.ProcessBufferWriter{}, &utils.ProcessBufferWriter{}
+	process.Stdout = stdout
+	// Prompts are displayed to user, they're also preserved to explain why the command failed.
+	process.Stderr = io.MultiWriter(os.Stderr, stderr)
+
+	return tea.ExecProcess(process, func(err error) tea.Msg {
+		return onExit(passwordCommandResult(stdout.Output, stderr.Output, err))
+	})
+}
+
+// passwordCommandResult - returns output of the password command. Exit status alone doesn't say why the command
+// failed, that's why the error contains text which the command printed to stderr.
+func passwordCommandResult(stdout, stderr []byte, err error) (string, error) {
+	if err == nil {
+		return string(stdout), nil
+	}
+
+	if reason := strings.TrimSpace(string(stderr)); reason != "" {
+		return "", fmt.Errorf("%w: %s", err, reason)
+	}
+
+	return "", err
+}
+
+type (
+	// msgStorageChanged fires when hosts storage was changed by another application.
+	msgStorageChanged struct{}
+	// msgPasswordRead fires when password command exits, ssh connection is established after that.
+	msgPasswordRead struct {
+		connect message.RunProcessSSHConnect
+		output  string
+		err     error
+	}
+)
+
 type iLogger interface {
 	Debug(format string, args ...any)
 	Info(format string, args ...any)
@@ -35,11 +96,12 @@ func New(
 	log iLogger,
 ) mainModel {
 	m := mainModel{
-		modelHostList: hostlist.New(ctx, storage, appState, log),
-		appContext:    ctx,
-		hostStorage:   storage,
-		appState:      appState,
-		logger:        log,
+		modelHostList:  hostlist.New(ctx, storage, appState, log),
+		modelDashboard: dashboard.New(ctx, storage, appState.ApplicationConfig.PruneThreshold, log),
+		appContext:     ctx,
+		hostStorage:    storage,
+		appState:       appState,
+		logger:         log,
 	}
 
 	return m
@@ -50,16 +112,110 @@ type mainModel struct {
 	hostStorage        storage.HostStorage
 	modelHostList      tea.Model
 	modelHostEdit      tea.Model
+	modelDashboard     tea.Model
 	appState           *state.ApplicationState
 	viewMessageContent string
+	// storageFileContent is a snapshot of hosts file taken before the file is opened in a text editor.
+	storageFileContent []byte
+	storageChanges     <-chan struct{}
+	helpKeyBindings    [][]key.Binding
+	previousView       state.View
 	logger             iLogger
 	viewport           viewport.Model
 	ready              bool
+	// connectedHost is a host of the current ssh session, its result is stored when the session ends.
+	connectedHost *hostModel.Host
+	// probedHost is a host which operating system is being detected. See dispatchProcessProbeRemoteOS.
+	probedHost *hostModel.Host
+	// autoConnectQueue contains background tunnels which are not yet established on app start. Tunnels are
+	// established one by one, because ssh may ask for a password. See autoConnect.
+	autoConnectQueue []hostModel.Host
 }
 
 func (m *mainModel) Init() tea.Cmd {
 	m.logger.Debug("[UI] Run init function")
-	return m.modelHostList.Init() // Loads hosts from DB
+	return tea.Batch(
+		m.modelHostList.Init(), // Loads hosts from DB
+		m.watchStorage(),
+		m.autoConnect(),
+	)
+}
+
+// autoConnect - establishes background tunnels of the hosts which are marked to connect on app start.
+func (m *mainModel) autoConnect() tea.Cmd {
+	hosts, err := m.hostStorage.GetAll()
+	if err != nil {
+		m.logger.Error("[UI] Cannot read hosts to connect on app start. %v", err)
+		return nil
+	}
+
+	m.autoConnectQueue = hostModel.AutoConnectHosts(hosts)
+	m.logger.Debug("[UI] Hosts to connect on app start: %d", len(m.autoConnectQueue))
+
+	return m.connectNextAutoConnectHost()
+}
+
+// connectNextAutoConnectHost - establishes the next tunnel from the auto-connect queue, if any.
+func (m *mainModel) connectNextAutoConnectHost() tea.Cmd {
+	if len(m.autoConnectQueue) == 0 {
+		return nil
+	}
+
+	h := m.autoConnectQueue[0]
+	m.autoConnectQueue = m.autoConnectQueue[1:]
+	// Host list can't ask user to confirm the connection on app start, such tunnels are skipped instead.
+	if reason := m.autoConnectSkipReason(h); reason != "" {
+		m.logger.Info("[UI] Skip background tunnel to host id: %d, title: %s. %s", h.ID, h.Title, reason)
+		return tea.Batch(
+			message.TeaCmd(message.HostListNotify{Text: i18n.Tf("tunnel to %s is not established, %s", h.Title, reason)}),
+			m.connectNextAutoConnectHost(),
+		)
+	}
+
+	m.logger.Info("[UI] Establish background tunnel to host id: %d, title: %s", h.ID, h.Title)
+
+	return message.TeaCmd(message.RunProcessSSHConnect{Host: h})
+}
+
+// autoConnectSkipReason - returns the reason why the tunnel can't be established without asking user, the same
+// checks are made by the host list before connecting. Empty string is returned if the tunnel can be established.
+func (m *mainModel) autoConnectSkipReason(h hostModel.Host) string {
+	if m.appState.ApplicationConfig.WarnLoopback && h.ConnectsToLoopback() {
+		return i18n.T("host connects to the local machine")
+	}
+
+	if busy := utils.UnavailableAddresses(h.LocalBindAddresses("")); len(busy) > 0 {
+		return i18n.Tf("local ports are in use: %s", strings.Join(busy, ", "))
+	}
+
+	return ""
+}
+
+// watchStorage - subscribes to changes of the storage which are made by other applications.
+func (m *mainModel) watchStorage() tea.Cmd {
+	watchableStorage, ok := m.hostStorage.(storage.WatchableStorage)
+	if !ok {
+		return nil
+	}
+
+	changes, err := watchableStorage.Watch(m.appContext)
+	if err != nil {
+		m.logger.Error("[UI] Cannot watch storage changes. %v", err)
+		return nil
+	}
+
+	m.storageChanges = changes
+	return waitForStorageChange(changes)
+}
+
+func waitForStorageChange(changes <-chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-changes; !ok {
+			return nil
+		}
+
+		return msgStorageChanged{}
+	}
 }
 
 func (m *mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -79,6 +235,9 @@ func (m *mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.logger.Debug("[UI] Open host edit form")
 		m.appState.CurrentView = state.ViewEditItem
 		ctx := context.WithValue(m.appContext, hostedit.ItemID, msg.HostID)
+		if msg.ScratchHost != nil {
+			ctx = context.WithValue(ctx, hostedit.ScratchHost, *msg.ScratchHost)
+		}
 		m.modelHostEdit = hostedit.New(ctx, m.hostStorage, m.appState, m.logger)
 	case hostedit.CloseEditForm:
 		m.logger.Debug("[UI] Close host edit form")
@@ -86,27 +245,90 @@ func (m *mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case message.HostListSelectItem:
 		m.logger.Debug("[UI] Update app state. Active host id: %d", msg.HostID)
 		m.appState.Selected = msg.HostID
+		// User switches between two recently selected hosts, see hostlist.selectRecentHost.
+		m.appState.AddRecentHost(msg.HostID)
+	case message.OpenDashboard:
+		m.logger.Debug("[UI] Open dashboard")
+		m.appState.CurrentView = state.ViewDashboard
+		return m, m.modelDashboard.Init()
+	case dashboard.CloseDashboard:
+		m.logger.Debug("[UI] Close dashboard")
+		m.appState.CurrentView = state.ViewHostList
+		return m, nil
+	case dashboard.HostsPruned:
+		m.logger.Debug("[UI] Hosts pruned from the dashboard. Reload hosts")
+		return m, message.TeaCmd(hostlist.MsgRefreshRepo{})
+	case dashboard.FailedChecksChanged:
+		m.logger.Debug("[UI] Failed checks changed by reachability scan. Reload hosts")
+		return m, message.TeaCmd(hostlist.MsgRefreshRepo{})
+	case message.OpenHelpOverlay:
+		m.logger.Debug("[UI] Open help overlay")
+		m.helpKeyBindings = msg.KeyBindings
+		m.previousView = m.appState.CurrentView
+		m.appState.CurrentView = state.ViewHelp
+		return m, nil
 	case message.RunProcessSSHConnect:
+		if msg.SFTP {
+			m.logger.Debug("[UI] Open sftp session with host id: %d, title: %s", msg.Host.ID, msg.Host.Title)
+			return m, m.dispatchProcessSFTP(msg)
+		}
+
 		m.logger.Debug("[UI] Connect to focused SSH host")
-		return m, m.dispatchProcessSSHConnect(msg)
+		return m, m.readPasswordAndConnect(msg)
+	case msgPasswordRead:
+		return m, m.handlePasswordRead(msg)
 	case message.RunProcessSSHLoadConfig:
 		m.logger.Debug("[UI] Load SSH config for focused host id: %d, title: %s", msg.Host.ID, msg.Host.Title)
 		return m, m.dispatchProcessSSHLoadConfig(msg)
 	case message.RunProcessSSHCopyID:
 		m.logger.Debug("[UI] Copy SSH config to host id: %d, title: %s", msg.Host.ID, msg.Host.Title)
 		return m, m.dispatchProcessSSHCopyID(msg)
+	case message.RunProcessSSHCheckAuth:
+		m.logger.Debug("[UI] Check authentication on host id: %d, title: %s", msg.Host.ID, msg.Host.Title)
+		return m, m.dispatchProcessSSHCheckAuth(msg)
+	case message.RunProcessSSHFS:
+		m.logger.Debug("[UI] Mount remote file system of host id: %d, title: %s", msg.Host.ID, msg.Host.Title)
+		return m, m.dispatchProcessSSHFS(msg)
+	case message.RunProcessOpenURL:
+		m.logger.Debug("[UI] Open url in the default browser")
+		return m, m.dispatchProcessOpenURL(msg)
+	case message.RunProcessListAgentKeys:
+		m.logger.Debug("[UI] List ssh-agent keys")
+		return m, m.dispatchProcessListAgentKeys()
+	case msgStorageChanged:
+		m.logger.Debug("[UI] Storage changed by another application. Reload hosts")
+		return m, tea.Batch(
+			message.TeaCmd(hostlist.MsgRefreshRepo{}),
+			waitForStorageChange(m.storageChanges),
+		)
+	case message.RunProcessEditStorage:
+		m.logger.Debug("[UI] Open hosts file in text editor")
+		return m, m.dispatchProcessEditStorage()
 	case message.RunProcessSuccess:
 		m.logger.Debug("[UI] Handle process success message. Process: %v", msg.ProcessType)
 		cmd = m.handleProcessSuccess(msg)
 		cmds = append(cmds, cmd)
+		if msg.ProcessType == constant.ProcessTypeSSHConnect {
+			cmds = append(cmds, m.connectNextAutoConnectHost())
+		}
 	case message.RunProcessErrorOccurred:
 		m.logger.Debug("[UI] Handle process error message. Process: %v", msg.ProcessType)
-		m.handleProcessError(msg)
+		cmd = m.handleProcessError(msg)
+		cmds = append(cmds, cmd)
+		// Tunnel which failed to start doesn't prevent other tunnels from being established.
+		if msg.ProcessType == constant.ProcessTypeSSHConnect {
+			cmds = append(cmds, m.connectNextAutoConnectHost())
+		}
 	}
 
 	m.modelHostList, cmd = m.modelHostList.Update(msg)
 	cmds = append(cmds, cmd)
 
+	// Dashboard receives messages even if it's not active, because reachability scan
+	// may complete after user closed the dashboard.
+	m.modelDashboard, cmd = m.modelDashboard.Update(msg)
+	cmds = append(cmds, cmd)
+
 	if m.appState.CurrentView == state.ViewEditItem {
 		// Edit host receives messages only if it's active. We re-create this component every time we go to edit mode
 		m.modelHostEdit, cmd = m.modelHostEdit.Update(msg)
@@ -126,6 +348,10 @@ func (m *mainModel) View() string {
 		content = m.viewMessageContent
 	case state.ViewEditItem:
 		content = m.modelHostEdit.View()
+	case state.ViewHelp:
+		content = m.helpOverlayView()
+	case state.ViewDashboard:
+		content = m.modelDashboard.View()
 	}
 
 	// Wrap UI into the ViewPort
@@ -151,15 +377,34 @@ func (m *mainModel) handleKeyEvent(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// 2. Switch to HostList view
 		m.viewMessageContent = ""
 		m.appState.CurrentView = state.ViewHostList
+	case state.ViewHelp:
+		// Any key closes help overlay and brings user back to the screen where the overlay was opened.
+		m.helpKeyBindings = nil
+		m.appState.CurrentView = m.previousView
 	case state.ViewHostList:
 		m.modelHostList, cmd = m.modelHostList.Update(msg)
 	case state.ViewEditItem:
 		m.modelHostEdit, cmd = m.modelHostEdit.Update(msg)
+	case state.ViewDashboard:
+		m.modelDashboard, cmd = m.modelDashboard.Update(msg)
 	}
 
 	return m, cmd
 }
 
+func (m *mainModel) helpOverlayView() string {
+	helpModel := help.New()
+	helpModel.Width = m.appState.Width
+	helpModel.ShowAll = true
+
+	return helpOverlayStyle.Render(fmt.Sprintf(
+		"%s\n\n%s\n\n%s",
+		i18n.T("keyboard shortcuts"),
+		helpModel.FullHelpView(m.helpKeyBindings),
+		i18n.T("press any key to close"),
+	))
+}
+
 func (m *mainModel) updateViewPort(w, h int) tea.Model {
 	if !m.ready {
 		m.ready = true
@@ -207,10 +452,18 @@ func (m *mainModel) dispatchProcess(
 			commandWhichFailed := strings.Join(process.Args, " ")
 			// errorDetails contains command which was executed and the error text.
 			errorDetails := fmt.Sprintf("Command: %s\nError:   %s", commandWhichFailed, readableStdErr)
+			exitCode := -1
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				exitCode = exitErr.ExitCode()
+			}
+
 			return message.RunProcessErrorOccurred{
 				ProcessType: processType,
 				StdOut:      processOutput,
 				StdErr:      errorDetails,
+				Reason:      readableStdErr,
+				ExitCode:    exitCode,
 			}
 		}
 
@@ -237,17 +490,241 @@ func (m *mainModel) dispatchProcess(
 	return tea.ExecProcess(process, onProcessExitCallback)
 }
 
-func (m *mainModel) dispatchProcessSSHConnect(msg message.RunProcessSSHConnect) tea.Cmd {
+// readPasswordAndConnect - runs password command of the host if it's set, connection is established when
+// the command exits, see handlePasswordRead. Otherwise connects right away.
+func (m *mainModel) readPasswordAndConnect(msg message.RunProcessSSHConnect) tea.Cmd {
+	passwordCommand := lo.Ternary(msg.Host.UsesPassword(), msg.Host.PasswordCommand, "")
+	if utils.StringEmpty(passwordCommand) {
+		return m.dispatchProcessSSHConnect(msg, "")
+	}
+
+	m.logger.Debug("[EXEC] Read password using command: '%s'", passwordCommand)
+	return passwordCommandRunner(passwordCommand, func(output string, err error) tea.Msg {
+		return msgPasswordRead{connect: msg, output: output, err: err}
+	})
+}
+
+// handlePasswordRead - connects to the host using the password which is printed by password command.
+func (m *mainModel) handlePasswordRead(msg msgPasswordRead) tea.Cmd {
+	password, err := parsePassword(msg.output)
+	if msg.err != nil {
+		err = msg.err
+	}
+
+	if err != nil {
+		passwordCommand := msg.connect.Host.PasswordCommand
+		m.logger.Error("[EXEC] Cannot read password using command '%s'. %v", passwordCommand, err)
+		return message.TeaCmd(message.RunProcessErrorOccurred{
+			ProcessType: constant.ProcessTypeSSHConnect,
+			StdErr:      fmt.Sprintf("Command: %s\nError:   %v", passwordCommand, err),
+			Reason:      err.Error(),
+		})
+	}
+
+	return m.dispatchProcessSSHConnect(msg.connect, password)
+}
+
+// dispatchProcessSSHConnect - connects to the host, password is passed to sshpass if it's not empty.
+func (m *mainModel) dispatchProcessSSHConnect(msg message.RunProcessSSHConnect, password string) tea.Cmd {
 	m.logger.Debug("[EXEC] Build ssh connect command for hostname: %v, title: %v", msg.Host.Address, msg.Host.Title)
-	process := utils.BuildProcessInterceptStdErr(msg.Host.CmdSSHConnect())
+	// Connection time is recorded for the original host, selected identity file, profile overrides and
+	// resolved address are never persisted.
+	connectHost := msg.Host.WithIdentityFile(msg.IdentityFile)
+	connectHost = connectHost.WithProfile(msg.Profile)
+	if msg.Profile != "" {
+		m.logger.Debug("[EXEC] Apply connection profile '%s'", msg.Profile)
+	}
+	// Jump host of the session is not applied to itself and to the hosts which define their own jump host.
+	if jumpHostID, jumpHost := m.appState.JumpHost(); jumpHost != "" && jumpHostID != msg.Host.ID {
+		m.logger.Debug("[EXEC] Jump through host '%s'", jumpHost)
+		connectHost = connectHost.WithJumpHost(jumpHost)
+	}
+	// Profile may override the address with a short name as well.
+	connectHost = m.resolveAddress(connectHost)
+	// Jump host is chosen once per connection, so round-robin strategy doesn't skip jump hosts of the pool.
+	connectHost = connectHost.WithProxyJumpSelected()
+
+	var command string
+	switch {
+	case msg.TailLog:
+		command = connectHost.CmdSSHTailLog()
+	case msg.Fast:
+		command = connectHost.CmdSSHFastConnect()
+	default:
+		command = connectHost.CmdSSHConnectWithForwardPreset(msg.ForwardPreset)
+	}
+
+	process := utils.BuildProcessInterceptStdErr(command)
+	if err := m.setEnvFromFile(process, msg.Host.EnvFile); err != nil {
+		m.logger.Error("[EXEC] Cannot read environment file '%s'. %v", msg.Host.EnvFile, err)
+		return message.TeaCmd(message.RunProcessErrorOccurred{
+			ProcessType: constant.ProcessTypeSSHConnect,
+			StdErr:      fmt.Sprintf("Environment file: %s\nError:   %v", msg.Host.EnvFile, err),
+		})
+	}
+
+	// Terminal type is set after the environment file, so it takes precedence.
+	setTermType(process, msg.Host.TermType)
+
+	setPassword(process, password)
+
 	m.logger.Info("[EXEC] Run process: '%s'", process.String())
+	refreshCmd := m.recordConnection(msg.Host)
+	processCmd := m.dispatchProcess(constant.ProcessTypeSSHConnect, process, false, false)
+	if !m.appState.ApplicationConfig.EchoCommand {
+		return tea.Batch(refreshCmd, processCmd)
+	}
+
+	// The command is not built again, so the echoed command is exactly the one which is run.
+	echoed := hostModel.RedactPassword(command, connectHost.Password)
+	// Replace Windows ssh prefix "cmd /c ssh" with "ssh"
+	echoed = utils.RemoveDuplicateSpaces(strings.Replace(echoed, "cmd /c ", "", 1))
+	m.logger.Debug("[EXEC] Display connect command: '%s'", echoed)
+
+	return tea.Batch(refreshCmd, tea.Sequence(
+		message.TeaCmd(message.HostListNotify{Text: echoed}),
+		tea.Tick(echoCommandDelay, func(time.Time) tea.Msg { return nil }),
+		processCmd,
+	))
+}
+
+// resolveAddress - returns a copy of the host, which short name is replaced with the address from the resolver map.
+// The host keeps the short name, so it's displayed and stored as is.
+func (m *mainModel) resolveAddress(h hostModel.Host) hostModel.Host {
+	resolved := h.WithResolvedAddress(m.appState.ApplicationConfig.Resolver)
+	if resolved.Address != h.Address {
+		m.logger.Debug("[EXEC] Resolve address '%s' of host id: %d to '%s'", h.Address, h.ID, resolved.Address)
+	}
+
+	return resolved
+}
+
+// recordConnection - stores time of the connection, it's used to display recently used hosts. Returns a command
+// which reloads the host list, otherwise the list keeps outdated connection stats and saving the host from the list,
+// for instance when it's marked as favorite, overwrites them. Scratch host is never stored, so neither the
+// connection nor its result are recorded.
+func (m *mainModel) recordConnection(h hostModel.Host) tea.Cmd {
+	if h.IsScratch() {
+		m.logger.Debug("[EXEC] Connection to the scratch host is not recorded")
+		return nil
+	}
+
+	// Host which is passed from the host list may be outdated, only connection stats are applied to the stored host.
+	h, err := m.storedHost(h)
+	if err != nil {
+		m.logger.Error("[EXEC] Cannot read host id: %d. %v", h.ID, err)
+	}
+
+	h.LastConnected = time.Now()
+	h.ConnectCount++
+	m.connectedHost = &h
+	if _, err = m.hostStorage.Save(h); err != nil {
+		m.logger.Error("[EXEC] Cannot save connection time of host id: %d. %v", h.ID, err)
+		return nil
+	}
+
+	return message.TeaCmd(hostlist.MsgRefreshRepo{})
+}
+
+// storedHost - returns the most recent version of the host from the storage. The host itself is returned if it
+// cannot be read.
+func (m *mainModel) storedHost(h hostModel.Host) (hostModel.Host, error) {
+	stored, err := m.hostStorage.Get(h.ID)
+	if err != nil {
+		return h, err
+	}
+
+	return stored, nil
+}
+
+// recordConnectResult - stores result of the ssh session which was started by recordConnection.
+// Returns the saved host, false is returned if there was no ssh session.
+func (m *mainModel) recordConnectResult(result hostModel.ConnectResult) (hostModel.Host, bool) {
+	if m.connectedHost == nil {
+		m.logger.Debug("[EXEC] Connection result is not saved, ssh session was not started")
+		return hostModel.Host{}, false
+	}
+
+	h, err := m.storedHost(*m.connectedHost)
+	if err != nil {
+		m.logger.Error("[EXEC] Cannot read host id: %d. %v", h.ID, err)
+	}
+
+	m.connectedHost = nil
+	result.Time = time.Now()
+	h.LastConnectResult = result
+	m.logger.Debug("[EXEC] Save connection result of host id: %d. Exit code: %d", h.ID, result.ExitCode)
+	if _, err = m.hostStorage.Save(h); err != nil {
+		m.logger.Error("[EXEC] Cannot save connection result of host id: %d. %v", h.ID, err)
+	}
+
+	return h, true
+}
+
+// parsePassword - returns password from the output of password command. Password managers may print additional
+// data, only the first line is considered as a password.
+func parsePassword(output string) (string, error) {
+	password, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	password = strings.TrimSpace(password)
+	if password == "" {
+		return "", errors.New("password command returned an empty value")
+	}
+
+	return password, nil
+}
+
+// setPassword - passes password to sshpass through SSHPASS environment variable. The password is never persisted
+// and not a part of the command line.
+func setPassword(process *exec.Cmd, password string) {
+	if password == "" {
+		return
+	}
+
+	process.Env = append(processEnv(process), "SSHPASS="+password)
+}
+
+// setEnvFromFile - loads variables from the host environment file into the environment of ssh process, so they
+// affect ssh itself and commands which it runs, for instance ProxyCommand.
+func (m *mainModel) setEnvFromFile(process *exec.Cmd, envFile string) error {
+	if utils.StringEmpty(envFile) {
+		return nil
+	}
+
+	m.logger.Debug("[EXEC] Load environment variables from file: '%s'", envFile)
+	variables, err := utils.ReadEnvFile(envFile)
+	if err != nil {
+		return err
+	}
 
-	return m.dispatchProcess(constant.ProcessTypeSSHConnect, process, false, false)
+	process.Env = append(processEnv(process), variables...)
+	return nil
+}
+
+// setTermType - overrides TERM variable of ssh process, the variable is passed to the remote host when
+// a pseudo-terminal is allocated.
+func setTermType(process *exec.Cmd, termType string) {
+	termType = strings.TrimSpace(termType)
+	if termType == "" {
+		return
+	}
+
+	process.Env = append(processEnv(process), "TERM="+termType)
+}
+
+// processEnv - returns environment of the process, which is inherited from the application unless it was set already.
+func processEnv(process *exec.Cmd) []string {
+	if process.Env == nil {
+		return os.Environ()
+	}
+
+	return process.Env
 }
 
 func (m *mainModel) dispatchProcessSSHLoadConfig(msg message.RunProcessSSHLoadConfig) tea.Cmd {
 	m.logger.Debug("[EXEC] Read ssh configuration for host: %+v", msg.Host)
-	process := utils.BuildProcessInterceptStdAll(msg.Host.CmdSSHConfig())
+	// Parameters of ssh-copy-id are taken from ssh config, so they are resolved as well.
+	resolvedHost := m.resolveAddress(msg.Host)
+	process := utils.BuildProcessInterceptStdAll(resolvedHost.CmdSSHConfig())
 	m.logger.Info("[EXEC] Run process: '%s'", process.String())
 
 	// Should run in non-blocking fashion for ssh load config
@@ -264,9 +741,244 @@ func (m *mainModel) dispatchProcessSSHCopyID(msg message.RunProcessSSHCopyID) te
 	return m.dispatchProcess(constant.ProcessTypeSSHCopyID, process, false, false)
 }
 
+func (m *mainModel) dispatchProcessSSHFS(msg message.RunProcessSSHFS) tea.Cmd {
+	// sshfs requires mount point to exist.
+	mountPoint := utils.ExpandTilde(msg.MountPoint)
+	if err := os.MkdirAll(mountPoint, 0o700); err != nil {
+		m.logger.Error("[EXEC] Cannot create mount point '%s'. %v", mountPoint, err)
+		return message.TeaCmd(message.RunProcessErrorOccurred{
+			ProcessType: constant.ProcessTypeSSHFS,
+			StdErr:      fmt.Sprintf("Error:   cannot create mount point '%s'. %v", mountPoint, err),
+		})
+	}
+
+	resolvedHost := m.resolveAddress(msg.Host)
+	process := utils.BuildProcessInterceptStdErr(resolvedHost.CmdSSHFS(mountPoint))
+	m.logger.Info("[EXEC] Run process: '%s'", process.String())
+
+	// Runs in foreground, because user may be asked for a password.
+	return m.dispatchProcess(constant.ProcessTypeSSHFS, process, false, false)
+}
+
+// dispatchProcessSFTP - opens an interactive sftp session. Unlike ssh session, it's not counted as a connection
+// to the host and the password is never passed to sftp, so password command is not run either.
+func (m *mainModel) dispatchProcessSFTP(msg message.RunProcessSSHConnect) tea.Cmd {
+	connectHost := msg.Host.WithIdentityFile(msg.IdentityFile)
+	connectHost = m.resolveAddress(connectHost)
+	process := utils.BuildProcessInterceptStdErr(connectHost.CmdSFTP())
+	if err := m.setEnvFromFile(process, msg.Host.EnvFile); err != nil {
+		m.logger.Error("[EXEC] Cannot read environment file '%s'. %v", msg.Host.EnvFile, err)
+		return message.TeaCmd(message.RunProcessErrorOccurred{
+			ProcessType: constant.ProcessTypeSFTP,
+			StdErr:      fmt.Sprintf("Environment file: %s\nError:   %v", msg.Host.EnvFile, err),
+		})
+	}
+
+	m.logger.Info("[EXEC] Run process: '%s'", process.String())
+
+	// Runs in foreground, because sftp is interactive.
+	return m.dispatchProcess(constant.ProcessTypeSFTP, process, false, false)
+}
+
+// dispatchProcessSSHCheckAuth - logs in to the host without opening a shell, result is displayed in the host list.
+func (m *mainModel) dispatchProcessSSHCheckAuth(msg message.RunProcessSSHCheckAuth) tea.Cmd {
+	checkHost := msg.Host
+	// Authentication is checked through the same jump host and with the same environment as the connection.
+	if jumpHostID, jumpHost := m.appState.JumpHost(); jumpHost != "" && jumpHostID != msg.Host.ID {
+		m.logger.Debug("[EXEC] Jump through host '%s'", jumpHost)
+		checkHost = checkHost.WithJumpHost(jumpHost)
+	}
+	checkHost = m.resolveAddress(checkHost)
+	checkHost = checkHost.WithProxyJumpSelected()
+	process := utils.BuildProcessInterceptStdAll(checkHost.CmdSSHCheckAuth())
+	if err := m.setEnvFromFile(process, msg.Host.EnvFile); err != nil {
+		m.logger.Error("[EXEC] Cannot read environment file '%s'. %v", msg.Host.EnvFile, err)
+		reason := fmt.Sprintf("environment file %s: %v", msg.Host.EnvFile, err)
+		return message.TeaCmd(message.HostListNotify{Text: i18n.Tf("cannot check authentication: %s", reason)})
+	}
+
+	m.logger.Info("[EXEC] Run process: '%s'", process.String())
+
+	// Password prompts are disabled, so there is no need to suspend the UI.
+	return m.dispatchProcess(constant.ProcessTypeSSHCheckAuth, process, true, false)
+}
+
+func (m *mainModel) dispatchProcessOpenURL(msg message.RunProcessOpenURL) tea.Cmd {
+	process := utils.BuildProcessInterceptStdAll(utils.OpenURLCommand(msg.URL))
+	m.logger.Info("[EXEC] Run process: '%s'", process.String())
+
+	// Browser launcher exits immediately, no need to suspend the UI.
+	return m.dispatchProcess(constant.ProcessTypeOpenURL, process, true, false)
+}
+
+// notifyTunnelEstablished - displays a notification in the host list and on the desktop, because user may have
+// switched to another window while the tunnel was being established.
+func (m *mainModel) notifyTunnelEstablished(h hostModel.Host) tea.Cmd {
+	text := i18n.Tf("tunnel to %s is established", h.Title)
+	m.logger.Info("[EXEC] Background tunnel to host id: %d is established", h.ID)
+	notifyCmd := message.TeaCmd(message.HostListNotify{Text: text})
+
+	command := utils.DesktopNotifyCommand("goto", text)
+	if command == "" {
+		return notifyCmd
+	}
+
+	process := utils.BuildProcessInterceptStdAll(command)
+	m.logger.Info("[EXEC] Run process: '%s'", process.String())
+	// Desktop notification is optional, for instance notify-send may be not installed.
+	return tea.Batch(notifyCmd, m.dispatchProcess(constant.ProcessTypeDesktopNotify, process, true, true))
+}
+
+func (m *mainModel) dispatchProcessListAgentKeys() tea.Cmd {
+	process := utils.BuildProcessInterceptStdAll(ssh.ListAgentKeysCommand())
+	m.logger.Info("[EXEC] Run process: '%s'", process.String())
+
+	// ssh-add exits immediately, no need to suspend the UI.
+	return m.dispatchProcess(constant.ProcessTypeListAgentKeys, process, true, false)
+}
+
+// dispatchProcessProbeRemoteOS - detects operating system of the host which user has just disconnected from.
+func (m *mainModel) dispatchProcessProbeRemoteOS(h hostModel.Host) tea.Cmd {
+	m.probedHost = &h
+	resolvedHost := m.resolveAddress(h)
+	process := utils.BuildProcessInterceptStdAll(resolvedHost.CmdSSHProbeRemoteOS())
+	m.logger.Info("[EXEC] Run process: '%s'", process.String())
+
+	// Probe is optional, it must not bother user with errors.
+	return m.dispatchProcess(constant.ProcessTypeProbeRemoteOS, process, true, true)
+}
+
+// saveRemoteOS - stores operating system of the probed host and reloads the host list to display it.
+func (m *mainModel) saveRemoteOS(remoteOS string) tea.Cmd {
+	if m.probedHost == nil || remoteOS == "" {
+		m.logger.Debug("[EXEC] Remote operating system is not detected")
+		return nil
+	}
+
+	h, err := m.storedHost(*m.probedHost)
+	if err != nil {
+		m.logger.Error("[EXEC] Cannot read host id: %d. %v", h.ID, err)
+	}
+
+	m.probedHost = nil
+	if h.RemoteOS == remoteOS {
+		return nil
+	}
+
+	h.RemoteOS = remoteOS
+	m.logger.Debug("[EXEC] Save remote operating system '%s' of host id: %d", remoteOS, h.ID)
+	if _, err = m.hostStorage.Save(h); err != nil {
+		m.logger.Error("[EXEC] Cannot save remote operating system of host id: %d. %v", h.ID, err)
+		return nil
+	}
+
+	return message.TeaCmd(hostlist.MsgRefreshRepo{})
+}
+
+func (m *mainModel) dispatchProcessEditStorage() tea.Cmd {
+	fileStorage, ok := m.hostStorage.(storage.FileStorage)
+	if !ok {
+		m.logger.Error("[EXEC] Storage does not support manual editing")
+		return message.TeaCmd(message.RunProcessErrorOccurred{
+			ProcessType: constant.ProcessTypeEditStorage,
+			StdErr:      "Error:   storage does not support manual editing",
+		})
+	}
+
+	editor := lo.CoalesceOrEmpty(os.Getenv("EDITOR"), os.Getenv("VISUAL"))
+	process := utils.BuildProcess(editor)
+	if process == nil {
+		m.logger.Error("[EXEC] Cannot open hosts file. EDITOR environment variable is not set")
+		return message.TeaCmd(message.RunProcessErrorOccurred{
+			ProcessType: constant.ProcessTypeEditStorage,
+			StdErr:      "Error:   EDITOR environment variable is not set",
+		})
+	}
+
+	// Editor may contain arguments, for instance "code --wait", file path should be the last one.
+	process.Args = append(process.Args, fileStorage.FilePath())
+	// Missing file is not an error, it'll be created by the editor.
+	m.storageFileContent, _ = os.ReadFile(fileStorage.FilePath())
+	m.logger.Info("[EXEC] Run process: '%s'", process.String())
+
+	return m.dispatchProcess(constant.ProcessTypeEditStorage, process, false, false)
+}
+
+// storageFileChanged - returns true if hosts file was changed since it was opened in a text editor.
+func (m *mainModel) storageFileChanged() bool {
+	fileStorage, ok := m.hostStorage.(storage.FileStorage)
+	if !ok {
+		return false
+	}
+
+	content, err := os.ReadFile(fileStorage.FilePath())
+	if err != nil {
+		m.logger.Debug("[UI] Cannot read hosts file. %v", err)
+		return false
+	}
+
+	return !bytes.Equal(content, m.storageFileContent)
+}
+
 func (m *mainModel) handleProcessSuccess(msg message.RunProcessSuccess) tea.Cmd {
+	if msg.ProcessType == constant.ProcessTypeSSHConnect {
+		h, connected := m.recordConnectResult(hostModel.ConnectResult{})
+		// ssh exits as soon as the tunnel goes to background, there is no session to probe remote OS in.
+		if connected && h.BackgroundTunnel {
+			return tea.Batch(message.TeaCmd(hostlist.MsgRefreshRepo{}), m.notifyTunnelEstablished(h))
+		}
+
+		if connected && m.appState.ApplicationConfig.ProbeRemoteOS {
+			return m.dispatchProcessProbeRemoteOS(h)
+		}
+
+		if connected {
+			// Connection time is changed, the list is reloaded, because hosts can be sorted by it.
+			return message.TeaCmd(hostlist.MsgRefreshRepo{})
+		}
+
+		return nil
+	}
+
+	if msg.ProcessType == constant.ProcessTypeProbeRemoteOS {
+		return m.saveRemoteOS(ssh.ParseRemoteOS(msg.StdOut))
+	}
+
+	if msg.ProcessType == constant.ProcessTypeEditStorage {
+		changed := m.storageFileChanged()
+		m.storageFileContent = nil
+		m.logger.Debug("[EXEC] Hosts file edited. Changed: %v", changed)
+		if changed {
+			return message.TeaCmd(hostlist.MsgRefreshRepo{})
+		}
+
+		return nil
+	}
+
+	if msg.ProcessType == constant.ProcessTypeSSHCheckAuth {
+		m.logger.Debug("[EXEC] Authentication succeeded")
+		return message.TeaCmd(message.HostListNotify{Text: i18n.T("authentication succeeded")})
+	}
+
+	if msg.ProcessType == constant.ProcessTypeSSHFS {
+		m.logger.Debug("[EXEC] Remote file system mounted")
+		return message.TeaCmd(message.HostListNotify{Text: i18n.T("remote file system mounted")})
+	}
+
+	if msg.ProcessType == constant.ProcessTypeSFTP {
+		// User is returned to the host list, there's nothing to record or to probe.
+		m.logger.Debug("[EXEC] Sftp session closed")
+		return nil
+	}
+
+	if msg.ProcessType == constant.ProcessTypeListAgentKeys {
+		keys := ssh.ParseAgentKeys(msg.StdOut)
+		m.logger.Debug("[EXEC] ssh-agent keys loaded: %d", len(keys))
+		return message.TeaCmd(message.AgentKeysLoaded{Keys: keys})
+	}
+
 	if msg.ProcessType == constant.ProcessTypeSSHLoadConfig {
-		parsedSSHConfig := ssh.Parse(msg.StdOut)
+		parsedSSHConfig := ssh.ParseWithSources(msg.StdOut, ssh.DefaultConfigFiles()...)
 		m.logger.Debug("[EXEC] Host SSH config loaded: %+v", *parsedSSHConfig)
 		return message.TeaCmd(message.HostSSHConfigLoaded{
 			HostID: m.appState.Selected,
@@ -289,7 +1001,35 @@ func (m *mainModel) handleProcessSuccess(msg message.RunProcessSuccess) tea.Cmd
 	return nil
 }
 
-func (m *mainModel) handleProcessError(msg message.RunProcessErrorOccurred) {
+func (m *mainModel) handleProcessError(msg message.RunProcessErrorOccurred) tea.Cmd {
+	var refreshCmd tea.Cmd
+	if msg.ProcessType == constant.ProcessTypeSSHConnect {
+		result := hostModel.ConnectResult{ExitCode: msg.ExitCode, Error: msg.Reason}
+		if _, connected := m.recordConnectResult(result); connected {
+			// ssh returns exit code of the last remote command, so the list is reloaded after failed sessions as
+			// well, otherwise it keeps outdated connection stats of the host.
+			refreshCmd = message.TeaCmd(hostlist.MsgRefreshRepo{})
+		}
+	}
+
+	if msg.ProcessType == constant.ProcessTypeSSHConnect && ssh.IsPublicKeyDenied(msg.StdErr) {
+		// Instead of displaying the error, offer user to copy the key to the remote host.
+		m.logger.Debug("[EXEC] Public key was rejected by the remote host. Suggest ssh-copy-id")
+		m.appState.CurrentView = state.ViewHostList
+		return tea.Batch(refreshCmd, message.TeaCmd(message.SuggestSSHCopyID{}))
+	}
+
+	if msg.ProcessType == constant.ProcessTypeListAgentKeys {
+		// ssh-add exits with an error when the agent has no keys or is not running, it's not worth a separate screen.
+		m.logger.Debug("[EXEC] Cannot list ssh-agent keys. %s", msg.Reason)
+		reason := lo.CoalesceOrEmpty(msg.StdOut, msg.Reason)
+		return message.TeaCmd(message.HostListNotify{Text: strings.ToLower(strings.TrimSuffix(reason, "."))})
+	}
+
+	if msg.ProcessType == constant.ProcessTypeSSHCheckAuth {
+		return message.TeaCmd(message.HostListNotify{Text: authCheckNotification(msg)})
+	}
+
 	var errMsg string
 	if !utils.StringEmpty(msg.StdOut) {
 		errMsg = fmt.Sprintf("%s\nDetails: %s", msg.StdErr, msg.StdOut)
@@ -302,4 +1042,20 @@ func (m *mainModel) handleProcessError(msg message.RunProcessErrorOccurred) {
 	m.logger.Debug("[EXEC] External process error. %v", errMsg)
 	m.viewMessageContent = errMsg
 	m.appState.CurrentView = state.ViewMessage
+
+	return refreshCmd
+}
+
+// authCheckNotification - describes result of the authentication check which ssh process ended with an error.
+func authCheckNotification(msg message.RunProcessErrorOccurred) string {
+	reason := strings.ToLower(strings.TrimSuffix(msg.Reason, "."))
+	switch ssh.ParseAuthCheck(msg.ExitCode, msg.Reason) {
+	case ssh.AuthCheckOK:
+		// Remote command failed, but user was logged in.
+		return i18n.T("authentication succeeded")
+	case ssh.AuthCheckFailed:
+		return i18n.Tf("authentication failed: %s", reason)
+	default:
+		return i18n.Tf("cannot check authentication: %s", reason)
+	}
 }
@@ -2,18 +2,30 @@ package ui
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"os"
+	"path"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafviktor/goto/internal/constant"
+	"github.com/grafviktor/goto/internal/i18n"
+	hostModel "github.com/grafviktor/goto/internal/model/host"
 	"github.com/grafviktor/goto/internal/model/ssh"
 	"github.com/grafviktor/goto/internal/state"
+	hostStorage "github.com/grafviktor/goto/internal/storage"
 	"github.com/grafviktor/goto/internal/test"
+	"github.com/grafviktor/goto/internal/ui/component/dashboard"
+	"github.com/grafviktor/goto/internal/ui/component/hostlist"
 	"github.com/grafviktor/goto/internal/ui/message"
 	"github.com/grafviktor/goto/internal/utils"
 )
@@ -246,8 +258,900 @@ func TestHandleProcessError(t *testing.T) {
 	}
 }
 
+func TestHandleProcessError_PublicKeyDenied(t *testing.T) {
+	model := New(context.TODO(), test.NewMockStorage(true), MockAppState(), &test.MockLogger{})
+	msg := message.RunProcessErrorOccurred{
+		ProcessType: constant.ProcessTypeSSHConnect,
+		StdErr:      "Command: ssh localhost\nError:   root@localhost: Permission denied (publickey).",
+	}
+
+	// Instead of error message, user should be offered to copy the key
+	cmd := model.handleProcessError(msg)
+	require.NotNil(t, cmd)
+	require.Equal(t, message.SuggestSSHCopyID{}, cmd())
+	require.Empty(t, model.viewMessageContent)
+	require.Equal(t, state.ViewHostList, model.appState.CurrentView)
+
+	// The same error of other processes is displayed as is
+	msg.ProcessType = constant.ProcessTypeSSHCopyID
+	require.Nil(t, model.handleProcessError(msg))
+	require.Equal(t, state.ViewMessage, model.appState.CurrentView)
+}
+
+func TestUpdate_HelpOverlay(t *testing.T) {
+	// Test that help overlay displays all key bindings and any key brings user back to the previous screen
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), &test.MockLogger{})
+	model.appState.CurrentView = state.ViewEditItem
+	keyBindings := [][]key.Binding{
+		{
+			key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "mock action 1")),
+			key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "mock action 2")),
+		},
+		{
+			key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "mock action 3")),
+		},
+	}
+
+	model.Update(message.OpenHelpOverlay{KeyBindings: keyBindings})
+	require.Equal(t, state.ViewHelp, model.appState.CurrentView)
+
+	overlay := model.helpOverlayView()
+	for _, group := range keyBindings {
+		for _, binding := range group {
+			require.Contains(t, overlay, binding.Help().Key)
+			require.Contains(t, overlay, binding.Help().Desc)
+		}
+	}
+
+	// Help overlay must intercept key event and switch to the screen where it was opened from
+	model.handleKeyEvent(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	require.Equal(t, state.ViewEditItem, model.appState.CurrentView)
+	require.Nil(t, model.helpKeyBindings)
+}
+
+func TestHelpOverlayView_Translated(t *testing.T) {
+	i18n.SetLanguage("de")
+	t.Cleanup(func() { i18n.SetLanguage(i18n.DefaultLanguage) })
+
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), &test.MockLogger{})
+	overlay := model.helpOverlayView()
+	require.Contains(t, overlay, "Tastenkürzel")
+	require.Contains(t, overlay, "beliebige Taste zum Schließen drücken")
+
+	cmd := model.handleProcessSuccess(message.RunProcessSuccess{ProcessType: constant.ProcessTypeSSHFS})
+	require.Equal(t, message.HostListNotify{Text: "Entferntes Dateisystem eingehängt"}, cmd())
+}
+
+func TestUpdate_Dashboard(t *testing.T) {
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), &test.MockLogger{})
+
+	model.Update(message.OpenDashboard{})
+	require.Equal(t, state.ViewDashboard, model.appState.CurrentView)
+	require.Contains(t, model.modelDashboard.View(), "summary")
+
+	// Dashboard handles key events and closes itself on "esc"
+	_, cmd := model.handleKeyEvent(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Equal(t, dashboard.CloseDashboard{}, cmd())
+	model.Update(cmd())
+	require.Equal(t, state.ViewHostList, model.appState.CurrentView)
+
+	// Host list is reloaded when dead hosts are pruned
+	_, cmd = model.Update(dashboard.HostsPruned{})
+	require.Equal(t, hostlist.MsgRefreshRepo{}, cmd())
+}
+
+func TestDispatchProcessSSHConnect_Fast(t *testing.T) {
+	h := test.NewMockStorage(false).Hosts[0]
+	h.SSHClientConfig = &ssh.Config{Hostname: "config-hostname", Port: "22", User: "config-user"}
+
+	tests := []struct {
+		name     string
+		fast     bool
+		expected string
+	}{
+		{"Regular connect", false, h.CmdSSHConnect()},
+		{"Fast connect", true, h.CmdSSHFastConnect()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &test.MockLogger{}
+			model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), logger)
+			model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h, Fast: tt.fast}, "")
+
+			// Process is started with the expected command and ssh config is never loaded
+			require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(tt.expected).String()))
+			for _, log := range logger.Logs {
+				require.NotContains(t, log, "-G")
+			}
+		})
+	}
+}
+
+func TestDispatchProcessSSHConnect_ProxyJumpPool(t *testing.T) {
+	h := test.NewMockStorage(false).Hosts[0]
+	h.ProxyJumpPool = []string{"rotated-bastion1", "rotated-bastion2"}
+	h.ProxyJumpStrategy = hostModel.ProxyJumpRoundRobin
+	h.LogPath = "/var/log/syslog"
+
+	// Every connection moves on to the next jump host once, no matter which command is used
+	for i, msg := range []message.RunProcessSSHConnect{
+		{Host: h, Fast: true},
+		{Host: h, TailLog: true},
+		{Host: h},
+	} {
+		logger := &test.MockLogger{}
+		model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), logger)
+		model.dispatchProcessSSHConnect(msg, "")
+		jumpHost := fmt.Sprintf("-J rotated-bastion%d", i%2+1)
+		require.True(t, lo.SomeBy(logger.Logs, func(log string) bool { return strings.Contains(log, jumpHost) }))
+	}
+}
+
+func TestDispatchProcessSSHConnect_TailLog(t *testing.T) {
+	h := test.NewMockStorage(false).Hosts[0]
+	h.LogPath = "/var/log/syslog"
+
+	logger := &test.MockLogger{}
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), logger)
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h, TailLog: true}, "")
+
+	expected := utils.BuildProcess(h.CmdSSHTailLog()).String()
+	require.Contains(t, expected, "tail -f")
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", expected))
+}
+
+func TestDispatchProcessSSHConnect_Scratch(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	appState := MockAppState()
+	appState.ApplicationConfig.ProbeRemoteOS = true
+	scratch := hostModel.Host{ID: hostModel.ScratchHostID, Title: "scratch", Address: "scratch.example.com"}
+
+	logger := &test.MockLogger{}
+	model := New(context.TODO(), storage, appState, logger)
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: scratch}, "")
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(scratch.CmdSSHConnect()).String()))
+
+	// Connecting from scratch never touches the store, neither connection time, nor result are recorded
+	hostsCount := len(storage.Hosts)
+	cmd := model.handleProcessSuccess(message.RunProcessSuccess{ProcessType: constant.ProcessTypeSSHConnect})
+	require.Nil(t, cmd)
+	require.Nil(t, model.probedHost)
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: scratch}, "")
+	model.Update(message.RunProcessErrorOccurred{ProcessType: constant.ProcessTypeSSHConnect, ExitCode: 255})
+	require.Len(t, storage.Hosts, hostsCount)
+}
+
+func TestDispatchProcessSSHConnect_SFTP(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	h := storage.Hosts[0]
+
+	logger := &test.MockLogger{}
+	model := New(context.TODO(), storage, MockAppState(), logger)
+	model.Update(message.RunProcessSSHConnect{Host: h, SFTP: true})
+
+	expected := utils.BuildProcess(h.CmdSFTP()).String()
+	require.Contains(t, expected, "sftp -P 2222")
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", expected))
+
+	// Sftp session is not a connection, it's neither recorded, nor followed by remote OS probe
+	require.Nil(t, model.connectedHost)
+	require.Zero(t, storage.Hosts[0].ConnectCount)
+	model.appState.ApplicationConfig.ProbeRemoteOS = true
+	require.Nil(t, model.handleProcessSuccess(message.RunProcessSuccess{ProcessType: constant.ProcessTypeSFTP}))
+	require.Nil(t, model.probedHost)
+
+	// Background tunnel is not reported as established after sftp session
+	h.BackgroundTunnel = true
+	model.Update(message.RunProcessSSHConnect{Host: h, SFTP: true})
+	require.Nil(t, model.handleProcessSuccess(message.RunProcessSuccess{ProcessType: constant.ProcessTypeSFTP}))
+
+	// Password command is not run, because sftp doesn't use sshpass
+	originalRunner := passwordCommandRunner
+	defer func() { passwordCommandRunner = originalRunner }()
+	passwordCommandRunner = func(string, func(string, error) tea.Msg) tea.Cmd {
+		require.Fail(t, "password command must not be run")
+		return nil
+	}
+	h.PasswordCommand = "pass show test"
+	logger = &test.MockLogger{}
+	model = New(context.TODO(), storage, MockAppState(), logger)
+	model.Update(message.RunProcessSSHConnect{Host: h, SFTP: true})
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", expected))
+}
+
+func TestDispatchProcessSSHConnect_Profile(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	h := storage.Hosts[0]
+	h.Profiles = map[string]hostModel.Profile{"off-hours": {Address: "vpn.example.com", RemotePort: "2022"}}
+	profiled := h.WithProfile("off-hours")
+
+	logger := &test.MockLogger{}
+	model := New(context.TODO(), storage, MockAppState(), logger)
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h, Profile: "off-hours"}, "")
+
+	// Command is built using profile overrides
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(profiled.CmdSSHConnect()).String()))
+	// But overrides are not persisted when connection time is recorded
+	saved := storage.Hosts[len(storage.Hosts)-1]
+	require.Equal(t, h.Address, saved.Address)
+	require.Equal(t, h.RemotePort, saved.RemotePort)
+	require.False(t, saved.LastConnected.IsZero())
+}
+
+func TestDispatchProcessSSHConnect_Resolver(t *testing.T) {
+	storage := newHostStorage(t, hostModel.NewHost(0, "Mock Host 1", "", "db1", "root", "id_rsa", "2222", ""))
+	appState := MockAppState()
+	appState.ApplicationConfig.Resolver = map[string]string{"db1": "10.0.0.5"}
+	h := getHost(t, storage, 1)
+	resolved := h
+	resolved.Address = "10.0.0.5"
+
+	// Short name is resolved when the command is built
+	logger := &test.MockLogger{}
+	model := New(context.TODO(), storage, appState, logger)
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h}, "")
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(resolved.CmdSSHConnect()).String()))
+	// But the host keeps the short name, so it's displayed and stored as is
+	require.Equal(t, "db1", h.Address)
+	require.Equal(t, "db1", getHost(t, storage, h.ID).Address)
+
+	// Other commands are resolved too
+	model.dispatchProcessSSHLoadConfig(message.RunProcessSSHLoadConfig{Host: h})
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(resolved.CmdSSHConfig()).String()))
+	mountPoint := t.TempDir()
+	model.dispatchProcessSSHFS(message.RunProcessSSHFS{Host: h, MountPoint: mountPoint})
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(resolved.CmdSSHFS(mountPoint)).String()))
+
+	// Name which is not in the map is not resolved
+	h.Address = "db2"
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h}, "")
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(h.CmdSSHConnect()).String()))
+}
+
+func TestDispatchProcessSSHConnect_IdentityFile(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	h := storage.Hosts[0]
+	h.IdentityFiles = []string{"/home/user/.ssh/id_ed25519"}
+
+	logger := &test.MockLogger{}
+	model := New(context.TODO(), storage, MockAppState(), logger)
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h, IdentityFile: "/home/user/.ssh/id_ed25519"}, "")
+
+	// Command is built using the selected identity file
+	withIdentity := h.WithIdentityFile("/home/user/.ssh/id_ed25519")
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(withIdentity.CmdSSHConnect()).String()))
+	// But it does not replace identity file of the host
+	saved := storage.Hosts[len(storage.Hosts)-1]
+	require.Equal(t, h.IdentityFilePath, saved.IdentityFilePath)
+}
+
+func TestDispatchProcessSSHConnect_JumpHost(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	appState := MockAppState()
+	appState.SetJumpHost(storage.Hosts[1].ID, "root@localhost:2222")
+	h := storage.Hosts[0]
+
+	// Connection jumps through the host which is selected during the session
+	logger := &test.MockLogger{}
+	model := New(context.TODO(), storage, appState, logger)
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h}, "")
+	withJumpHost := h.WithJumpHost("root@localhost:2222")
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(withJumpHost.CmdSSHConnect()).String()))
+	// But jump host is not persisted
+	require.Empty(t, storage.Hosts[len(storage.Hosts)-1].ProxyJumpPool)
+
+	// Jump host which is defined by the host takes precedence
+	h.ProxyJumpPool = []string{"bastion"}
+	logger = &test.MockLogger{}
+	model = New(context.TODO(), storage, appState, logger)
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h}, "")
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(h.CmdSSHConnect()).String()))
+
+	// Jump host itself is connected directly
+	jumpHost := storage.Hosts[1]
+	logger = &test.MockLogger{}
+	model = New(context.TODO(), storage, appState, logger)
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: jumpHost}, "")
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(jumpHost.CmdSSHConnect()).String()))
+}
+
+func TestDispatchProcessSSHConnect_EchoCommand(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	h := storage.Hosts[0]
+	h.Password = "secret"
+
+	// Command is not displayed by default
+	model := New(context.TODO(), storage, MockAppState(), &test.MockLogger{})
+	var msgs []tea.Msg
+	test.CmdToMessage(model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h}, ""), &msgs)
+	for _, msg := range msgs {
+		require.NotEqual(t, reflect.TypeOf(message.HostListNotify{}), reflect.TypeOf(msg))
+	}
+
+	// Displayed command is the one which is run, except for the password
+	model.appState.ApplicationConfig.EchoCommand = true
+	msgs = nil
+	test.CmdToMessage(model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h}, ""), &msgs)
+	require.Contains(t, msgs, message.HostListNotify{Text: "sshpass -p '*****' ssh -i id_rsa -p 2222 -l root localhost"})
+	for _, msg := range msgs {
+		if notify, ok := msg.(message.HostListNotify); ok {
+			require.NotContains(t, notify.Text, "secret")
+			built := strings.Replace(h.CmdSSHConnect(), "cmd /c ", "", 1)
+			require.Equal(t, hostModel.RedactPassword(built, h.Password), notify.Text)
+		}
+	}
+}
+
+func TestRecordConnection(t *testing.T) {
+	storage := newHostStorage(t, hostModel.NewHost(0, "Mock Host 1", "", "localhost", "root", "id_rsa", "2222", ""))
+	model := New(context.TODO(), storage, MockAppState(), &test.MockLogger{})
+	connected := getHost(t, storage, 1)
+	require.True(t, connected.LastConnected.IsZero())
+
+	model.recordConnection(connected)
+	saved := getHost(t, storage, connected.ID)
+	require.False(t, saved.LastConnected.IsZero())
+	require.Equal(t, 1, saved.ConnectCount)
+
+	// Outdated copy of the host doesn't reset connection stats
+	connected.IsFavorite = true
+	model.recordConnection(connected)
+	saved = getHost(t, storage, connected.ID)
+	require.Equal(t, 2, saved.ConnectCount)
+	require.False(t, saved.IsFavorite)
+}
+
+func TestRecordConnection_AfterFailedSession(t *testing.T) {
+	storage := newHostStorage(t, hostModel.NewHost(0, "Mock Host 1", "", "localhost", "root", "id_rsa", "2222", ""))
+	model := New(context.TODO(), storage, MockAppState(), &test.MockLogger{})
+	// Host list keeps this copy of the host until it's reloaded
+	listed := getHost(t, storage, 1)
+
+	model.Update(message.RunProcessSSHConnect{Host: listed})
+	_, cmd := model.Update(message.RunProcessErrorOccurred{ProcessType: constant.ProcessTypeSSHConnect, ExitCode: 1})
+	var msgs []tea.Msg
+	test.CmdToMessage(cmd, &msgs)
+	require.Contains(t, msgs, hostlist.MsgRefreshRepo{})
+
+	model.Update(message.RunProcessSSHConnect{Host: listed})
+	saved := getHost(t, storage, listed.ID)
+	require.Equal(t, 2, saved.ConnectCount)
+	require.Equal(t, 1, saved.LastConnectResult.ExitCode)
+}
+
+func TestToggleFavorite_AfterFailedSession(t *testing.T) {
+	storage := newHostStorage(t, hostModel.NewHost(0, "Mock Host 1", "", "localhost", "root", "id_rsa", "2222", ""))
+	model := New(context.TODO(), storage, MockAppState(), &test.MockLogger{})
+	model.modelHostList.Init()
+	// Host list is reloaded whenever connection stats are recorded
+	updateHostList := func(cmd tea.Cmd) {
+		var msgs []tea.Msg
+		test.CmdToMessage(cmd, &msgs)
+		require.Contains(t, msgs, hostlist.MsgRefreshRepo{})
+		model.Update(hostlist.MsgRefreshRepo{})
+	}
+
+	updateHostList(model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: getHost(t, storage, 1)}, ""))
+	updateHostList(model.handleProcessError(message.RunProcessErrorOccurred{
+		ProcessType: constant.ProcessTypeSSHConnect,
+		Reason:      "Connection refused",
+		ExitCode:    255,
+	}))
+
+	// Host which is saved from the list keeps the result of the failed session
+	model.Update(hostlist.MsgToggleFavorite{HostID: 1})
+	saved := getHost(t, storage, 1)
+	require.True(t, saved.IsFavorite)
+	require.Equal(t, 1, saved.ConnectCount)
+	require.True(t, saved.LastConnectResult.Failed())
+	require.Equal(t, "Connection refused", saved.LastConnectResult.Error)
+}
+
+func TestToggleFavorite_AfterReachabilityScan(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, closedPort, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+
+	storage := newHostStorage(t, hostModel.NewHost(0, "Mock Host 1", "", "127.0.0.1", "root", "id_rsa", closedPort, ""))
+	model := New(context.TODO(), storage, MockAppState(), &test.MockLogger{})
+	model.modelHostList.Init()
+	model.modelDashboard.Init()
+	_, scanCmd := model.modelDashboard.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	scanResult := scanCmd()
+
+	// Scan completes after the dashboard is closed
+	model.Update(dashboard.CloseDashboard{})
+	_, cmd := model.Update(scanResult)
+	var msgs []tea.Msg
+	test.CmdToMessage(cmd, &msgs)
+	require.Contains(t, msgs, dashboard.FailedChecksChanged{})
+	_, cmd = model.Update(dashboard.FailedChecksChanged{})
+	require.Equal(t, hostlist.MsgRefreshRepo{}, cmd())
+	model.Update(hostlist.MsgRefreshRepo{})
+
+	// Host which is saved from the list keeps the counter
+	model.Update(hostlist.MsgToggleFavorite{HostID: 1})
+	saved := getHost(t, storage, 1)
+	require.True(t, saved.IsFavorite)
+	require.Equal(t, 1, saved.FailedChecks)
+}
+
+func TestUpdate_HostListSelectItem(t *testing.T) {
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), &test.MockLogger{})
+	model.Update(message.HostListSelectItem{HostID: 1})
+	require.Equal(t, 1, model.appState.Selected)
+	require.Equal(t, []int{1}, model.appState.RecentHosts)
+
+	// Only two most recently selected hosts are remembered
+	model.Update(message.HostListSelectItem{HostID: 2})
+	model.Update(message.HostListSelectItem{HostID: 3})
+	require.Equal(t, 3, model.appState.Selected)
+	require.Equal(t, []int{3, 2}, model.appState.RecentHosts)
+}
+
+func TestRecordConnectResult(t *testing.T) {
+	storage := newHostStorage(t,
+		hostModel.NewHost(0, "Mock Host 1", "", "localhost", "root", "id_rsa", "2222", ""),
+		hostModel.NewHost(0, "Mock Host 2", "", "localhost", "root", "id_rsa", "2222", ""),
+	)
+	model := New(context.TODO(), storage, MockAppState(), &test.MockLogger{})
+
+	// Failed session
+	model.recordConnection(getHost(t, storage, 1))
+	_, cmd := model.Update(message.RunProcessErrorOccurred{
+		ProcessType: constant.ProcessTypeSSHConnect,
+		StdErr:      "Command: ssh localhost\nError:   Connection refused",
+		Reason:      "Connection refused",
+		ExitCode:    255,
+	})
+	// Host list is reloaded to display the result
+	var msgs []tea.Msg
+	test.CmdToMessage(cmd, &msgs)
+	require.Contains(t, msgs, hostlist.MsgRefreshRepo{})
+	saved := getHost(t, storage, 1)
+	require.True(t, saved.LastConnectResult.Failed())
+	require.Equal(t, 255, saved.LastConnectResult.ExitCode)
+	require.Equal(t, "Connection refused", saved.LastConnectResult.Error)
+	require.False(t, saved.LastConnectResult.Time.IsZero())
+	// Connection time is preserved
+	require.False(t, saved.LastConnected.IsZero())
+
+	// Successful session
+	model.recordConnection(getHost(t, storage, 2))
+	_, cmd = model.Update(message.RunProcessSuccess{ProcessType: constant.ProcessTypeSSHConnect})
+	// Host list is reloaded to display the new connection time
+	require.Equal(t, hostlist.MsgRefreshRepo{}, cmd())
+	saved = getHost(t, storage, 2)
+	require.False(t, saved.LastConnectResult.Failed())
+	require.False(t, saved.LastConnectResult.Time.IsZero())
+
+	// Result of other processes is not recorded
+	_, cmd = model.Update(message.RunProcessErrorOccurred{ProcessType: constant.ProcessTypeSSHCopyID, ExitCode: 1})
+	msgs = nil
+	test.CmdToMessage(cmd, &msgs)
+	require.NotContains(t, msgs, hostlist.MsgRefreshRepo{})
+	require.Equal(t, saved, getHost(t, storage, 2))
+}
+
+func TestProbeRemoteOS(t *testing.T) {
+	storage := newHostStorage(t, hostModel.NewHost(0, "Mock Host 1", "", "localhost", "root", "id_rsa", "2222", ""))
+	appState := MockAppState()
+	model := New(context.TODO(), storage, appState, &test.MockLogger{})
+
+	// Probe is disabled by default, only the host list is reloaded
+	model.recordConnection(getHost(t, storage, 1))
+	cmd := model.handleProcessSuccess(message.RunProcessSuccess{ProcessType: constant.ProcessTypeSSHConnect})
+	require.Equal(t, hostlist.MsgRefreshRepo{}, cmd())
+	require.Nil(t, model.probedHost)
+
+	appState.ApplicationConfig.ProbeRemoteOS = true
+	model.recordConnection(getHost(t, storage, 1))
+	cmd = model.handleProcessSuccess(message.RunProcessSuccess{ProcessType: constant.ProcessTypeSSHConnect})
+	require.NotNil(t, cmd)
+	require.NotNil(t, model.probedHost)
+	require.Equal(t, 1, model.probedHost.ID)
+
+	cmd = model.handleProcessSuccess(message.RunProcessSuccess{
+		ProcessType: constant.ProcessTypeProbeRemoteOS,
+		StdOut:      "Linux",
+	})
+	require.Equal(t, hostlist.MsgRefreshRepo{}, cmd())
+	saved := getHost(t, storage, 1)
+	require.Equal(t, ssh.RemoteOSLinux, saved.RemoteOS)
+	// Connection result is preserved
+	require.False(t, saved.LastConnectResult.Time.IsZero())
+	require.Nil(t, model.probedHost)
+
+	// Empty output is ignored
+	model.probedHost = &saved
+	cmd = model.handleProcessSuccess(message.RunProcessSuccess{ProcessType: constant.ProcessTypeProbeRemoteOS})
+	require.Nil(t, cmd)
+}
+
+func TestBackgroundTunnel(t *testing.T) {
+	h := hostModel.NewHost(0, "Mock Host 1", "", "localhost", "root", "id_rsa", "2222", "")
+	h.BackgroundTunnel = true
+	storage := newHostStorage(t, h)
+	appState := MockAppState()
+	appState.ApplicationConfig.ProbeRemoteOS = true
+	h = getHost(t, storage, 1)
+
+	logger := &test.MockLogger{}
+	model := New(context.TODO(), storage, appState, logger)
+	model.dispatchProcessSSHConnect(message.RunProcessSSHConnect{Host: h}, "")
+	expected := utils.BuildProcess(h.CmdSSHConnect()).String()
+	require.Contains(t, expected, "-f -N -T localhost")
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", expected))
+
+	// Remote OS is not probed, user is notified that the tunnel is established instead
+	cmd := model.handleProcessSuccess(message.RunProcessSuccess{ProcessType: constant.ProcessTypeSSHConnect})
+	require.Nil(t, model.probedHost)
+	batch := cmd().(tea.BatchMsg)
+	require.Equal(t, hostlist.MsgRefreshRepo{}, batch[0]())
+	notification := batch[1]()
+	if desktopNotification, ok := notification.(tea.BatchMsg); ok {
+		notification = desktopNotification[0]()
+	}
+	require.Equal(t, message.HostListNotify{Text: "tunnel to Mock Host 1 is established"}, notification)
+}
+
+func TestAutoConnect(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	storage.Hosts[0].AutoConnect = true
+	storage.Hosts[0].BackgroundTunnel = true
+	// Host which opens an interactive shell is not connected on app start
+	storage.Hosts[1].AutoConnect = true
+	storage.Hosts[2].AutoConnect = true
+	storage.Hosts[2].BackgroundTunnel = true
+
+	model := New(context.TODO(), storage, MockAppState(), &test.MockLogger{})
+	require.Equal(t, message.RunProcessSSHConnect{Host: storage.Hosts[0]}, model.autoConnect()())
+	require.Len(t, model.autoConnectQueue, 1)
+
+	// Next tunnel is established even if the previous one failed
+	model.Update(message.RunProcessErrorOccurred{ProcessType: constant.ProcessTypeSSHConnect})
+	require.Empty(t, model.autoConnectQueue)
+	require.Nil(t, model.connectNextAutoConnectHost())
+}
+
+func TestAutoConnect_SkipUnconfirmed(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	_, busyPort, _ := net.SplitHostPort(listener.Addr().String())
+
+	storage := test.NewMockStorage(false)
+	storage.Hosts[0].AutoConnect = true
+	storage.Hosts[0].BackgroundTunnel = true
+	storage.Hosts[0].LocalForwards = []string{busyPort + ":localhost:80"}
+	storage.Hosts[1].AutoConnect = true
+	storage.Hosts[1].BackgroundTunnel = true
+
+	// Host list can't ask user whether to connect anyway, so the tunnel is skipped and the next one is established
+	model := New(context.TODO(), storage, MockAppState(), &test.MockLogger{})
+	var msgs []tea.Msg
+	test.CmdToMessage(model.autoConnect(), &msgs)
+	require.Equal(t, []tea.Msg{
+		message.HostListNotify{Text: fmt.Sprintf(
+			"tunnel to Mock Host 1 is not established, local ports are in use: 127.0.0.1:%s", busyPort,
+		)},
+		message.RunProcessSSHConnect{Host: storage.Hosts[1]},
+	}, msgs)
+
+	// Mock hosts connect to localhost
+	appState := MockAppState()
+	appState.ApplicationConfig.WarnLoopback = true
+	model = New(context.TODO(), storage, appState, &test.MockLogger{})
+	msgs = nil
+	test.CmdToMessage(model.autoConnect(), &msgs)
+	require.Equal(t, []tea.Msg{
+		message.HostListNotify{Text: "tunnel to Mock Host 1 is not established, host connects to the local machine"},
+		message.HostListNotify{Text: "tunnel to Mock Host 2 is not established, host connects to the local machine"},
+	}, msgs)
+}
+
+func TestParsePassword(t *testing.T) {
+	tests := []struct {
+		name          string
+		output        string
+		expected      string
+		expectedError bool
+	}{
+		{"Password is taken from the first line of the output", "  secret  \nlogin: root\n", "secret", false},
+		{"Empty output", "\n", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			password, err := parsePassword(tt.output)
+			require.Equal(t, tt.expectedError, err != nil)
+			require.Equal(t, tt.expected, password)
+		})
+	}
+}
+
+func TestPasswordCommandResult(t *testing.T) {
+	output, err := passwordCommandResult([]byte("secret\n"), []byte("unlocked"), nil)
+	require.NoError(t, err)
+	require.Equal(t, "secret\n", output)
+
+	// Text which the command printed to stderr explains the exit status
+	exitErr := errors.New("exit status 1")
+	_, err = passwordCommandResult(nil, []byte("gpg: decryption failed: No secret key\n"), exitErr)
+	require.ErrorIs(t, err, exitErr)
+	require.EqualError(t, err, "exit status 1: gpg: decryption failed: No secret key")
+
+	_, err = passwordCommandResult(nil, nil, exitErr)
+	require.Equal(t, exitErr, err)
+}
+
+func TestReadPasswordAndConnect(t *testing.T) {
+	originalRunner := passwordCommandRunner
+	defer func() { passwordCommandRunner = originalRunner }()
+
+	tests := []struct {
+		name          string
+		command       string
+		runnerOutput  string
+		runnerError   error
+		expectedError string
+	}{
+		{name: "Password command is not set"},
+		{name: "Password is read", command: "pass show servers/db", runnerOutput: "secret\n"},
+		{
+			name:          "Password command fails",
+			command:       "pass show servers/db",
+			runnerError:   errors.New("exit status 1: pass: store is locked"),
+			expectedError: "Command: pass show servers/db\nError:   exit status 1: pass: store is locked",
+		},
+		{
+			name:          "Password command returns empty output",
+			command:       "pass show servers/db",
+			runnerOutput:  "\n",
+			expectedError: "Command: pass show servers/db\nError:   password command returned an empty value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var invokedWith string
+			passwordCommandRunner = func(command string, onExit func(string, error) tea.Msg) tea.Cmd {
+				invokedWith = command
+				return func() tea.Msg { return onExit(tt.runnerOutput, tt.runnerError) }
+			}
+
+			storage := test.NewMockStorage(false)
+			h := storage.Hosts[0]
+			h.PasswordCommand = tt.command
+			logger := &test.MockLogger{}
+			model := New(context.TODO(), storage, MockAppState(), logger)
+			runProcessLog := fmt.Sprintf("[EXEC] Run process: '%s'", utils.BuildProcess(h.CmdSSHConnect()).String())
+
+			cmd := model.readPasswordAndConnect(message.RunProcessSSHConnect{Host: h})
+			require.Equal(t, tt.command, invokedWith)
+			if tt.command == "" {
+				require.Contains(t, logger.Logs, runProcessLog)
+				return
+			}
+
+			// Connection is established only when password command exits, the UI is not blocked while it runs
+			require.NotContains(t, logger.Logs, runProcessLog)
+			passwordRead := cmd()
+			require.IsType(t, msgPasswordRead{}, passwordRead)
+			_, cmd = model.Update(passwordRead)
+			if tt.expectedError == "" {
+				require.Contains(t, logger.Logs, runProcessLog)
+				return
+			}
+
+			require.NotContains(t, logger.Logs, runProcessLog)
+			var msgs []tea.Msg
+			test.CmdToMessage(cmd, &msgs)
+			processError, found := lo.Find(msgs, func(msg tea.Msg) bool {
+				_, ok := msg.(message.RunProcessErrorOccurred)
+				return ok
+			})
+			require.True(t, found)
+			require.Equal(t, tt.expectedError, processError.(message.RunProcessErrorOccurred).StdErr)
+		})
+	}
+}
+
+func TestSetPassword(t *testing.T) {
+	process := utils.BuildProcess("ssh localhost")
+	setPassword(process, "")
+	require.Nil(t, process.Env)
+
+	setPassword(process, "secret")
+	require.Contains(t, process.Env, "SSHPASS=secret")
+	// Application environment is inherited
+	require.Greater(t, len(process.Env), 1)
+}
+
+func TestSetEnvFromFile(t *testing.T) {
+	envFile := path.Join(t.TempDir(), "staging.env")
+	require.NoError(t, os.WriteFile(envFile, []byte("# comment\nAWS_PROFILE=staging\n"), 0o600))
+
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), &test.MockLogger{})
+	process := utils.BuildProcess("ssh localhost")
+	require.NoError(t, model.setEnvFromFile(process, ""))
+	require.Nil(t, process.Env)
+
+	require.NoError(t, model.setEnvFromFile(process, envFile))
+	require.Contains(t, process.Env, "AWS_PROFILE=staging")
+	// Application environment is inherited
+	require.Greater(t, len(process.Env), 1)
+
+	// Password is added on top of the variables from the file
+	setPassword(process, "secret")
+	require.Contains(t, process.Env, "AWS_PROFILE=staging")
+	require.Contains(t, process.Env, "SSHPASS=secret")
+
+	require.Error(t, model.setEnvFromFile(process, path.Join(t.TempDir(), "missing.env")))
+}
+
+func TestSetTermType(t *testing.T) {
+	envFile := path.Join(t.TempDir(), "staging.env")
+	require.NoError(t, os.WriteFile(envFile, []byte("TERM=dumb\n"), 0o600))
+
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), &test.MockLogger{})
+	process := utils.BuildProcess("ssh localhost")
+	setTermType(process, " ")
+	require.Nil(t, process.Env)
+
+	// Terminal type overrides the variable from the environment file, the last value wins
+	require.NoError(t, model.setEnvFromFile(process, envFile))
+	setTermType(process, "xterm-256color")
+	require.Equal(t, "TERM=xterm-256color", process.Env[len(process.Env)-1])
+	require.Contains(t, process.Env, "TERM=dumb")
+}
+
 // ---------------------------------
 
 func MockAppState() *state.ApplicationState {
 	return &state.ApplicationState{}
 }
+
+// newHostStorage - returns a storage in a temporary folder, unlike the mock storage it replaces hosts when they're
+// saved, so hosts can be read back by id.
+func newHostStorage(t *testing.T, hosts ...hostModel.Host) hostStorage.HostStorage {
+	t.Helper()
+
+	repo, err := hostStorage.NewYAML(context.TODO(), t.TempDir(), &test.MockLogger{})
+	require.NoError(t, err)
+	for _, h := range hosts {
+		_, err = repo.Save(h)
+		require.NoError(t, err)
+	}
+
+	return repo
+}
+
+func getHost(t *testing.T, repo hostStorage.HostStorage, id int) hostModel.Host {
+	t.Helper()
+
+	h, err := repo.Get(id)
+	require.NoError(t, err)
+
+	return h
+}
+
+func TestHandleProcessSuccess_SSHFS(t *testing.T) {
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), &test.MockLogger{})
+	cmd := model.handleProcessSuccess(message.RunProcessSuccess{ProcessType: constant.ProcessTypeSSHFS})
+	require.NotNil(t, cmd)
+	require.Equal(t, message.HostListNotify{Text: "remote file system mounted"}, cmd())
+}
+
+func TestHandleProcess_SSHCheckAuth(t *testing.T) {
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), &test.MockLogger{})
+	cmd := model.handleProcessSuccess(message.RunProcessSuccess{ProcessType: constant.ProcessTypeSSHCheckAuth})
+	require.Equal(t, message.HostListNotify{Text: "authentication succeeded"}, cmd())
+
+	cmd = model.handleProcessError(message.RunProcessErrorOccurred{
+		ProcessType: constant.ProcessTypeSSHCheckAuth,
+		Reason:      "root@localhost: Permission denied (publickey).",
+		ExitCode:    255,
+	})
+	require.Equal(t, message.HostListNotify{
+		Text: "authentication failed: root@localhost: permission denied (publickey)",
+	}, cmd())
+	// Result is displayed in the host list title instead of a separate screen
+	require.NotEqual(t, state.ViewMessage, model.appState.CurrentView)
+
+	cmd = model.handleProcessError(message.RunProcessErrorOccurred{
+		ProcessType: constant.ProcessTypeSSHCheckAuth,
+		Reason:      "ssh: connect to host localhost port 22: Connection refused",
+		ExitCode:    255,
+	})
+	require.Equal(t, message.HostListNotify{
+		Text: "cannot check authentication: ssh: connect to host localhost port 22: connection refused",
+	}, cmd())
+}
+
+func TestDispatchProcessSSHCheckAuth(t *testing.T) {
+	storage := test.NewMockStorage(false)
+	appState := MockAppState()
+	appState.SetJumpHost(storage.Hosts[1].ID, "root@localhost:2222")
+	h := storage.Hosts[0]
+
+	// Authentication is checked through the jump host of the session, same as the connection
+	logger := &test.MockLogger{}
+	model := New(context.TODO(), storage, appState, logger)
+	model.dispatchProcessSSHCheckAuth(message.RunProcessSSHCheckAuth{Host: h})
+	withJumpHost := h.WithJumpHost("root@localhost:2222")
+	expected := utils.BuildProcessInterceptStdAll(withJumpHost.CmdSSHCheckAuth()).String()
+	require.Contains(t, logger.Logs, fmt.Sprintf("[EXEC] Run process: '%s'", expected))
+
+	// Environment file which cannot be read is reported in the host list title
+	h.EnvFile = "/nonexistent/goto.env"
+	cmd := model.dispatchProcessSSHCheckAuth(message.RunProcessSSHCheckAuth{Host: h})
+	notify, ok := cmd().(message.HostListNotify)
+	require.True(t, ok)
+	require.Contains(t, notify.Text, "cannot check authentication: environment file /nonexistent/goto.env")
+}
+
+func TestHandleProcess_ListAgentKeys(t *testing.T) {
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), &test.MockLogger{})
+	cmd := model.handleProcessSuccess(message.RunProcessSuccess{
+		ProcessType: constant.ProcessTypeListAgentKeys,
+		StdOut:      "256 SHA256:abc /home/user/.ssh/id_ed25519 (ED25519)",
+	})
+	require.Equal(t, message.AgentKeysLoaded{Keys: []ssh.AgentKey{
+		{Bits: 256, Fingerprint: "SHA256:abc", Comment: "/home/user/.ssh/id_ed25519", Type: "ED25519"},
+	}}, cmd())
+
+	// ssh-add exits with code 1 when the agent has no keys, the message is displayed in the host list title
+	cmd = model.handleProcessError(message.RunProcessErrorOccurred{
+		ProcessType: constant.ProcessTypeListAgentKeys,
+		StdOut:      "The agent has no identities.",
+		ExitCode:    1,
+	})
+	require.Equal(t, message.HostListNotify{Text: "the agent has no identities"}, cmd())
+	require.NotEqual(t, state.ViewMessage, model.appState.CurrentView)
+}
+
+func TestHandleProcessSuccess_EditStorage(t *testing.T) {
+	hostsFile := path.Join(t.TempDir(), "hosts.yaml")
+	require.NoError(t, os.WriteFile(hostsFile, []byte("- host:\n    title: test\n"), 0o600))
+
+	storage := test.NewMockStorage(false)
+	storage.StorageFile = hostsFile
+	model := New(context.TODO(), storage, MockAppState(), &test.MockLogger{})
+	editSuccess := message.RunProcessSuccess{ProcessType: constant.ProcessTypeEditStorage}
+
+	// File is not changed - hosts should not be reloaded
+	model.storageFileContent, _ = os.ReadFile(hostsFile)
+	require.False(t, model.storageFileChanged())
+	require.Nil(t, model.handleProcessSuccess(editSuccess))
+
+	// File is changed - hosts should be reloaded
+	model.storageFileContent, _ = os.ReadFile(hostsFile)
+	require.NoError(t, os.WriteFile(hostsFile, []byte("- host:\n    title: changed\n"), 0o600))
+	require.True(t, model.storageFileChanged())
+	cmd := model.handleProcessSuccess(editSuccess)
+	require.NotNil(t, cmd)
+	require.Equal(t, hostlist.MsgRefreshRepo{}, cmd())
+
+	// File was created by the editor
+	model.storageFileContent = nil
+	require.True(t, model.storageFileChanged())
+
+	// File was deleted by the editor - nothing to reload
+	require.NoError(t, os.Remove(hostsFile))
+	require.False(t, model.storageFileChanged())
+}
+
+func TestDispatchProcessEditStorage_NoEditor(t *testing.T) {
+	t.Setenv("EDITOR", "")
+	t.Setenv("VISUAL", "")
+
+	model := New(context.TODO(), test.NewMockStorage(false), MockAppState(), &test.MockLogger{})
+	cmd := model.dispatchProcessEditStorage()
+	require.Equal(t, message.RunProcessErrorOccurred{
+		ProcessType: constant.ProcessTypeEditStorage,
+		StdErr:      "Error:   EDITOR environment variable is not set",
+	}, cmd())
+}
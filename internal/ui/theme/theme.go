@@ -0,0 +1,124 @@
+// Package theme contains color palettes of the user interface.
+package theme
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultTheme - is the palette which is used when the theme is not set or not found.
+const DefaultTheme = "default"
+
+// Palette contains colors which are used by styles of the user interface components.
+type Palette struct {
+	// Accent is used for borders and descriptions of the focused item.
+	Accent lipgloss.AdaptiveColor
+	// Highlight is used for titles of the focused item and for the focused input.
+	Highlight lipgloss.AdaptiveColor
+	// TitleBackground and TitleForeground are colors of the screen title.
+	TitleBackground lipgloss.AdaptiveColor
+	TitleForeground lipgloss.AdaptiveColor
+	// Muted is used for descriptions, hints and separators.
+	Muted lipgloss.AdaptiveColor
+	// Error and Warning are used for input labels which didn't pass validation.
+	Error   lipgloss.AdaptiveColor
+	Warning lipgloss.AdaptiveColor
+}
+
+var (
+	muted    = lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"}
+	red      = lipgloss.AdaptiveColor{Light: "#FF7783", Dark: "#FF7783"}
+	orange   = lipgloss.AdaptiveColor{Light: "#D7875F", Dark: "#FFAF5F"}
+	white    = lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#FFFFFF"}
+	lemon    = lipgloss.AdaptiveColor{Light: "#FFFFD7", Dark: "#FFFFD7"}
+	palettes = map[string]Palette{
+		DefaultTheme: {
+			Accent:          lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"},
+			Highlight:       lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"},
+			TitleBackground: lipgloss.AdaptiveColor{Light: "#5F5FD7", Dark: "#5F5FD7"},
+			TitleForeground: lemon,
+			Muted:           muted,
+			Error:           red,
+			Warning:         orange,
+		},
+		"ocean": {
+			Accent:          lipgloss.AdaptiveColor{Light: "#0087D7", Dark: "#5FAFFF"},
+			Highlight:       lipgloss.AdaptiveColor{Light: "#005F87", Dark: "#87D7FF"},
+			TitleBackground: lipgloss.AdaptiveColor{Light: "#005F87", Dark: "#005F87"},
+			TitleForeground: white,
+			Muted:           muted,
+			Error:           red,
+			Warning:         orange,
+		},
+		"forest": {
+			Accent:          lipgloss.AdaptiveColor{Light: "#5F8700", Dark: "#87AF5F"},
+			Highlight:       lipgloss.AdaptiveColor{Light: "#3A5F0B", Dark: "#AFD787"},
+			TitleBackground: lipgloss.AdaptiveColor{Light: "#3A5F0B", Dark: "#3A5F0B"},
+			TitleForeground: lemon,
+			Muted:           muted,
+			Error:           red,
+			Warning:         orange,
+		},
+		// Monochrome palette is for terminals where colors are hard to read, for instance in presentations.
+		"mono": {
+			Accent:          lipgloss.AdaptiveColor{Light: "#4E4E4E", Dark: "#BCBCBC"},
+			Highlight:       lipgloss.AdaptiveColor{Light: "#000000", Dark: "#FFFFFF"},
+			TitleBackground: lipgloss.AdaptiveColor{Light: "#303030", Dark: "#D0D0D0"},
+			TitleForeground: lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#000000"},
+			Muted:           muted,
+			Error:           red,
+			Warning:         orange,
+		},
+	}
+)
+
+var (
+	mu      sync.RWMutex
+	current = DefaultTheme
+)
+
+// Set - selects the palette. Empty name selects the default palette. Returns false if there is no palette
+// with this name, in that case the default palette is used.
+func Set(name string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	if _, ok := palettes[name]; !ok {
+		current = DefaultTheme
+		return name == ""
+	}
+
+	current = name
+	return true
+}
+
+// Name - returns name of the selected palette.
+func Name() string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return current
+}
+
+// Current - returns colors of the selected palette.
+func Current() Palette {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return palettes[current]
+}
+
+// Names - returns names of all palettes sorted alphabetically.
+func Names() []string {
+	names := make([]string, 0, len(palettes))
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
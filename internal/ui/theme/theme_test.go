@@ -0,0 +1,31 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	t.Cleanup(func() { Set(DefaultTheme) })
+
+	require.True(t, Set("ocean"))
+	require.Equal(t, "ocean", Name())
+	require.True(t, Set(" Forest "))
+	require.Equal(t, "forest", Name())
+	require.NotEqual(t, palettes[DefaultTheme], Current())
+
+	// Empty name selects the default palette
+	require.True(t, Set(""))
+	require.Equal(t, DefaultTheme, Name())
+
+	// Unknown palette falls back to the default one
+	Set("ocean")
+	require.False(t, Set("solarized"))
+	require.Equal(t, DefaultTheme, Name())
+	require.Equal(t, palettes[DefaultTheme], Current())
+}
+
+func TestNames(t *testing.T) {
+	require.Equal(t, []string{"default", "forest", "mono", "ocean"}, Names())
+}